@@ -15,6 +15,12 @@ const (
 	HTTPAlarmCallbackType = "org.graylog2.alarmcallbacks.HTTPAlarmCallback"
 	// SlackAlarmCallbackType is a type of SlackAlarmCallback.
 	SlackAlarmCallbackType = "org.graylog2.plugins.slack.callback.SlackAlarmCallback"
+	// PagerDutyAlarmCallbackType is a type of PagerDutyAlarmCallback.
+	PagerDutyAlarmCallbackType = "org.graylog.plugins.pagerduty.PagerDutyAlarmCallback"
+	// ScriptAlarmCallbackType is a type of ScriptAlarmCallback.
+	ScriptAlarmCallbackType = "org.graylog.integrations.alarmcallbacks.ScriptAlarmCallback"
+	// TeamsAlarmCallbackType is a type of TeamsAlarmCallback.
+	TeamsAlarmCallbackType = "org.graylog2.plugins.teams.callback.TeamsAlarmCallback"
 )
 
 type (
@@ -67,6 +73,37 @@ type (
 		NotifyChannel bool   `json:"notify_channel"`
 	}
 
+	// PagerDutyAlarmCallbackConfiguration represents a configuration of PagerDutyAlarmCallback.
+	// https://github.com/graylog-labs/graylog-plugin-pagerduty
+	PagerDutyAlarmCallbackConfiguration struct {
+		ServiceKey     string `json:"service_key" v-create:"required"`
+		ClientName     string `json:"client_name,omitempty"`
+		ClientURL      string `json:"client_url,omitempty"`
+		KeyPrefix      string `json:"key_prefix,omitempty"`
+		CustomIncident bool   `json:"custom_incident"`
+	}
+
+	// ScriptAlarmCallbackConfiguration represents a configuration of ScriptAlarmCallback.
+	// ScriptAlarmCallback is provided by Graylog Integrations plugin.
+	ScriptAlarmCallbackConfiguration struct {
+		ScriptPath      string `json:"script_path" v-create:"required"`
+		ScriptArgs      string `json:"script_args,omitempty"`
+		ScriptTimeout   int    `json:"script_timeout,omitempty"`
+		ScriptSendStdin bool   `json:"script_send_stdin"`
+	}
+
+	// TeamsAlarmCallbackConfiguration represents a configuration of TeamsAlarmCallback.
+	// Note that TeamsAlarmCallback is a third party plugin and not an official alarm callback.
+	TeamsAlarmCallbackConfiguration struct {
+		WebhookURL    string `json:"webhook_url" v-create:"required"`
+		Color         string `json:"color,omitempty"`
+		Graylog2URL   string `json:"graylog2_url,omitempty"`
+		IconURL       string `json:"icon_url,omitempty"`
+		ProxyAddress  string `json:"proxy_address,omitempty"`
+		CustomMessage string `json:"custom_message,omitempty"`
+		BacklogItems  int    `json:"backlog_items,omitempty"`
+	}
+
 	// AlarmCallbacksBody represents Get Alarm Callbacks API's response body.
 	// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
 	AlarmCallbacksBody struct {
@@ -74,6 +111,26 @@ type (
 		Total          int             `json:"total"`
 	}
 
+	// AlarmCallbackTestResult represents the result of testing an alarm callback.
+	// If the callback fails, Success is false and Message is the callback's error message.
+	AlarmCallbackTestResult struct {
+		Success bool   `json:"success"`
+		Message string `json:"message,omitempty"`
+	}
+
+	// AlarmCallbackTypeInfo represents an available alarm callback type.
+	AlarmCallbackTypeInfo struct {
+		Name                   string                        `json:"name"`
+		LinkToDocs             string                        `json:"link_to_docs,omitempty"`
+		RequestedConfiguration map[string]ConfigurationField `json:"requested_configuration"`
+	}
+
+	// AlarmCallbackTypesBody represents Get Alarm Callback Types API's response body.
+	// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+	AlarmCallbackTypesBody struct {
+		Types map[string]AlarmCallbackTypeInfo `json:"types"`
+	}
+
 	// GeneralAlarmCallbackConfiguration is a general third party's AlarmCallbackConfiguration.
 	GeneralAlarmCallbackConfiguration struct {
 		Type          string                 `json:"type"`
@@ -119,6 +176,21 @@ func (ac *SlackAlarmCallbackConfiguration) AlarmCallbackType() string {
 	return SlackAlarmCallbackType
 }
 
+// AlarmCallbackType returns an alarm callback type.
+func (ac *PagerDutyAlarmCallbackConfiguration) AlarmCallbackType() string {
+	return PagerDutyAlarmCallbackType
+}
+
+// AlarmCallbackType returns an alarm callback type.
+func (ac *ScriptAlarmCallbackConfiguration) AlarmCallbackType() string {
+	return ScriptAlarmCallbackType
+}
+
+// AlarmCallbackType returns an alarm callback type.
+func (ac *TeamsAlarmCallbackConfiguration) AlarmCallbackType() string {
+	return TeamsAlarmCallbackType
+}
+
 // UnmarshalJSON unmarshals JSON into an AlarmCallback.
 func (ac *AlarmCallback) UnmarshalJSON(b []byte) error {
 	errMsg := "failed to unmarshal JSON to AlarmCallback"
@@ -136,27 +208,12 @@ func (ac *AlarmCallback) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &a); err != nil {
 		return errors.Wrap(err, errMsg)
 	}
-	switch a.Type {
-	case EmailAlarmCallbackType:
-		p := EmailAlarmCallbackConfiguration{}
-		if err := json.Unmarshal(a.Configuration, &p); err != nil {
-			return errors.Wrap(err, errMsg)
-		}
-		ac.Configuration = &p
-		return nil
-	case HTTPAlarmCallbackType:
-		p := HTTPAlarmCallbackConfiguration{}
-		if err := json.Unmarshal(a.Configuration, &p); err != nil {
-			return errors.Wrap(err, errMsg)
-		}
-		ac.Configuration = &p
-		return nil
-	case SlackAlarmCallbackType:
-		p := SlackAlarmCallbackConfiguration{}
-		if err := json.Unmarshal(a.Configuration, &p); err != nil {
+	if cfg, ok := NewAlarmCallbackConfigurationByType(a.Type); ok {
+		v, err := unmarshalTyped(a.Configuration, cfg)
+		if err != nil {
 			return errors.Wrap(err, errMsg)
 		}
-		ac.Configuration = &p
+		ac.Configuration = v.(AlarmCallbackConfiguration)
 		return nil
 	}
 	p := map[string]interface{}{}
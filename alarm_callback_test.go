@@ -0,0 +1,34 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testdata"
+)
+
+func TestAlarmCallback_JSON(t *testing.T) {
+	data := map[string]graylog.AlarmCallback{
+		"pagerduty_stream_alarm_callback.json": testdata.PagerDutyStreamAlarmCallback,
+		"script_stream_alarm_callback.json":    testdata.ScriptStreamAlarmCallback,
+		"teams_stream_alarm_callback.json":     testdata.TeamsStreamAlarmCallback,
+		"slack_stream_alarm_callback.json":     testdata.SlackStreamAlarmCallback,
+	}
+	for file, exp := range data {
+		t.Run(file, func(t *testing.T) {
+			buf, err := ioutil.ReadFile("testdata/" + file)
+			require.Nil(t, err)
+			ac := graylog.AlarmCallback{}
+			require.Nil(t, json.Unmarshal(buf, &ac))
+			require.Equal(t, exp, ac)
+
+			b, err := json.Marshal(&ac)
+			require.Nil(t, err)
+			require.JSONEq(t, string(buf), string(b))
+		})
+	}
+}
@@ -1,17 +1,43 @@
 package graylog
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AlertStateAny is the state filter which matches all alerts.
+	AlertStateAny = "any"
+	// AlertStateResolved is the state filter which matches resolved alerts.
+	AlertStateResolved = "resolved"
+	// AlertStateUnresolved is the state filter which matches unresolved alerts.
+	AlertStateUnresolved = "unresolved"
+
+	// AlertReceiverTypeUsers is the type of stream alert receivers which are users.
+	AlertReceiverTypeUsers = "users"
+	// AlertReceiverTypeEmails is the type of stream alert receivers which are email addresses.
+	AlertReceiverTypeEmails = "emails"
+)
+
 type (
 	// Alert represents an Alert.
 	// https://docs.graylog.org/en/latest/pages/streams/alerts.html
+	//
+	// Graylog's API doesn't return the alert condition type with an alert,
+	// so ConditionParameters is decoded as GeneralAlertConditionParameters whose Type is empty.
+	// client.Client.ResolveAlertConditionTypes resolves the type with the alert conditions and
+	// ConditionParameters is decoded into the typed struct by SetConditionType.
 	Alert struct {
-		ID                  string                    `json:"id"`
-		Description         string                    `json:"description"`
-		ConditionID         string                    `json:"condition_id"`
-		StreamID            string                    `json:"stream_id"`
-		TriggeredAt         string                    `json:"triggered_at"`
-		ResolvedAt          string                    `json:"resolved_at"`
-		IsInterval          bool                      `json:"is_interval"`
-		ConditionParameters *AlertConditionParameters `json:"condition_parameters"`
+		ID                  string                   `json:"id"`
+		Description         string                   `json:"description"`
+		ConditionID         string                   `json:"condition_id"`
+		StreamID            string                   `json:"stream_id"`
+		TriggeredAt         string                   `json:"triggered_at"`
+		ResolvedAt          string                   `json:"resolved_at"`
+		IsInterval          bool                     `json:"is_interval"`
+		ConditionParameters AlertConditionParameters `json:"condition_parameters"`
 	}
 
 	// AlertsBody represents Get Alerts API's response body.
@@ -21,3 +47,57 @@ type (
 		Total  int     `json:"total"`
 	}
 )
+
+// IsResolved returns true if the alert has been resolved.
+func (alert Alert) IsResolved() bool {
+	return alert.ResolvedAt != ""
+}
+
+// UnmarshalJSON unmarshals JSON into an alert.
+func (alert *Alert) UnmarshalJSON(b []byte) error {
+	errMsg := "failed to unmarshal JSON to alert"
+	if alert == nil {
+		return fmt.Errorf("%s: alert is nil", errMsg)
+	}
+	type alias Alert
+	a := struct {
+		ConditionParameters json.RawMessage `json:"condition_parameters"`
+		*alias
+	}{
+		alias: (*alias)(alert),
+	}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return errors.Wrap(err, errMsg)
+	}
+	alert.ConditionParameters = nil
+	if len(a.ConditionParameters) == 0 || string(a.ConditionParameters) == "null" {
+		return nil
+	}
+	prms, err := decodeAlertConditionParameters("", a.ConditionParameters)
+	if err != nil {
+		return errors.Wrap(err, errMsg)
+	}
+	alert.ConditionParameters = prms
+	return nil
+}
+
+// SetConditionType decodes the alert's condition parameters into the struct of the given alert condition type.
+// If the type isn't registered, the parameters are kept as GeneralAlertConditionParameters with the type.
+func (alert *Alert) SetConditionType(t string) error {
+	if alert.ConditionParameters == nil {
+		return nil
+	}
+	if alert.ConditionParameters.AlertConditionType() == t {
+		return nil
+	}
+	b, err := json.Marshal(alert.ConditionParameters)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the alert condition parameters")
+	}
+	prms, err := decodeAlertConditionParameters(t, b)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode the alert condition parameters")
+	}
+	alert.ConditionParameters = prms
+	return nil
+}
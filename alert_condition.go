@@ -18,7 +18,7 @@ type (
 		// in_grace must be "omitempty". Without "omitempty", it is failed to create an Alert Condition.
 		// Unable to map property in_grace. Known properties include: title, type, parameters
 		InGrace    bool                     `json:"in_grace,omitempty"`
-		Parameters AlertConditionParameters `json:"parameters" v-create:"reqired" v-update:"required"`
+		Parameters AlertConditionParameters `json:"parameters" v-create:"required" v-update:"required"`
 	}
 
 	// AlertConditionParameters represents Alert Condition's parameters.
@@ -45,8 +45,8 @@ type (
 		RepeatNotifications bool   `json:"repeat_notifications"`
 		Field               string `json:"field,omitempty" v-create:"required"`
 		Query               string `json:"query,omitempty"`
-		ThresholdType       string `json:"threshold_type,omitempty" v-create:"required"`
-		Type                string `json:"type,omitempty" v-create:"required"`
+		ThresholdType       string `json:"threshold_type,omitempty" v-create:"required,oneof=HIGHER LOWER" v-update:"required,oneof=HIGHER LOWER"`
+		Type                string `json:"type,omitempty" v-create:"required,oneof=MEAN MIN MAX SUM STDDEV" v-update:"required,oneof=MEAN MIN MAX SUM STDDEV"`
 	}
 
 	// MessageCountAlertConditionParameters represents Field Aggregation Alert Condition's parameters.
@@ -57,7 +57,7 @@ type (
 		Time                int    `json:"time"`
 		RepeatNotifications bool   `json:"repeat_notifications"`
 		Query               string `json:"query,omitempty"`
-		ThresholdType       string `json:"threshold_type,omitempty" v-create:"required"`
+		ThresholdType       string `json:"threshold_type,omitempty" v-create:"required,oneof=MORE LESS" v-update:"required,oneof=MORE LESS"`
 	}
 
 	// AlertConditionsBody represents Get Alert Conditions API's response body.
@@ -67,6 +67,37 @@ type (
 		Total           int              `json:"total"`
 	}
 
+	// AlertConditionTestResult represents the result of testing an alert condition.
+	AlertConditionTestResult struct {
+		Triggered   bool   `json:"triggered"`
+		Description string `json:"description,omitempty"`
+		// ex. "2019-09-20T12:10:17.793Z"
+		TriggeredAt      string                          `json:"triggered_at,omitempty"`
+		MatchingMessages []AlertConditionMatchingMessage `json:"matching_messages,omitempty"`
+		Error            bool                            `json:"error,omitempty"`
+		ErrorMessages    []AlertConditionTestError       `json:"error_messages,omitempty"`
+	}
+
+	// AlertConditionMatchingMessage represents a message which matches an alert condition.
+	AlertConditionMatchingMessage struct {
+		Index   string                 `json:"index"`
+		Message map[string]interface{} `json:"message"`
+	}
+
+	// AlertConditionTestError represents an error of testing an alert condition.
+	AlertConditionTestError struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	}
+
+	// AlertConditionTypeInfo represents an available alert condition type.
+	AlertConditionTypeInfo struct {
+		Name                   string                        `json:"name"`
+		HumanName              string                        `json:"human_name,omitempty"`
+		LinkToDocs             string                        `json:"link_to_docs,omitempty"`
+		RequestedConfiguration map[string]ConfigurationField `json:"requested_configuration"`
+	}
+
 	// GeneralAlertConditionParameters is a general third party's alert condition parameters.
 	GeneralAlertConditionParameters struct {
 		Type       string
@@ -114,37 +145,31 @@ func (cond *AlertCondition) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &a); err != nil {
 		return errors.Wrap(err, errMsg)
 	}
-	switch a.Type {
-	case "field_content_value":
-		p := FieldContentAlertConditionParameters{}
-		if err := json.Unmarshal(a.Parameters, &p); err != nil {
-			return errors.Wrap(err, errMsg)
-		}
-		cond.Parameters = p
-		return nil
-	case "field_value":
-		p := FieldAggregationAlertConditionParameters{}
-		if err := json.Unmarshal(a.Parameters, &p); err != nil {
-			return errors.Wrap(err, errMsg)
-		}
-		cond.Parameters = p
-		return nil
-	case "message_count":
-		p := MessageCountAlertConditionParameters{}
-		if err := json.Unmarshal(a.Parameters, &p); err != nil {
-			return errors.Wrap(err, errMsg)
+	prms, err := decodeAlertConditionParameters(a.Type, a.Parameters)
+	if err != nil {
+		return errors.Wrap(err, errMsg)
+	}
+	cond.Parameters = prms
+	return nil
+}
+
+// decodeAlertConditionParameters decodes the parameters of the given alert condition type.
+// If the type isn't registered, the parameters are decoded as GeneralAlertConditionParameters.
+func decodeAlertConditionParameters(t string, b []byte) (AlertConditionParameters, error) {
+	if prms, ok := NewAlertConditionParametersByType(t); ok {
+		v, err := unmarshalTyped(b, prms)
+		if err != nil {
+			return nil, err
 		}
-		cond.Parameters = p
-		return nil
+		return v.(AlertConditionParameters), nil
 	}
 	p := map[string]interface{}{}
-	if err := json.Unmarshal(a.Parameters, &p); err != nil {
-		return errors.Wrap(err, errMsg)
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, err
 	}
-	cond.Parameters = GeneralAlertConditionParameters{
-		Type: a.Type, Parameters: p,
-	}
-	return nil
+	return GeneralAlertConditionParameters{
+		Type: t, Parameters: p,
+	}, nil
 }
 
 // AlertConditionType returns an alert condition type.
@@ -168,6 +193,6 @@ func (p GeneralAlertConditionParameters) AlertConditionType() string {
 }
 
 // MarshalJSON returns JSON encoding of GeneralAlertConditionParameters.
-func (p *GeneralAlertConditionParameters) MarshalJSON() ([]byte, error) {
+func (p GeneralAlertConditionParameters) MarshalJSON() ([]byte, error) {
 	return json.Marshal(p.Parameters)
 }
@@ -0,0 +1,41 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func TestAlertSetConditionType(t *testing.T) {
+	alert := &graylog.Alert{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "id": "5d84c3d92ab79c000d35e2fd",
+  "condition_id": "56f9f507-601d-4a54-a2f4-4bda93bb8492",
+  "condition_parameters": {"grace": 1, "threshold": 10, "time": 5, "threshold_type": "MORE"},
+  "resolved_at": null
+}`), alert))
+	require.Equal(t, graylog.GeneralAlertConditionParameters{
+		Parameters: map[string]interface{}{
+			"grace": float64(1), "threshold": float64(10), "time": float64(5), "threshold_type": "MORE"},
+	}, alert.ConditionParameters)
+	require.False(t, alert.IsResolved())
+
+	require.Nil(t, alert.SetConditionType("message_count"))
+	require.Equal(t, graylog.MessageCountAlertConditionParameters{
+		Grace: 1, Threshold: 10, Time: 5, ThresholdType: "MORE",
+	}, alert.ConditionParameters)
+
+	b, err := json.Marshal(alert)
+	require.Nil(t, err)
+	a := &graylog.Alert{}
+	require.Nil(t, json.Unmarshal(b, a))
+	require.Nil(t, a.SetConditionType("message_count"))
+	require.Equal(t, alert, a)
+
+	require.Nil(t, json.Unmarshal([]byte(`{"id": "5d84c3d92ab79c000d35e2fd"}`), a))
+	require.Nil(t, a.ConditionParameters)
+	require.Nil(t, a.SetConditionType("message_count"))
+}
@@ -0,0 +1,25 @@
+/*
+Package alertwatch polls Graylog's alerts and dispatches the new and resolved alerts.
+
+The high-water mark (the triggered time and the IDs of the latest alerts) and the unresolved alerts
+are saved in a StateStore, so the watcher doesn't dispatch the same alert again after the restart.
+
+	w := &alertwatch.Watcher{
+		Client: cl,
+		Store:  &alertwatch.FileStore{Path: "/var/lib/graylog-alert-watch/state.json"},
+		Handler: func(ctx context.Context, ev alertwatch.Event) error {
+			log.Println(ev.Type, ev.Alert.ID, ev.Alert.Description)
+			return nil
+		},
+	}
+	// Run blocks until ctx is canceled.
+	err := w.Run(ctx)
+
+Run and Events deliver events at least once.
+The state is saved after all events of a poll are handled,
+so if the handler returns an error or the process stops in the middle of a poll,
+all events of the poll, including the events which have already been handled, are dispatched again.
+Handlers should be idempotent.
+On the other hand, Poll saves the state before it returns the events, so it delivers events at most once.
+*/
+package alertwatch
@@ -0,0 +1,111 @@
+package alertwatch
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type (
+	// State is the watcher's state which is saved in a StateStore.
+	State struct {
+		// LastTriggeredAt is the triggered time of the latest dispatched alerts.
+		LastTriggeredAt string `json:"last_triggered_at"`
+		// LastIDs is the IDs of the dispatched alerts which were triggered at LastTriggeredAt.
+		LastIDs []string `json:"last_ids"`
+		// Unresolved is the IDs of the dispatched alerts which haven't been resolved.
+		Unresolved []string `json:"unresolved"`
+	}
+
+	// StateStore loads and saves the watcher's state.
+	StateStore interface {
+		// Load returns the saved state. If no state has been saved, Load returns nil.
+		Load(ctx context.Context) (*State, error)
+		Save(ctx context.Context, state *State) error
+	}
+
+	// MemoryStore is a StateStore which keeps the state in memory.
+	// The state is lost when the process exits.
+	MemoryStore struct {
+		mutex sync.Mutex
+		state *State
+	}
+
+	// FileStore is a StateStore which saves the state in a JSON file.
+	FileStore struct {
+		Path string
+	}
+)
+
+func (state *State) clone() *State {
+	if state == nil {
+		return nil
+	}
+	return &State{
+		LastTriggeredAt: state.LastTriggeredAt,
+		LastIDs:         append([]string{}, state.LastIDs...),
+		Unresolved:      append([]string{}, state.Unresolved...),
+	}
+}
+
+// Load returns the saved state.
+func (store *MemoryStore) Load(ctx context.Context) (*State, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.state.clone(), nil
+}
+
+// Save saves the state.
+func (store *MemoryStore) Save(ctx context.Context, state *State) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.state = state.clone()
+	return nil
+}
+
+// Load reads the state from the file.
+// If the file doesn't exist, Load returns nil.
+func (store *FileStore) Load(ctx context.Context) (*State, error) {
+	b, err := ioutil.ReadFile(store.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state := &State{}
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save writes the state to the file.
+// The state is written to a temporary file and renamed so that the file isn't broken.
+func (store *FileStore) Save(ctx context.Context, state *State) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(store.Path), filepath.Base(store.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), store.Path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
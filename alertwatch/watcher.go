@@ -0,0 +1,325 @@
+package alertwatch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+const (
+	// EventTriggered is the type of the event which is emitted when a new alert is found.
+	EventTriggered EventType = "triggered"
+	// EventResolved is the type of the event which is emitted when an alert is resolved.
+	EventResolved EventType = "resolved"
+
+	defaultInterval = time.Minute
+	defaultPageSize = 100
+)
+
+type (
+	// EventType is the type of the event.
+	EventType string
+
+	// Event is emitted when an alert is triggered or resolved.
+	Event struct {
+		Type  EventType
+		Alert graylog.Alert
+	}
+
+	// Client is the subset of client.Client which the watcher uses.
+	Client interface {
+		GetAlerts(ctx context.Context, skip, limit int) ([]graylog.Alert, int, *client.ErrorInfo, error)
+		GetAlert(ctx context.Context, id string) (*graylog.Alert, *client.ErrorInfo, error)
+	}
+
+	// Handler handles an event.
+	// Events are delivered at least once: if Handler returns an error, the state isn't saved and
+	// all events of the poll, including the events which have already been handled, are dispatched again
+	// at the next poll. So Handler should be idempotent, for example by deduplicating the alert ID.
+	Handler func(ctx context.Context, ev Event) error
+
+	// Watcher polls alerts and dispatches the events.
+	Watcher struct {
+		Client Client
+		// Store saves the state. If Store is nil, the state is kept in memory.
+		Store StateStore
+		// Handler is called for each event in order.
+		// The events are delivered at least once. See the Handler type.
+		Handler Handler
+		// OnError is called when a poll fails. Run continues polling after the error.
+		OnError func(err error)
+		// Interval is the polling interval. The default is 1 minute.
+		Interval time.Duration
+		// PageSize is the number of alerts which are got by an API call. The default is 100.
+		PageSize int
+		// EmitExisting makes the watcher dispatch the existing alerts at the first poll.
+		// By default, the existing alerts are only recorded as the high-water mark.
+		EmitExisting bool
+	}
+)
+
+func (w *Watcher) store() StateStore {
+	if w.Store == nil {
+		w.Store = &MemoryStore{}
+	}
+	return w.Store
+}
+
+func (w *Watcher) interval() time.Duration {
+	if w.Interval <= 0 {
+		return defaultInterval
+	}
+	return w.Interval
+}
+
+func (w *Watcher) pageSize() int {
+	if w.PageSize <= 0 {
+		return defaultPageSize
+	}
+	return w.PageSize
+}
+
+// Run polls alerts at the interval until ctx is canceled.
+// Run returns nil when ctx is canceled.
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.Handler == nil {
+		return fmt.Errorf("handler is nil")
+	}
+	ticker := time.NewTicker(w.interval())
+	defer ticker.Stop()
+	for {
+		if err := w.runOnce(ctx); err != nil && ctx.Err() == nil && w.OnError != nil {
+			w.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Events runs the watcher in a goroutine and returns the channel of the events.
+// The channel is closed when ctx is canceled.
+// Handler is ignored.
+func (w *Watcher) Events(ctx context.Context) <-chan Event {
+	ch := make(chan Event)
+	cp := *w
+	cp.Store = w.store()
+	cp.Handler = func(ctx context.Context, ev Event) error {
+		select {
+		case ch <- ev:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	go func() {
+		defer close(ch)
+		_ = cp.Run(ctx)
+	}()
+	return ch
+}
+
+func (w *Watcher) runOnce(ctx context.Context) error {
+	events, state, err := w.poll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		if err := w.Handler(ctx, ev); err != nil {
+			return fmt.Errorf("failed to handle the event %s %s: %v", ev.Type, ev.Alert.ID, err)
+		}
+	}
+	return w.store().Save(ctx, state)
+}
+
+// Poll gets alerts once and returns the events.
+// The state is saved before Poll returns, so the events are never returned again.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	events, state, err := w.poll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.store().Save(ctx, state); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// poll returns the events and the new state without saving the state.
+func (w *Watcher) poll(ctx context.Context) ([]Event, *State, error) {
+	state, err := w.store().Load(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the state: %v", err)
+	}
+	first := state == nil
+	if first {
+		state = &State{}
+	}
+	alerts, err := w.fetch(ctx, state, first && !w.EmitExisting)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unresolved := make(map[string]struct{}, len(state.Unresolved))
+	for _, id := range state.Unresolved {
+		unresolved[id] = struct{}{}
+	}
+	lastIDs := make(map[string]struct{}, len(state.LastIDs))
+	for _, id := range state.LastIDs {
+		lastIDs[id] = struct{}{}
+	}
+	mark := state.LastTriggeredAt
+	newState := &State{LastTriggeredAt: mark}
+	if !first {
+		newState.LastIDs = append(newState.LastIDs, state.LastIDs...)
+	}
+
+	events := []Event{}
+	seen := map[string]struct{}{}
+	// dispatch the older alerts first
+	sort.Slice(alerts, func(i, j int) bool {
+		if c := compareTime(alerts[i].TriggeredAt, alerts[j].TriggeredAt); c != 0 {
+			return c < 0
+		}
+		return alerts[i].ID < alerts[j].ID
+	})
+	for _, alert := range alerts {
+		seen[alert.ID] = struct{}{}
+		if _, ok := unresolved[alert.ID]; ok {
+			if alert.IsResolved() {
+				delete(unresolved, alert.ID)
+				events = append(events, Event{Type: EventResolved, Alert: alert})
+			}
+			continue
+		}
+		if !isNew(alert, mark, lastIDs) {
+			continue
+		}
+		switch c := compareTime(alert.TriggeredAt, newState.LastTriggeredAt); {
+		case c > 0:
+			newState.LastTriggeredAt = alert.TriggeredAt
+			newState.LastIDs = []string{alert.ID}
+		case c == 0:
+			newState.LastIDs = append(newState.LastIDs, alert.ID)
+		}
+		if first && !w.EmitExisting {
+			continue
+		}
+		if !alert.IsResolved() {
+			unresolved[alert.ID] = struct{}{}
+		}
+		events = append(events, Event{Type: EventTriggered, Alert: alert})
+		if alert.IsResolved() {
+			events = append(events, Event{Type: EventResolved, Alert: alert})
+		}
+	}
+
+	// the unresolved alerts which aren't in the recent pages
+	for _, id := range state.Unresolved {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		alert, ei, err := w.Client.GetAlert(ctx, id)
+		if err != nil {
+			if ei != nil && ei.Response != nil && ei.Response.StatusCode == http.StatusNotFound {
+				// the alert has been removed
+				delete(unresolved, id)
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to get the alert %s: %v", id, err)
+		}
+		if alert.IsResolved() {
+			delete(unresolved, id)
+			events = append(events, Event{Type: EventResolved, Alert: *alert})
+		}
+	}
+
+	for id := range unresolved {
+		newState.Unresolved = append(newState.Unresolved, id)
+	}
+	sort.Strings(newState.Unresolved)
+	return events, newState, nil
+}
+
+// fetch gets the alerts from the latest until the alerts which are older than the high-water mark.
+// If firstPageOnly is true, only the first page is got.
+func (w *Watcher) fetch(ctx context.Context, state *State, firstPageOnly bool) ([]graylog.Alert, error) {
+	limit := w.pageSize()
+	ret := []graylog.Alert{}
+	for skip := 0; ; skip += limit {
+		alerts, total, _, err := w.Client.GetAlerts(ctx, skip, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %v", err)
+		}
+		ret = append(ret, alerts...)
+		if firstPageOnly || len(alerts) < limit || skip+len(alerts) >= total {
+			return ret, nil
+		}
+		if state.LastTriggeredAt != "" {
+			for _, alert := range alerts {
+				if compareTime(alert.TriggeredAt, state.LastTriggeredAt) < 0 {
+					return ret, nil
+				}
+			}
+		}
+	}
+}
+
+func isNew(alert graylog.Alert, mark string, lastIDs map[string]struct{}) bool {
+	if mark == "" {
+		return true
+	}
+	c := compareTime(alert.TriggeredAt, mark)
+	if c != 0 {
+		return c > 0
+	}
+	_, ok := lastIDs[alert.ID]
+	return !ok
+}
+
+var timeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-0700",
+}
+
+// compareTime compares Graylog's timestamps.
+// If a timestamp can't be parsed, they are compared as strings.
+func compareTime(a, b string) int {
+	ta, errA := parseTime(a)
+	tb, errB := parseTime(b)
+	if errA != nil || errB != nil {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case ta.Before(tb):
+		return -1
+	case ta.After(tb):
+		return 1
+	}
+	return 0
+}
+
+func parseTime(s string) (time.Time, error) {
+	var err error
+	for _, f := range timeFormats {
+		var t time.Time
+		t, err = time.Parse(f, s)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,243 @@
+package alertwatch_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/alertwatch"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+// fakeClient returns the alerts in descending order of the triggered time as Graylog does.
+type fakeClient struct {
+	mutex  sync.Mutex
+	alerts map[string]graylog.Alert
+}
+
+func newFakeClient(alerts ...graylog.Alert) *fakeClient {
+	cl := &fakeClient{alerts: map[string]graylog.Alert{}}
+	cl.add(alerts...)
+	return cl
+}
+
+func (cl *fakeClient) add(alerts ...graylog.Alert) {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	for _, alert := range alerts {
+		cl.alerts[alert.ID] = alert
+	}
+}
+
+func (cl *fakeClient) GetAlerts(ctx context.Context, skip, limit int) ([]graylog.Alert, int, *client.ErrorInfo, error) {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	alerts := make([]graylog.Alert, 0, len(cl.alerts))
+	for _, alert := range cl.alerts {
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].TriggeredAt == alerts[j].TriggeredAt {
+			return alerts[i].ID > alerts[j].ID
+		}
+		return alerts[i].TriggeredAt > alerts[j].TriggeredAt
+	})
+	total := len(alerts)
+	if skip >= total {
+		return []graylog.Alert{}, total, nil, nil
+	}
+	end := skip + limit
+	if end > total {
+		end = total
+	}
+	return alerts[skip:end], total, nil, nil
+}
+
+func (cl *fakeClient) GetAlert(ctx context.Context, id string) (*graylog.Alert, *client.ErrorInfo, error) {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	alert, ok := cl.alerts[id]
+	if !ok {
+		return nil, &client.ErrorInfo{Response: &http.Response{StatusCode: 404}}, errors.New("not found")
+	}
+	return &alert, nil, nil
+}
+
+func alert(id string, minute int) graylog.Alert {
+	return graylog.Alert{
+		ID:          id,
+		TriggeredAt: fmt.Sprintf("2019-09-20T12:%02d:00.000Z", minute),
+	}
+}
+
+func eventIDs(events []alertwatch.Event) []string {
+	ids := make([]string, len(events))
+	for i, ev := range events {
+		ids[i] = string(ev.Type) + ":" + ev.Alert.ID
+	}
+	return ids
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	ctx := context.Background()
+	cl := newFakeClient(alert("a", 1), alert("b", 2))
+	w := &alertwatch.Watcher{Client: cl, PageSize: 2}
+
+	// the existing alerts are not dispatched
+	events, err := w.Poll(ctx)
+	require.Nil(t, err)
+	require.Empty(t, events)
+
+	c := alert("c", 3)
+	d := alert("d", 3)
+	cl.add(c, d, alert("e", 4))
+	events, err = w.Poll(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []string{"triggered:c", "triggered:d", "triggered:e"}, eventIDs(events))
+
+	// no duplication
+	events, err = w.Poll(ctx)
+	require.Nil(t, err)
+	require.Empty(t, events)
+
+	// an alert which is triggered at the same time as the high-water mark
+	cl.add(alert("f", 4))
+	events, err = w.Poll(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []string{"triggered:f"}, eventIDs(events))
+
+	// resolution of an old alert which isn't in the recent pages
+	c.ResolvedAt = "2019-09-20T12:10:00.000Z"
+	cl.add(c, alert("g", 5), alert("h", 6), alert("i", 7))
+	events, err = w.Poll(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []string{"triggered:g", "triggered:h", "triggered:i", "resolved:c"}, eventIDs(events))
+	state, err := w.Store.Load(ctx)
+	require.Nil(t, err)
+	require.Equal(t, &alertwatch.State{
+		LastTriggeredAt: "2019-09-20T12:07:00.000Z",
+		LastIDs:         []string{"i"},
+		Unresolved:      []string{"d", "e", "f", "g", "h", "i"},
+	}, state)
+}
+
+func TestWatcher_EmitExisting(t *testing.T) {
+	ctx := context.Background()
+	resolved := alert("b", 2)
+	resolved.ResolvedAt = "2019-09-20T12:03:00.000Z"
+	cl := newFakeClient(alert("a", 1), resolved)
+	w := &alertwatch.Watcher{Client: cl, EmitExisting: true}
+	events, err := w.Poll(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []string{"triggered:a", "triggered:b", "resolved:b"}, eventIDs(events))
+}
+
+func TestWatcher_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cl := newFakeClient(alert("a", 1))
+	store := &alertwatch.MemoryStore{}
+	require.Nil(t, store.Save(ctx, &alertwatch.State{}))
+
+	var (
+		mutex  sync.Mutex
+		ids    []string
+		failed = true
+	)
+	w := &alertwatch.Watcher{
+		Client:   cl,
+		Store:    store,
+		Interval: 10 * time.Millisecond,
+		Handler: func(ctx context.Context, ev alertwatch.Event) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if failed {
+				// the event is dispatched again at the next poll
+				failed = false
+				return errors.New("failed to handle")
+			}
+			ids = append(ids, ev.Alert.ID)
+			cancel()
+			return nil
+		},
+	}
+	require.Nil(t, w.Run(ctx))
+	require.Equal(t, []string{"a"}, ids)
+}
+
+func TestWatcher_Run_atLeastOnce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cl := newFakeClient(alert("a", 1), alert("b", 2))
+	store := &alertwatch.MemoryStore{}
+	require.Nil(t, store.Save(ctx, &alertwatch.State{}))
+
+	var (
+		mutex  sync.Mutex
+		ids    []string
+		failed = true
+	)
+	w := &alertwatch.Watcher{
+		Client:   cl,
+		Store:    store,
+		Interval: 10 * time.Millisecond,
+		Handler: func(ctx context.Context, ev alertwatch.Event) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if ev.Alert.ID == "b" && failed {
+				failed = false
+				return errors.New("failed to handle")
+			}
+			ids = append(ids, ev.Alert.ID)
+			if ev.Alert.ID == "b" {
+				cancel()
+			}
+			return nil
+		},
+	}
+	require.Nil(t, w.Run(ctx))
+	// "a" has been handled but is dispatched again because "b" failed
+	require.Equal(t, []string{"a", "a", "b"}, ids)
+}
+
+func TestWatcher_Events(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cl := newFakeClient()
+	store := &alertwatch.MemoryStore{}
+	require.Nil(t, store.Save(ctx, &alertwatch.State{}))
+	w := &alertwatch.Watcher{Client: cl, Store: store, Interval: 10 * time.Millisecond}
+	ch := w.Events(ctx)
+	cl.add(alert("a", 1))
+	ev := <-ch
+	require.Equal(t, alertwatch.EventTriggered, ev.Type)
+	require.Equal(t, "a", ev.Alert.ID)
+	cancel()
+	for range ch {
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "alertwatch")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	store := &alertwatch.FileStore{Path: filepath.Join(dir, "state.json")}
+	state, err := store.Load(ctx)
+	require.Nil(t, err)
+	require.Nil(t, state)
+	exp := &alertwatch.State{LastTriggeredAt: "2019-09-20T12:01:00.000Z", LastIDs: []string{"a"}, Unresolved: []string{"a"}}
+	require.Nil(t, store.Save(ctx, exp))
+	state, err = store.Load(ctx)
+	require.Nil(t, err)
+	require.Equal(t, exp, state)
+}
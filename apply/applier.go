@@ -0,0 +1,202 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+type (
+	// Config is the configuration of Applier.
+	Config struct {
+		// Prune deletes live resources which aren't declared in documents.
+		// Only kinds which are declared in documents are pruned,
+		// and stream rules and extractors are pruned only in declared streams and inputs.
+		// The default index set, the default stream and read only roles are never pruned.
+		Prune bool
+	}
+
+	// Applier converges live resources to documents.
+	Applier struct {
+		cl      *client.Client
+		cfg     *Config
+		dryRun  bool
+		plan    *Plan
+		deletes []pendingDelete
+		// ids maps a kind and a natural key to the resource's ID.
+		ids map[string]map[string]string
+		// loaded is the set of kinds whose live resources have been read.
+		loaded map[string]bool
+	}
+
+	// resource is a desired or live resource.
+	resource struct {
+		key string
+		id  string
+		// data is the resource converted to a map via JSON.
+		data map[string]interface{}
+		// spec is the document's spec. It is nil for live resources.
+		spec map[string]interface{}
+		// protected resources are never pruned.
+		protected bool
+	}
+
+	// operations is a set of the API calls of a kind.
+	operations struct {
+		// create creates a resource and returns the ID.
+		create func(ctx context.Context, want map[string]interface{}) (string, error)
+		// update updates a resource with the live data overwritten by the spec.
+		update func(ctx context.Context, id string, merged, want map[string]interface{}) error
+		del    func(ctx context.Context, live resource) error
+	}
+
+	pendingDelete struct {
+		change Change
+		run    func() error
+	}
+)
+
+// NewApplier returns a new Applier.
+// If cfg is nil, the default configuration is used.
+func NewApplier(cl *client.Client, cfg *Config) *Applier {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	return &Applier{cl: cl, cfg: cfg}
+}
+
+// Plan returns the changes to converge live resources to documents without changing anything.
+func (a *Applier) Plan(ctx context.Context, docs []Document) (*Plan, error) {
+	return a.run(ctx, docs, true)
+}
+
+// Apply converges live resources to documents and returns the applied changes.
+// When an error occurs, the changes applied before the error are returned with the error.
+func (a *Applier) Apply(ctx context.Context, docs []Document) (*Plan, error) {
+	return a.run(ctx, docs, false)
+}
+
+func (a *Applier) run(ctx context.Context, docs []Document, dryRun bool) (*Plan, error) {
+	a.dryRun = dryRun
+	a.plan = &Plan{DryRun: dryRun, Changes: []Change{}}
+	a.deletes = nil
+	a.ids = map[string]map[string]string{}
+	a.loaded = map[string]bool{}
+
+	byKind := map[string][]Document{}
+	for _, doc := range docs {
+		if err := doc.validate(); err != nil {
+			return nil, err
+		}
+		byKind[doc.Kind] = append(byKind[doc.Kind], doc)
+	}
+	funcs := map[string]func(context.Context, []Document) error{
+		KindIndexSet:     a.applyIndexSets,
+		KindStream:       a.applyStreams,
+		KindStreamRule:   a.applyStreamRules,
+		KindInput:        a.applyInputs,
+		KindExtractor:    a.applyExtractors,
+		KindPipelineRule: a.applyPipelineRules,
+		KindPipeline:     a.applyPipelines,
+		KindRole:         a.applyRoles,
+	}
+	for _, kind := range kinds {
+		d, ok := byKind[kind]
+		if !ok {
+			continue
+		}
+		if err := funcs[kind](ctx, d); err != nil {
+			return a.plan, err
+		}
+	}
+	// delete resources in reverse order of dependency
+	for i := len(a.deletes) - 1; i >= 0; i-- {
+		d := a.deletes[i]
+		if !a.dryRun {
+			if err := d.run(); err != nil {
+				return a.plan, errors.Wrapf(err, "failed to delete %s %s", d.change.Kind, d.change.Key)
+			}
+		}
+		a.plan.add(d.change)
+	}
+	return a.plan, nil
+}
+
+func (a *Applier) setID(kind, key, id string) {
+	ids, ok := a.ids[kind]
+	if !ok {
+		ids = map[string]string{}
+		a.ids[kind] = ids
+	}
+	ids[key] = id
+}
+
+func (a *Applier) getID(kind, key string) (string, bool) {
+	id, ok := a.ids[kind][key]
+	return id, ok
+}
+
+// converge compares desired resources with live resources and creates, updates or deletes resources.
+func (a *Applier) converge(
+	ctx context.Context, kind string, desired, live []resource, ops operations,
+) error {
+	a.loaded[kind] = true
+	liveByKey := make(map[string]resource, len(live))
+	for _, r := range live {
+		liveByKey[r.key] = r
+		a.setID(kind, r.key, r.id)
+	}
+	declared := make(map[string]bool, len(desired))
+	for _, want := range desired {
+		if declared[want.key] {
+			return fmt.Errorf("%s %s is declared more than once", kind, want.key)
+		}
+		declared[want.key] = true
+		got, ok := liveByKey[want.key]
+		if !ok {
+			id := ""
+			if !a.dryRun {
+				var err error
+				id, err = ops.create(ctx, want.data)
+				if err != nil {
+					return errors.Wrapf(err, "failed to create %s %s", kind, want.key)
+				}
+			}
+			a.setID(kind, want.key, id)
+			a.plan.add(Change{Kind: kind, Key: want.key, Action: ActionCreate, ID: id})
+			continue
+		}
+		projected := project(want.data, want.spec).(map[string]interface{})
+		changes := diffValues("", projected, got.data)
+		if len(changes) == 0 {
+			continue
+		}
+		if !a.dryRun {
+			merged := merge(got.data, projected).(map[string]interface{})
+			if err := ops.update(ctx, got.id, merged, projected); err != nil {
+				return errors.Wrapf(err, "failed to update %s %s", kind, want.key)
+			}
+		}
+		a.plan.add(Change{
+			Kind: kind, Key: want.key, Action: ActionUpdate, ID: got.id, Fields: changes})
+	}
+	if !a.cfg.Prune {
+		return nil
+	}
+	for _, r := range live {
+		if declared[r.key] || r.protected {
+			continue
+		}
+		r := r
+		a.deletes = append(a.deletes, pendingDelete{
+			change: Change{Kind: kind, Key: r.key, Action: ActionDelete, ID: r.id},
+			run: func() error {
+				return ops.del(ctx, r)
+			},
+		})
+	}
+	return nil
+}
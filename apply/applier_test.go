@@ -0,0 +1,89 @@
+package apply_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/apply"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestApplier(t *testing.T) {
+	ctx := context.Background()
+	server, cl, err := testutil.GetServerAndClient()
+	require.Nil(t, err)
+	if server == nil {
+		t.Skip("the mock server is required")
+	}
+	defer server.Close()
+	is, f, err := testutil.GetIndexSet(ctx, cl, server, "hoge")
+	require.Nil(t, err)
+	if f != nil {
+		defer f(is.ID)
+	}
+
+	docs, err := apply.Decode(strings.NewReader(`
+kind: stream
+index_set: ` + is.IndexPrefix + `
+spec:
+  title: apply-test
+  description: created
+  matching_type: AND
+---
+kind: role
+spec:
+  name: apply-test
+  permissions:
+  - dashboards:read
+  - users:edit
+`))
+	require.Nil(t, err)
+	applier := apply.NewApplier(cl, nil)
+
+	plan, err := applier.Plan(ctx, docs)
+	require.Nil(t, err)
+	require.True(t, plan.DryRun)
+	require.Equal(t, 2, plan.Count(apply.ActionCreate))
+
+	plan, err = applier.Apply(ctx, docs)
+	require.Nil(t, err)
+	require.Equal(t, 2, plan.Count(apply.ActionCreate))
+	streamID := plan.Changes[0].ID
+	require.NotEmpty(t, streamID)
+	stream, _, err := cl.GetStream(ctx, streamID)
+	require.Nil(t, err)
+	require.Equal(t, "created", stream.Description)
+	require.Equal(t, is.ID, stream.IndexSetID)
+
+	// no changes
+	plan, err = applier.Plan(ctx, docs)
+	require.Nil(t, err)
+	require.False(t, plan.HasChanges(), plan.String())
+
+	// update
+	docs[0].Spec["description"] = "updated"
+	plan, err = applier.Apply(ctx, docs)
+	require.Nil(t, err)
+	require.Equal(t, []apply.Change{{
+		Kind: apply.KindStream, Key: "apply-test", Action: apply.ActionUpdate, ID: streamID,
+		Fields: []apply.FieldChange{{Field: "description", Old: "created", New: "updated"}},
+	}}, plan.Changes)
+	stream, _, err = cl.GetStream(ctx, streamID)
+	require.Nil(t, err)
+	require.Equal(t, "updated", stream.Description)
+
+	// prune
+	applier = apply.NewApplier(cl, &apply.Config{Prune: true})
+	plan, err = applier.Apply(ctx, docs[1:])
+	require.Nil(t, err)
+	require.Equal(t, 0, plan.Count(apply.ActionDelete), plan.String())
+	plan, err = applier.Plan(ctx, docs[:1])
+	require.Nil(t, err)
+	for _, c := range plan.Changes {
+		require.Equal(t, apply.ActionDelete, c.Action)
+		require.NotEqual(t, streamID, c.ID)
+	}
+}
@@ -0,0 +1,121 @@
+package apply
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// toJSONMap converts a model to a map via JSON.
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// fromJSONMap converts a map to a model via JSON.
+func fromJSONMap(m map[string]interface{}, v interface{}) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// project returns the part of v which has the same shape as a given spec.
+// Fields which aren't written in the spec are dropped.
+func project(v, spec interface{}) interface{} {
+	s, ok := spec.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	m, _ := v.(map[string]interface{})
+	ret := make(map[string]interface{}, len(s))
+	for k, e := range s {
+		ret[k] = project(m[k], e)
+	}
+	return ret
+}
+
+// sortSet sorts a list of strings of a given key such as role permissions,
+// because the order of the list isn't meaningful.
+func sortSet(m map[string]interface{}, key string) {
+	list, ok := m[key].([]interface{})
+	if !ok {
+		return
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		a, _ := list[i].(string)
+		b, _ := list[j].(string)
+		return a < b
+	})
+}
+
+// isZero returns true if v is the zero value of the JSON value.
+// Zero values are omitted by "omitempty" so null and zero values are regarded as same.
+func isZero(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// diffValues compares the wanted value with the live value.
+// Maps are compared recursively, and only fields of the wanted map are compared.
+func diffValues(path string, want, got interface{}) []FieldChange {
+	if w, ok := want.(map[string]interface{}); ok {
+		g, _ := got.(map[string]interface{})
+		keys := make([]string, 0, len(w))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var changes []FieldChange
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			changes = append(changes, diffValues(p, w[k], g[k])...)
+		}
+		return changes
+	}
+	if isZero(want) && isZero(got) {
+		return nil
+	}
+	if reflect.DeepEqual(want, got) {
+		return nil
+	}
+	return []FieldChange{{Field: path, Old: got, New: want}}
+}
+
+// merge overwrites the live value with the wanted value recursively and returns the result.
+func merge(got, want interface{}) interface{} {
+	w, ok := want.(map[string]interface{})
+	if !ok {
+		return want
+	}
+	g, ok := got.(map[string]interface{})
+	if !ok {
+		return want
+	}
+	ret := make(map[string]interface{}, len(g))
+	for k, v := range g {
+		ret[k] = v
+	}
+	for k, v := range w {
+		ret[k] = merge(g[k], v)
+	}
+	return ret
+}
@@ -0,0 +1,78 @@
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffValues(t *testing.T) {
+	want := map[string]interface{}{
+		"title":       "foo",
+		"description": nil,
+		"attributes": map[string]interface{}{
+			"port": float64(514),
+		},
+	}
+	got := map[string]interface{}{
+		"id":          "xxx",
+		"title":       "bar",
+		"description": "",
+		"attributes": map[string]interface{}{
+			"port":      float64(1514),
+			"bind_addr": "0.0.0.0",
+		},
+	}
+	require.Equal(t, []FieldChange{
+		{Field: "attributes.port", Old: float64(1514), New: float64(514)},
+		{Field: "title", Old: "bar", New: "foo"},
+	}, diffValues("", want, got))
+	require.Empty(t, diffValues("", got, got))
+}
+
+func TestProject(t *testing.T) {
+	v := map[string]interface{}{
+		"title": "foo",
+		"attributes": map[string]interface{}{
+			"port":      float64(514),
+			"bind_addr": "0.0.0.0",
+		},
+	}
+	spec := map[string]interface{}{
+		"attributes": map[string]interface{}{
+			"port": 514,
+		},
+		"global": false,
+	}
+	require.Equal(t, map[string]interface{}{
+		"attributes": map[string]interface{}{
+			"port": float64(514),
+		},
+		"global": nil,
+	}, project(v, spec))
+}
+
+func TestMerge(t *testing.T) {
+	got := map[string]interface{}{
+		"id":    "xxx",
+		"title": "bar",
+		"attributes": map[string]interface{}{
+			"port":      float64(1514),
+			"bind_addr": "0.0.0.0",
+		},
+	}
+	want := map[string]interface{}{
+		"title": "foo",
+		"attributes": map[string]interface{}{
+			"port": float64(514),
+		},
+	}
+	require.Equal(t, map[string]interface{}{
+		"id":    "xxx",
+		"title": "foo",
+		"attributes": map[string]interface{}{
+			"port":      float64(514),
+			"bind_addr": "0.0.0.0",
+		},
+	}, merge(got, want))
+}
@@ -0,0 +1,41 @@
+/*
+Package apply converges Graylog's configuration to YAML documents declaratively.
+
+Each YAML document has the resource kind and the spec.
+The spec is decoded into the model struct with util.MSDecode,
+so the spec's keys are the JSON field names of the struct.
+
+	kind: index_set
+	spec:
+	  title: app
+	  index_prefix: app
+	  ...
+	---
+	kind: stream
+	# index prefix of the stream's index set
+	index_set: app
+	spec:
+	  title: app
+	  matching_type: AND
+	---
+	kind: stream_rule
+	# title of the stream
+	stream: app
+	spec:
+	  field: tag
+	  value: app
+	  type: 1
+
+Resources are matched with live resources by their natural keys.
+Only fields written in the spec are compared and updated,
+so fields which are omitted keep the live values.
+
+	docs, err := apply.ReadFiles("graylog.yaml")
+	applier := apply.NewApplier(cl, &apply.Config{Prune: true})
+	// diff
+	plan, err := applier.Plan(ctx, docs)
+	// apply
+	plan, err = applier.Apply(ctx, docs)
+	fmt.Println(plan)
+*/
+package apply
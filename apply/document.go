@@ -0,0 +1,192 @@
+package apply
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// KindIndexSet is the kind of index sets. The natural key is the index prefix.
+	KindIndexSet = "index_set"
+	// KindStream is the kind of streams. The natural key is the title.
+	KindStream = "stream"
+	// KindStreamRule is the kind of stream rules.
+	// The natural key is the stream, field, type, value and inverted.
+	KindStreamRule = "stream_rule"
+	// KindInput is the kind of inputs. The natural key is the title.
+	KindInput = "input"
+	// KindExtractor is the kind of extractors. The natural key is the input and title.
+	KindExtractor = "extractor"
+	// KindPipelineRule is the kind of pipeline rules. The natural key is the title.
+	KindPipelineRule = "pipeline_rule"
+	// KindPipeline is the kind of pipelines. The natural key is the title.
+	KindPipeline = "pipeline"
+	// KindRole is the kind of roles. The natural key is the name.
+	KindRole = "role"
+)
+
+// kinds is the list of supported kinds in order of dependency.
+var kinds = []string{
+	KindIndexSet, KindStream, KindStreamRule, KindInput, KindExtractor,
+	KindPipelineRule, KindPipeline, KindRole,
+}
+
+type (
+	// Document is a YAML document which declares a resource.
+	Document struct {
+		Kind string `yaml:"kind"`
+		// IndexSet is the index prefix of the stream's index set.
+		// It is used instead of spec.index_set_id.
+		IndexSet string `yaml:"index_set"`
+		// Stream is the title of the stream rule's stream.
+		Stream string `yaml:"stream"`
+		// Input is the title of the extractor's input.
+		Input string                 `yaml:"input"`
+		Spec  map[string]interface{} `yaml:"spec"`
+		// Source is the file path where the document is read.
+		Source string `yaml:"-"`
+	}
+)
+
+// Decode reads YAML documents from a reader.
+// Empty documents are ignored.
+func Decode(r io.Reader) ([]Document, error) {
+	decoder := yaml.NewDecoder(r)
+	docs := []Document{}
+	for {
+		doc := Document{}
+		if err := decoder.Decode(&doc); err != nil {
+			if err == io.EOF {
+				return docs, nil
+			}
+			return nil, err
+		}
+		if doc.Kind == "" && doc.Spec == nil {
+			continue
+		}
+		spec, err := normalize(doc.Spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid spec of %s", doc.Kind)
+		}
+		doc.Spec = spec.(map[string]interface{})
+		if err := doc.validate(); err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// ReadFiles reads YAML documents from files.
+// If a path is a directory, files whose extension is ".yaml" or ".yml" in the directory are read.
+// If a path is "-", documents are read from the standard input.
+func ReadFiles(paths ...string) ([]Document, error) {
+	docs := []Document{}
+	for _, p := range paths {
+		if p == "-" {
+			d, err := Decode(os.Stdin)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read documents from the standard input")
+			}
+			docs = append(docs, d...)
+			continue
+		}
+		files := []string{p}
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if fi.IsDir() {
+			files = nil
+			for _, pattern := range []string{"*.yaml", "*.yml"} {
+				a, err := filepath.Glob(filepath.Join(p, pattern))
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, a...)
+			}
+		}
+		for _, file := range files {
+			b, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			d, err := Decode(bytes.NewReader(b))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read documents from %s", file)
+			}
+			for i := range d {
+				d[i].Source = file
+			}
+			docs = append(docs, d...)
+		}
+	}
+	return docs, nil
+}
+
+func (doc *Document) validate() error {
+	switch doc.Kind {
+	case KindStreamRule:
+		if doc.Stream == "" {
+			return errors.New("stream is required for stream_rule")
+		}
+	case KindExtractor:
+		if doc.Input == "" {
+			return errors.New("input is required for extractor")
+		}
+	case KindIndexSet, KindStream, KindInput, KindPipelineRule, KindPipeline, KindRole:
+	case "":
+		return errors.New("kind is required")
+	default:
+		return fmt.Errorf("unsupported kind: %s", doc.Kind)
+	}
+	return nil
+}
+
+// normalize converts map[interface{}]interface{}, which yaml.v2 decodes, to map[string]interface{}
+// so that the value can be converted to JSON.
+func normalize(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("map key must be string: %v", k)
+			}
+			n, err := normalize(e)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = n
+		}
+		return m, nil
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			n, err := normalize(e)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = n
+		}
+		return m, nil
+	case []interface{}:
+		a := make([]interface{}, len(val))
+		for i, e := range val {
+			n, err := normalize(e)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = n
+		}
+		return a, nil
+	}
+	return v, nil
+}
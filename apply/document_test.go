@@ -0,0 +1,52 @@
+package apply_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/apply"
+)
+
+func TestDecode(t *testing.T) {
+	docs, err := apply.Decode(strings.NewReader(`
+kind: stream
+index_set: app
+spec:
+  title: app
+  matching_type: AND
+---
+---
+kind: input
+spec:
+  title: syslog
+  attributes:
+    port: 514
+`))
+	require.Nil(t, err)
+	require.Equal(t, []apply.Document{
+		{
+			Kind:     "stream",
+			IndexSet: "app",
+			Spec: map[string]interface{}{
+				"title":         "app",
+				"matching_type": "AND",
+			},
+		},
+		{
+			Kind: "input",
+			Spec: map[string]interface{}{
+				"title": "syslog",
+				"attributes": map[string]interface{}{
+					"port": 514,
+				},
+			},
+		},
+	}, docs)
+
+	_, err = apply.Decode(strings.NewReader("kind: foo\n"))
+	require.NotNil(t, err)
+	_, err = apply.Decode(strings.NewReader("kind: stream_rule\nspec:\n  field: tag\n"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,100 @@
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ActionCreate means the resource is created.
+	ActionCreate = "create"
+	// ActionUpdate means the resource is updated.
+	ActionUpdate = "update"
+	// ActionDelete means the resource is deleted.
+	ActionDelete = "delete"
+)
+
+type (
+	// FieldChange is a change of a field.
+	// Field is the JSON path such as "attributes.port".
+	FieldChange struct {
+		Field string      `json:"field"`
+		Old   interface{} `json:"old"`
+		New   interface{} `json:"new"`
+	}
+
+	// Change is a change of a resource.
+	Change struct {
+		Kind   string        `json:"kind"`
+		Key    string        `json:"key"`
+		Action string        `json:"action"`
+		ID     string        `json:"id,omitempty"`
+		Fields []FieldChange `json:"fields,omitempty"`
+	}
+
+	// Plan is the list of changes to converge live resources to documents.
+	Plan struct {
+		DryRun  bool     `json:"dry_run"`
+		Changes []Change `json:"changes"`
+	}
+)
+
+// HasChanges returns true if the plan has any changes.
+func (plan *Plan) HasChanges() bool {
+	return len(plan.Changes) != 0
+}
+
+// Count returns the number of changes of a given action.
+func (plan *Plan) Count(action string) int {
+	n := 0
+	for _, c := range plan.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+func (plan *Plan) add(change Change) {
+	plan.Changes = append(plan.Changes, change)
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "(null)"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// String returns a human readable plan.
+func (plan *Plan) String() string {
+	buf := &strings.Builder{}
+	marks := map[string]string{
+		ActionCreate: "+",
+		ActionUpdate: "~",
+		ActionDelete: "-",
+	}
+	for _, c := range plan.Changes {
+		fmt.Fprintf(buf, "%s %s %q\n", marks[c.Action], c.Kind, c.Key)
+		for _, f := range c.Fields {
+			fmt.Fprintf(buf, "    %s: %s => %s\n", f.Field, formatValue(f.Old), formatValue(f.New))
+		}
+	}
+	verb := "applied"
+	if plan.DryRun {
+		verb = "to be applied"
+	}
+	if !plan.HasChanges() {
+		fmt.Fprintf(buf, "No changes %s.\n", verb)
+		return buf.String()
+	}
+	fmt.Fprintf(
+		buf, "%d to create, %d to update, %d to delete (%s).\n",
+		plan.Count(ActionCreate), plan.Count(ActionUpdate), plan.Count(ActionDelete), verb)
+	return buf.String()
+}
@@ -0,0 +1,637 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/util"
+)
+
+var (
+	pipelineTitlePattern     = regexp.MustCompile(`pipeline\s+"((?:[^"\\]|\\.)*)"`)
+	pipelineRuleTitlePattern = regexp.MustCompile(`rule\s+"((?:[^"\\]|\\.)*)"`)
+)
+
+// titleOfSource returns the title which is declared in a pipeline or pipeline rule's source.
+func titleOfSource(pattern *regexp.Regexp, source string) string {
+	a := pattern.FindStringSubmatch(source)
+	if a == nil {
+		return ""
+	}
+	return a[1]
+}
+
+// newDesired converts a document's model to a desired resource.
+func newDesired(key string, model interface{}, spec map[string]interface{}) (resource, error) {
+	data, err := toJSONMap(model)
+	if err != nil {
+		return resource{}, err
+	}
+	return resource{key: key, data: data, spec: spec}, nil
+}
+
+func newLive(key, id string, model interface{}) (resource, error) {
+	data, err := toJSONMap(model)
+	if err != nil {
+		return resource{}, err
+	}
+	return resource{key: key, id: id, data: data}, nil
+}
+
+// decodeSpec decodes a document's spec into a model with util.MSDecode.
+func decodeSpec(doc Document, model interface{}) error {
+	if err := util.MSDecode(doc.Spec, model); err != nil {
+		return errors.Wrapf(err, "failed to decode the spec of %s", doc.Kind)
+	}
+	return nil
+}
+
+func (a *Applier) applyIndexSets(ctx context.Context, docs []Document) error {
+	desired := make([]resource, len(docs))
+	for i, doc := range docs {
+		is := &graylog.IndexSet{}
+		if err := decodeSpec(doc, is); err != nil {
+			return err
+		}
+		if is.IndexPrefix == "" {
+			return errors.New("index_prefix is required for index_set")
+		}
+		r, err := newDesired(is.IndexPrefix, is, doc.Spec)
+		if err != nil {
+			return err
+		}
+		desired[i] = r
+	}
+	indexSets, _, _, _, err := a.cl.GetIndexSets(ctx, 0, 0, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get index sets")
+	}
+	live := make([]resource, len(indexSets))
+	for i, is := range indexSets {
+		r, err := newLive(is.IndexPrefix, is.ID, &is)
+		if err != nil {
+			return err
+		}
+		r.protected = is.Default
+		live[i] = r
+	}
+	return a.converge(ctx, KindIndexSet, desired, live, operations{
+		create: func(ctx context.Context, want map[string]interface{}) (string, error) {
+			is := &graylog.IndexSet{}
+			if err := fromJSONMap(want, is); err != nil {
+				return "", err
+			}
+			is.SetCreateDefaultValues()
+			_, err := a.cl.CreateIndexSet(ctx, is)
+			return is.ID, err
+		},
+		update: func(ctx context.Context, id string, merged, want map[string]interface{}) error {
+			is := &graylog.IndexSet{}
+			if err := fromJSONMap(merged, is); err != nil {
+				return err
+			}
+			is.ID = id
+			_, _, err := a.cl.UpdateIndexSet(ctx, is.NewUpdateParams())
+			return err
+		},
+		del: func(ctx context.Context, r resource) error {
+			_, err := a.cl.DeleteIndexSet(ctx, r.id)
+			return err
+		},
+	})
+}
+
+func (a *Applier) changeStreamState(ctx context.Context, id string, disabled bool) error {
+	var err error
+	if disabled {
+		_, err = a.cl.PauseStream(ctx, id)
+	} else {
+		_, err = a.cl.ResumeStream(ctx, id)
+	}
+	return err
+}
+
+func (a *Applier) applyStreams(ctx context.Context, docs []Document) error {
+	desired := make([]resource, len(docs))
+	for i, doc := range docs {
+		stream := &graylog.Stream{}
+		if err := decodeSpec(doc, stream); err != nil {
+			return err
+		}
+		if stream.Title == "" {
+			return errors.New("title is required for stream")
+		}
+		spec := doc.Spec
+		if doc.IndexSet != "" {
+			id, err := a.lookupID(ctx, KindIndexSet, doc.IndexSet)
+			if err != nil {
+				return err
+			}
+			if id == "" {
+				// the index set will be created
+				id = fmt.Sprintf("(index set %s)", doc.IndexSet)
+			}
+			stream.IndexSetID = id
+			spec = make(map[string]interface{}, len(doc.Spec)+1)
+			for k, v := range doc.Spec {
+				spec[k] = v
+			}
+			spec["index_set_id"] = id
+		}
+		r, err := newDesired(stream.Title, stream, spec)
+		if err != nil {
+			return err
+		}
+		desired[i] = r
+	}
+	streams, _, _, err := a.cl.GetStreams(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get streams")
+	}
+	live := make([]resource, len(streams))
+	for i, stream := range streams {
+		r, err := newLive(stream.Title, stream.ID, &stream)
+		if err != nil {
+			return err
+		}
+		r.protected = stream.IsDefault
+		live[i] = r
+	}
+	return a.converge(ctx, KindStream, desired, live, operations{
+		create: func(ctx context.Context, want map[string]interface{}) (string, error) {
+			stream := &graylog.Stream{}
+			if err := fromJSONMap(want, stream); err != nil {
+				return "", err
+			}
+			disabled := stream.Disabled
+			stream.Disabled = false
+			if _, err := a.cl.CreateStream(ctx, stream); err != nil {
+				return "", err
+			}
+			// a created stream is paused
+			if !disabled {
+				if _, err := a.cl.ResumeStream(ctx, stream.ID); err != nil {
+					return stream.ID, err
+				}
+			}
+			return stream.ID, nil
+		},
+		update: func(ctx context.Context, id string, merged, want map[string]interface{}) error {
+			m := &graylog.Stream{}
+			if err := fromJSONMap(merged, m); err != nil {
+				return err
+			}
+			stream := &graylog.Stream{
+				ID:                             id,
+				Title:                          m.Title,
+				IndexSetID:                     m.IndexSetID,
+				Description:                    m.Description,
+				MatchingType:                   m.MatchingType,
+				RemoveMatchesFromDefaultStream: m.RemoveMatchesFromDefaultStream,
+			}
+			if _, err := a.cl.UpdateStream(ctx, stream); err != nil {
+				return err
+			}
+			if _, ok := want["disabled"]; ok {
+				return a.changeStreamState(ctx, id, m.Disabled)
+			}
+			return nil
+		},
+		del: func(ctx context.Context, r resource) error {
+			_, err := a.cl.DeleteStream(ctx, r.id)
+			return err
+		},
+	})
+}
+
+// lookupID returns the ID of the resource whose natural key is a given key.
+// If no document of the kind is declared, live resources are read.
+// If the resource will be created, the empty string is returned.
+func (a *Applier) lookupID(ctx context.Context, kind, key string) (string, error) {
+	if id, ok := a.getID(kind, key); ok {
+		return id, nil
+	}
+	if !a.loaded[kind] {
+		a.loaded[kind] = true
+		switch kind {
+		case KindIndexSet:
+			indexSets, _, _, _, err := a.cl.GetIndexSets(ctx, 0, 0, false)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to get index sets")
+			}
+			for _, is := range indexSets {
+				a.setID(kind, is.IndexPrefix, is.ID)
+			}
+		case KindStream:
+			streams, _, _, err := a.cl.GetStreams(ctx)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to get streams")
+			}
+			for _, stream := range streams {
+				a.setID(kind, stream.Title, stream.ID)
+			}
+		case KindInput:
+			inputs, _, _, err := a.cl.GetInputs(ctx)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to get inputs")
+			}
+			for _, input := range inputs {
+				a.setID(kind, input.Title, input.ID)
+			}
+		}
+		if id, ok := a.getID(kind, key); ok {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%s %s isn't found", kind, key)
+}
+
+func streamRuleKey(stream string, rule *graylog.StreamRule) string {
+	return fmt.Sprintf(
+		"%s: %s %d %s inverted=%t", stream, rule.Field, rule.Type, rule.Value, rule.Inverted)
+}
+
+func (a *Applier) applyStreamRules(ctx context.Context, docs []Document) error {
+	desired := make([]resource, len(docs))
+	// the titles of the streams whose rules are declared
+	streams := []string{}
+	streamIDs := map[string]string{}
+	for i, doc := range docs {
+		streamID, ok := streamIDs[doc.Stream]
+		if !ok {
+			id, err := a.lookupID(ctx, KindStream, doc.Stream)
+			if err != nil {
+				return err
+			}
+			streamID = id
+			streamIDs[doc.Stream] = id
+			streams = append(streams, doc.Stream)
+		}
+		rule := &graylog.StreamRule{}
+		if err := decodeSpec(doc, rule); err != nil {
+			return err
+		}
+		rule.StreamID = streamID
+		r, err := newDesired(streamRuleKey(doc.Stream, rule), rule, doc.Spec)
+		if err != nil {
+			return err
+		}
+		desired[i] = r
+	}
+	live := []resource{}
+	for _, title := range streams {
+		streamID := streamIDs[title]
+		if streamID == "" {
+			continue
+		}
+		rules, _, _, err := a.cl.GetStreamRules(ctx, streamID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get stream rules of the stream %s", title)
+		}
+		for _, rule := range rules {
+			r, err := newLive(streamRuleKey(title, &rule), rule.ID, &rule)
+			if err != nil {
+				return err
+			}
+			live = append(live, r)
+		}
+	}
+	return a.converge(ctx, KindStreamRule, desired, live, operations{
+		create: func(ctx context.Context, want map[string]interface{}) (string, error) {
+			rule := &graylog.StreamRule{}
+			if err := fromJSONMap(want, rule); err != nil {
+				return "", err
+			}
+			_, err := a.cl.CreateStreamRule(ctx, rule)
+			return rule.ID, err
+		},
+		update: func(ctx context.Context, id string, merged, want map[string]interface{}) error {
+			rule := &graylog.StreamRule{}
+			if err := fromJSONMap(merged, rule); err != nil {
+				return err
+			}
+			rule.ID = id
+			_, err := a.cl.UpdateStreamRule(ctx, rule)
+			return err
+		},
+		del: func(ctx context.Context, r resource) error {
+			streamID, _ := r.data["stream_id"].(string)
+			_, err := a.cl.DeleteStreamRule(ctx, streamID, r.id)
+			return err
+		},
+	})
+}
+
+func (a *Applier) applyInputs(ctx context.Context, docs []Document) error {
+	desired := make([]resource, len(docs))
+	for i, doc := range docs {
+		d := &graylog.InputData{}
+		if err := decodeSpec(doc, d); err != nil {
+			return err
+		}
+		input := &graylog.Input{}
+		if err := d.ToInput(input); err != nil {
+			return err
+		}
+		if input.Title == "" {
+			return errors.New("title is required for input")
+		}
+		r, err := newDesired(input.Title, input, doc.Spec)
+		if err != nil {
+			return err
+		}
+		desired[i] = r
+	}
+	inputs, _, _, err := a.cl.GetInputs(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get inputs")
+	}
+	live := make([]resource, len(inputs))
+	for i, input := range inputs {
+		r, err := newLive(input.Title, input.ID, &input)
+		if err != nil {
+			return err
+		}
+		live[i] = r
+	}
+	return a.converge(ctx, KindInput, desired, live, operations{
+		create: func(ctx context.Context, want map[string]interface{}) (string, error) {
+			input := &graylog.Input{}
+			if err := fromJSONMap(want, input); err != nil {
+				return "", err
+			}
+			_, err := a.cl.CreateInput(ctx, input)
+			return input.ID, err
+		},
+		update: func(ctx context.Context, id string, merged, want map[string]interface{}) error {
+			input := &graylog.Input{}
+			if err := fromJSONMap(merged, input); err != nil {
+				return err
+			}
+			input.ID = id
+			_, _, err := a.cl.UpdateInput(ctx, input.NewUpdateParams())
+			return err
+		},
+		del: func(ctx context.Context, r resource) error {
+			_, err := a.cl.DeleteInput(ctx, r.id)
+			return err
+		},
+	})
+}
+
+func (a *Applier) applyExtractors(ctx context.Context, docs []Document) error {
+	desired := make([]resource, len(docs))
+	inputs := []string{}
+	inputIDs := map[string]string{}
+	for i, doc := range docs {
+		if _, ok := inputIDs[doc.Input]; !ok {
+			id, err := a.lookupID(ctx, KindInput, doc.Input)
+			if err != nil {
+				return err
+			}
+			inputIDs[doc.Input] = id
+			inputs = append(inputs, doc.Input)
+		}
+		extractor := &graylog.Extractor{}
+		if err := decodeSpec(doc, extractor); err != nil {
+			return err
+		}
+		if extractor.Title == "" {
+			return errors.New("title is required for extractor")
+		}
+		r, err := newDesired(doc.Input+": "+extractor.Title, extractor, doc.Spec)
+		if err != nil {
+			return err
+		}
+		r.data["input_id"] = inputIDs[doc.Input]
+		desired[i] = r
+	}
+	live := []resource{}
+	for _, title := range inputs {
+		inputID := inputIDs[title]
+		if inputID == "" {
+			continue
+		}
+		extractors, _, _, err := a.cl.GetExtractors(ctx, inputID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get extractors of the input %s", title)
+		}
+		for _, extractor := range extractors {
+			r, err := newLive(title+": "+extractor.Title, extractor.ID, &extractor)
+			if err != nil {
+				return err
+			}
+			r.data["input_id"] = inputID
+			live = append(live, r)
+		}
+	}
+	// input_id isn't a field of Extractor, so it is removed before the conversion.
+	toExtractor := func(m map[string]interface{}) (*graylog.Extractor, string, error) {
+		inputID, _ := m["input_id"].(string)
+		data := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if k != "input_id" {
+				data[k] = v
+			}
+		}
+		extractor := &graylog.Extractor{}
+		return extractor, inputID, fromJSONMap(data, extractor)
+	}
+	return a.converge(ctx, KindExtractor, desired, live, operations{
+		create: func(ctx context.Context, want map[string]interface{}) (string, error) {
+			extractor, inputID, err := toExtractor(want)
+			if err != nil {
+				return "", err
+			}
+			_, err = a.cl.CreateExtractor(ctx, inputID, extractor)
+			return extractor.ID, err
+		},
+		update: func(ctx context.Context, id string, merged, want map[string]interface{}) error {
+			extractor, inputID, err := toExtractor(merged)
+			if err != nil {
+				return err
+			}
+			extractor.ID = id
+			_, err = a.cl.UpdateExtractor(ctx, inputID, extractor)
+			return err
+		},
+		del: func(ctx context.Context, r resource) error {
+			inputID, _ := r.data["input_id"].(string)
+			_, err := a.cl.DeleteExtractor(ctx, inputID, r.id)
+			return err
+		},
+	})
+}
+
+func (a *Applier) applyPipelineRules(ctx context.Context, docs []Document) error {
+	desired := make([]resource, len(docs))
+	for i, doc := range docs {
+		rule := &graylog.PipelineRule{}
+		if err := decodeSpec(doc, rule); err != nil {
+			return err
+		}
+		title := rule.Title
+		if title == "" {
+			title = titleOfSource(pipelineRuleTitlePattern, rule.Source)
+		}
+		if title == "" {
+			return errors.New("title or source is required for pipeline_rule")
+		}
+		r, err := newDesired(title, rule, doc.Spec)
+		if err != nil {
+			return err
+		}
+		desired[i] = r
+	}
+	rules, _, err := a.cl.GetPipelineRules(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pipeline rules")
+	}
+	live := make([]resource, len(rules))
+	for i, rule := range rules {
+		r, err := newLive(rule.Title, rule.ID, &rule)
+		if err != nil {
+			return err
+		}
+		live[i] = r
+	}
+	return a.converge(ctx, KindPipelineRule, desired, live, operations{
+		create: func(ctx context.Context, want map[string]interface{}) (string, error) {
+			rule := &graylog.PipelineRule{}
+			if err := fromJSONMap(want, rule); err != nil {
+				return "", err
+			}
+			_, err := a.cl.CreatePipelineRule(ctx, rule)
+			return rule.ID, err
+		},
+		update: func(ctx context.Context, id string, merged, want map[string]interface{}) error {
+			rule := &graylog.PipelineRule{}
+			if err := fromJSONMap(merged, rule); err != nil {
+				return err
+			}
+			rule.ID = id
+			_, err := a.cl.UpdatePipelineRule(ctx, rule)
+			return err
+		},
+		del: func(ctx context.Context, r resource) error {
+			_, err := a.cl.DeletePipelineRule(ctx, r.id)
+			return err
+		},
+	})
+}
+
+func (a *Applier) applyPipelines(ctx context.Context, docs []Document) error {
+	desired := make([]resource, len(docs))
+	for i, doc := range docs {
+		pipeline := &graylog.Pipeline{}
+		if err := decodeSpec(doc, pipeline); err != nil {
+			return err
+		}
+		title := pipeline.Title
+		if title == "" {
+			title = titleOfSource(pipelineTitlePattern, pipeline.Source)
+		}
+		if title == "" {
+			return errors.New("title or source is required for pipeline")
+		}
+		r, err := newDesired(title, pipeline, doc.Spec)
+		if err != nil {
+			return err
+		}
+		desired[i] = r
+	}
+	pipelines, _, err := a.cl.GetPipelines(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pipelines")
+	}
+	live := make([]resource, len(pipelines))
+	for i, pipeline := range pipelines {
+		r, err := newLive(pipeline.Title, pipeline.ID, &pipeline)
+		if err != nil {
+			return err
+		}
+		live[i] = r
+	}
+	return a.converge(ctx, KindPipeline, desired, live, operations{
+		create: func(ctx context.Context, want map[string]interface{}) (string, error) {
+			pipeline := &graylog.Pipeline{}
+			if err := fromJSONMap(want, pipeline); err != nil {
+				return "", err
+			}
+			_, err := a.cl.CreatePipeline(ctx, pipeline)
+			return pipeline.ID, err
+		},
+		update: func(ctx context.Context, id string, merged, want map[string]interface{}) error {
+			pipeline := &graylog.Pipeline{}
+			if err := fromJSONMap(merged, pipeline); err != nil {
+				return err
+			}
+			pipeline.ID = id
+			_, err := a.cl.UpdatePipeline(ctx, pipeline)
+			return err
+		},
+		del: func(ctx context.Context, r resource) error {
+			_, err := a.cl.DeletePipeline(ctx, r.id)
+			return err
+		},
+	})
+}
+
+func (a *Applier) applyRoles(ctx context.Context, docs []Document) error {
+	desired := make([]resource, len(docs))
+	for i, doc := range docs {
+		role := &graylog.Role{}
+		if err := decodeSpec(doc, role); err != nil {
+			return err
+		}
+		if role.Name == "" {
+			return errors.New("name is required for role")
+		}
+		r, err := newDesired(role.Name, role, doc.Spec)
+		if err != nil {
+			return err
+		}
+		sortSet(r.data, "permissions")
+		desired[i] = r
+	}
+	roles, _, _, err := a.cl.GetRoles(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get roles")
+	}
+	live := make([]resource, len(roles))
+	for i, role := range roles {
+		r, err := newLive(role.Name, role.Name, &role)
+		if err != nil {
+			return err
+		}
+		sortSet(r.data, "permissions")
+		r.protected = role.ReadOnly
+		live[i] = r
+	}
+	return a.converge(ctx, KindRole, desired, live, operations{
+		create: func(ctx context.Context, want map[string]interface{}) (string, error) {
+			role := &graylog.Role{}
+			if err := fromJSONMap(want, role); err != nil {
+				return "", err
+			}
+			_, err := a.cl.CreateRole(ctx, role)
+			return role.Name, err
+		},
+		update: func(ctx context.Context, id string, merged, want map[string]interface{}) error {
+			role := &graylog.Role{}
+			if err := fromJSONMap(merged, role); err != nil {
+				return err
+			}
+			_, _, err := a.cl.UpdateRole(ctx, id, role.NewUpdateParams())
+			return err
+		},
+		del: func(ctx context.Context, r resource) error {
+			_, err := a.cl.DeleteRole(ctx, r.id)
+			return err
+		},
+	})
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 
 	"github.com/suzuki-shunsuke/go-graylog"
 )
@@ -15,3 +16,33 @@ func (client *Client) GetAlarmCallbacks(ctx context.Context) (
 		ctx, client.Endpoints().AlarmCallbacks(), nil, body)
 	return body.AlarmCallbacks, body.Total, ei, err
 }
+
+// TestAlarmCallback sends a test alert with an alarm callback.
+// If the alarm callback fails, the returned result's Success is false and Message is the callback's error message,
+// and the returned error is nil.
+func (client *Client) TestAlarmCallback(ctx context.Context, id string) (
+	*graylog.AlarmCallbackTestResult, *ErrorInfo, error,
+) {
+	if id == "" {
+		return nil, nil, errors.New("id is empty")
+	}
+	ei, err := client.callPost(ctx, client.Endpoints().AlarmCallbackTest(id), nil, nil)
+	if err == nil {
+		return &graylog.AlarmCallbackTestResult{Success: true}, ei, nil
+	}
+	// the callback's error is returned as 400 Bad Request
+	if ei != nil && ei.Response != nil && ei.Response.StatusCode == 400 && ei.Message != "" {
+		return &graylog.AlarmCallbackTestResult{Message: ei.Message}, ei, nil
+	}
+	return nil, ei, err
+}
+
+// GetAlarmCallbackTypes returns available alarm callback types.
+// The key of the returned map is the type such as graylog.HTTPAlarmCallbackType.
+func (client *Client) GetAlarmCallbackTypes(ctx context.Context) (
+	map[string]graylog.AlarmCallbackTypeInfo, *ErrorInfo, error,
+) {
+	body := &graylog.AlarmCallbackTypesBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().AlarmCallbackTypes(), nil, body)
+	return body.Types, ei, err
+}
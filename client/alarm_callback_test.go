@@ -2,8 +2,14 @@ package client_test
 
 import (
 	"context"
+	"io/ioutil"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
 	"github.com/suzuki-shunsuke/go-graylog/testutil"
 )
 
@@ -22,3 +28,63 @@ func TestClient_GetAlarmCallbacks(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestClient_TestAlarmCallback(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	_, _, err = cl.TestAlarmCallback(ctx, "")
+	require.NotNil(t, err)
+
+	gock.New("http://example.com").
+		Post("/api/alerts/callbacks/xxxxx/test").
+		MatchType("json").Reply(200)
+	result, _, err := cl.TestAlarmCallback(ctx, "xxxxx")
+	require.Nil(t, err)
+	require.Equal(t, &graylog.AlarmCallbackTestResult{Success: true}, result)
+
+	gock.New("http://example.com").
+		Post("/api/alerts/callbacks/xxxxx/test").
+		MatchType("json").Reply(400).
+		BodyString(`{"type": "ApiError", "message": "Connection refused"}`)
+	result, _, err = cl.TestAlarmCallback(ctx, "xxxxx")
+	require.Nil(t, err)
+	require.Equal(t, &graylog.AlarmCallbackTestResult{Message: "Connection refused"}, result)
+
+	gock.New("http://example.com").
+		Post("/api/alerts/callbacks/xxxxx/test").
+		MatchType("json").Reply(404).
+		BodyString(`{"type": "ApiError", "message": "not found"}`)
+	_, _, err = cl.TestAlarmCallback(ctx, "xxxxx")
+	require.NotNil(t, err)
+}
+
+func TestClient_GetAlarmCallbackTypes(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	buf, err := ioutil.ReadFile("../testdata/alarm_callback_types.json")
+	require.Nil(t, err)
+	gock.New("http://example.com").
+		Get("/api/alerts/callbacks/types").
+		MatchType("json").Reply(200).
+		BodyString(string(buf))
+	types, _, err := cl.GetAlarmCallbackTypes(ctx)
+	require.Nil(t, err)
+	http, ok := types[graylog.HTTPAlarmCallbackType]
+	require.True(t, ok)
+	require.Equal(t, "HTTP Alarm Callback", http.Name)
+	require.Equal(t, graylog.ConfigurationField{
+		Type:           "text",
+		HumanName:      "URL",
+		Description:    "The URL to POST to when an alert is triggered",
+		DefaultValue:   "https://example.org/alerts",
+		Attributes:     []string{},
+		AdditionalInfo: map[string]interface{}{},
+		Position:       100,
+	}, http.RequestedConfiguration["url"])
+}
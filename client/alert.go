@@ -10,6 +10,8 @@ import (
 )
 
 // GetAlert returns an alert.
+// The alert's condition parameters are decoded as graylog.GeneralAlertConditionParameters.
+// To decode them into the struct of the condition type, call ResolveAlertConditionTypes.
 func (client *Client) GetAlert(ctx context.Context, id string) (
 	*graylog.Alert, *ErrorInfo, error,
 ) {
@@ -23,6 +25,8 @@ func (client *Client) GetAlert(ctx context.Context, id string) (
 }
 
 // GetAlerts returns all alerts.
+// The alerts' condition parameters are decoded as graylog.GeneralAlertConditionParameters.
+// To decode them into the struct of the condition type, call ResolveAlertConditionTypes.
 func (client *Client) GetAlerts(ctx context.Context, skip, limit int) (
 	[]graylog.Alert, int, *ErrorInfo, error,
 ) {
@@ -35,3 +39,72 @@ func (client *Client) GetAlerts(ctx context.Context, skip, limit int) (
 		ctx, client.Endpoints().Alerts()+"?"+v.Encode(), nil, body)
 	return body.Alerts, body.Total, ei, err
 }
+
+// GetAlertsPaginated returns alerts filtered by the state.
+// The state is one of graylog.AlertStateAny, graylog.AlertStateResolved and graylog.AlertStateUnresolved.
+// If the state is empty, all alerts are returned.
+// The alerts' condition parameters are decoded as graylog.GeneralAlertConditionParameters.
+// To decode them into the struct of the condition type, call ResolveAlertConditionTypes.
+func (client *Client) GetAlertsPaginated(
+	ctx context.Context, skip, limit int, state string,
+) ([]graylog.Alert, int, *ErrorInfo, error) {
+	body := &graylog.AlertsBody{}
+	ei, err := client.callGet(
+		ctx, client.Endpoints().AlertsPaginated()+"?"+alertsQuery(skip, limit, state).Encode(), nil, body)
+	return body.Alerts, body.Total, ei, err
+}
+
+// GetUnresolvedAlerts returns unresolved alerts of all streams.
+func (client *Client) GetUnresolvedAlerts(ctx context.Context, skip, limit int) (
+	[]graylog.Alert, int, *ErrorInfo, error,
+) {
+	return client.GetAlertsPaginated(ctx, skip, limit, graylog.AlertStateUnresolved)
+}
+
+func alertsQuery(skip, limit int, state string) url.Values {
+	v := url.Values{
+		"skip":  []string{strconv.Itoa(skip)},
+		"limit": []string{strconv.Itoa(limit)},
+	}
+	if state != "" {
+		v.Set("state", state)
+	}
+	return v
+}
+
+// ResolveAlertConditionTypes gets all alert conditions and decodes the alerts' condition parameters
+// into the struct of the condition type in place.
+// Graylog's alert API doesn't return the condition type, so an additional API call is needed.
+// If alerts is empty, no API is called.
+func (client *Client) ResolveAlertConditionTypes(
+	ctx context.Context, alerts []graylog.Alert,
+) (*ErrorInfo, error) {
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+	conds, _, ei, err := client.GetAlertConditions(ctx)
+	if err != nil {
+		return ei, err
+	}
+	return ei, setAlertConditionTypes(alerts, conds)
+}
+
+// setAlertConditionTypes decodes the alerts' condition parameters by the type of the alert conditions.
+// The parameters of an alert whose condition isn't found (for example, the condition has been removed)
+// are kept as graylog.GeneralAlertConditionParameters.
+func setAlertConditionTypes(alerts []graylog.Alert, conds []graylog.AlertCondition) error {
+	types := make(map[string]string, len(conds))
+	for _, cond := range conds {
+		types[cond.ID] = cond.Type()
+	}
+	for i := range alerts {
+		t, ok := types[alerts[i].ConditionID]
+		if !ok {
+			continue
+		}
+		if err := alerts[i].SetConditionType(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -15,3 +15,13 @@ func (client *Client) GetAlertConditions(ctx context.Context) (
 		ctx, client.Endpoints().AlertConditions(), nil, conditions)
 	return conditions.AlertConditions, conditions.Total, ei, err
 }
+
+// GetAlertConditionTypes returns available alert condition types.
+// The key of the returned map is the type such as "message_count".
+func (client *Client) GetAlertConditionTypes(ctx context.Context) (
+	map[string]graylog.AlertConditionTypeInfo, *ErrorInfo, error,
+) {
+	types := map[string]graylog.AlertConditionTypeInfo{}
+	ei, err := client.callGet(ctx, client.Endpoints().AlertConditionTypes(), nil, &types)
+	return types, ei, err
+}
@@ -2,8 +2,13 @@ package client_test
 
 import (
 	"context"
+	"io/ioutil"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
 	"github.com/suzuki-shunsuke/go-graylog/testutil"
 )
 
@@ -22,3 +27,25 @@ func TestClient_GetAlertConditions(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestClient_GetAlertConditionTypes(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	buf, err := ioutil.ReadFile("../testdata/alert_condition_types.json")
+	require.Nil(t, err)
+	gock.New("http://example.com").
+		Get("/api/alerts/conditions/types").
+		MatchType("json").Reply(200).
+		BodyString(string(buf))
+	types, _, err := cl.GetAlertConditionTypes(ctx)
+	require.Nil(t, err)
+	mc, ok := types["message_count"]
+	require.True(t, ok)
+	require.Equal(t, "Message Count Alert Condition", mc.HumanName)
+	require.Equal(t, "number", mc.RequestedConfiguration["time"].Type)
+	require.Equal(t, []string{"only_positive"}, mc.RequestedConfiguration["time"].Attributes)
+	require.Equal(t, "MORE", mc.RequestedConfiguration["threshold_type"].DefaultValue)
+}
@@ -2,8 +2,15 @@ package client_test
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
 	"github.com/suzuki-shunsuke/go-graylog/testutil"
 )
 
@@ -40,3 +47,50 @@ func TestClient_GetAlert(t *testing.T) {
 		t.Fatal("alert should not be found")
 	}
 }
+
+func TestClient_GetUnresolvedAlerts(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	alerts, err := ioutil.ReadFile("../testdata/stream_alerts.json")
+	require.Nil(t, err)
+	gock.New("http://example.com").
+		Get("/api/streams/alerts/paginated").
+		MatchParams(map[string]string{"skip": "0", "limit": "10", "state": "unresolved"}).
+		MatchType("json").Reply(200).
+		BodyString(string(alerts))
+	as, total, _, err := cl.GetUnresolvedAlerts(ctx, 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	// the alert conditions aren't got
+	require.Equal(t, "", as[0].ConditionParameters.AlertConditionType())
+}
+
+func TestClient_ResolveAlertConditionTypes(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	// no API is called
+	_, err = cl.ResolveAlertConditionTypes(ctx, nil)
+	require.Nil(t, err)
+
+	b, err := ioutil.ReadFile("../testdata/stream_alerts.json")
+	require.Nil(t, err)
+	body := &graylog.AlertsBody{}
+	require.Nil(t, json.Unmarshal(b, body))
+	conds, err := ioutil.ReadFile("../testdata/stream_alert_conditions.json")
+	require.Nil(t, err)
+	gock.New("http://example.com").
+		Get("/api/alerts/conditions").
+		MatchType("json").Reply(200).
+		BodyString(string(conds))
+	_, err = cl.ResolveAlertConditionTypes(ctx, body.Alerts)
+	require.Nil(t, err)
+	require.Equal(t, "field_content_value", body.Alerts[0].ConditionParameters.AlertConditionType())
+	// the alert condition has been removed
+	require.Equal(t, "", body.Alerts[1].ConditionParameters.AlertConditionType())
+}
@@ -4,3 +4,15 @@ package endpoint
 func (ep *Endpoints) AlarmCallbacks() string {
 	return ep.alarmCallbacks
 }
+
+// AlarmCallbackTest returns Test Alarm Callback API's endpoint url.
+func (ep *Endpoints) AlarmCallbackTest(id string) string {
+	// /alerts/callbacks/{id}/test
+	return ep.alarmCallbacks + "/" + id + "/test"
+}
+
+// AlarmCallbackTypes returns Alarm Callback Types API's endpoint url.
+func (ep *Endpoints) AlarmCallbackTypes() string {
+	// /alerts/callbacks/types
+	return ep.alarmCallbacks + "/types"
+}
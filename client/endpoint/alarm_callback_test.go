@@ -14,3 +14,15 @@ func TestEndpoints_AlarmCallbacks(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, fmt.Sprintf("%s/alerts/callbacks", apiURL), ep.AlarmCallbacks())
 }
+
+func TestEndpoints_AlarmCallbackTest(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/alerts/callbacks/%s/test", apiURL, ID), ep.AlarmCallbackTest(ID))
+}
+
+func TestEndpoints_AlarmCallbackTypes(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/alerts/callbacks/types", apiURL), ep.AlarmCallbackTypes())
+}
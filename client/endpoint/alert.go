@@ -9,3 +9,8 @@ func (ep *Endpoints) Alert(id string) string {
 func (ep *Endpoints) Alerts() string {
 	return ep.alerts
 }
+
+// AlertsPaginated returns Get Paginated Alerts API's endpoint url.
+func (ep *Endpoints) AlertsPaginated() string {
+	return ep.alerts + "/paginated"
+}
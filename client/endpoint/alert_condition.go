@@ -4,3 +4,9 @@ package endpoint
 func (ep *Endpoints) AlertConditions() string {
 	return ep.alertConditions
 }
+
+// AlertConditionTypes returns Alert Condition Types API's endpoint url.
+func (ep *Endpoints) AlertConditionTypes() string {
+	// /alerts/conditions/types
+	return ep.alertConditions + "/types"
+}
@@ -14,3 +14,9 @@ func TestEndpoints_AlertConditions(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, fmt.Sprintf("%s/alerts/conditions", apiURL), ep.AlertConditions())
 }
+
+func TestEndpoints_AlertConditionTypes(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/alerts/conditions/types", apiURL), ep.AlertConditionTypes())
+}
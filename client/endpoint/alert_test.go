@@ -20,3 +20,9 @@ func TestEndpoints_Alert(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, fmt.Sprintf("%s/streams/alerts/%s", apiURL, ID), ep.Alert(ID))
 }
+
+func TestEndpoints_AlertsPaginated(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/streams/alerts/paginated", apiURL), ep.AlertsPaginated())
+}
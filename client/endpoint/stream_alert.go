@@ -0,0 +1,19 @@
+package endpoint
+
+// StreamAlerts returns Stream Alerts API's endpoint url.
+func (ep *Endpoints) StreamAlerts(streamID string) string {
+	// /streams/{streamId}/alerts
+	return ep.streams + "/" + streamID + "/alerts"
+}
+
+// StreamAlertsPaginated returns Get Paginated Stream Alerts API's endpoint url.
+func (ep *Endpoints) StreamAlertsPaginated(streamID string) string {
+	// /streams/{streamId}/alerts/paginated
+	return ep.streams + "/" + streamID + "/alerts/paginated"
+}
+
+// StreamAlertReceivers returns Stream Alert Receivers API's endpoint url.
+func (ep *Endpoints) StreamAlertReceivers(streamID string) string {
+	// /streams/{streamId}/alerts/receivers
+	return ep.streams + "/" + streamID + "/alerts/receivers"
+}
@@ -11,3 +11,9 @@ func (ep *Endpoints) StreamAlertConditions(streamID string) string {
 	// /streams/{streamId}/alerts/conditions
 	return ep.streams + "/" + streamID + "/alerts/conditions"
 }
+
+// StreamAlertConditionTest returns Test Stream Alert Condition API's endpoint url.
+func (ep *Endpoints) StreamAlertConditionTest(streamID, id string) string {
+	// /streams/{streamId}/alerts/conditions/{conditionId}/test
+	return ep.streams + "/" + streamID + "/alerts/conditions/" + id + "/test"
+}
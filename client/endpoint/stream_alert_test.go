@@ -0,0 +1,28 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestEndpoints_StreamAlerts(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/streams/%s/alerts", apiURL, ID), ep.StreamAlerts(ID))
+}
+
+func TestEndpoints_StreamAlertsPaginated(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/streams/%s/alerts/paginated", apiURL, ID), ep.StreamAlertsPaginated(ID))
+}
+
+func TestEndpoints_StreamAlertReceivers(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/streams/%s/alerts/receivers", apiURL, ID), ep.StreamAlertReceivers(ID))
+}
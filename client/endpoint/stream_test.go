@@ -38,3 +38,9 @@ func TestEndpoints_EnabledStreams(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, fmt.Sprintf("%s/streams/enabled", apiURL), ep.EnabledStreams())
 }
+
+func TestEndpoints_StreamAlertConditionTest(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/streams/%s/alerts/conditions/%s/test", apiURL, ID, ID), ep.StreamAlertConditionTest(ID, ID))
+}
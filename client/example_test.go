@@ -6,7 +6,7 @@ import (
 	"log"
 
 	"github.com/suzuki-shunsuke/go-graylog/client"
-	"github.com/suzuki-shunsuke/graylog-mock-server/mockserver"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
 )
 
 func ExampleClient() {
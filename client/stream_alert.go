@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetStreamAlerts returns the alerts of a stream which have been triggered since the given unix time.
+// The alerts' condition parameters are decoded as graylog.GeneralAlertConditionParameters.
+// To decode them into the struct of the condition type, call ResolveAlertConditionTypes.
+func (client *Client) GetStreamAlerts(
+	ctx context.Context, streamID string, since, limit int,
+) ([]graylog.Alert, int, *ErrorInfo, error) {
+	// GET /streams/{streamId}/alerts Get the most recent alarms of this stream.
+	if streamID == "" {
+		return nil, 0, nil, errors.New("stream id is empty")
+	}
+	body := &graylog.AlertsBody{}
+	v := url.Values{
+		"since": []string{strconv.Itoa(since)},
+		"limit": []string{strconv.Itoa(limit)},
+	}
+	ei, err := client.callGet(
+		ctx, client.Endpoints().StreamAlerts(streamID)+"?"+v.Encode(), nil, body)
+	return body.Alerts, body.Total, ei, err
+}
+
+// GetStreamAlertsPaginated returns the alerts of a stream filtered by the state.
+// The state is one of graylog.AlertStateAny, graylog.AlertStateResolved and graylog.AlertStateUnresolved.
+// If the state is empty, all alerts are returned.
+// The alerts' condition parameters are decoded as graylog.GeneralAlertConditionParameters.
+// To decode them into the struct of the condition type, call ResolveAlertConditionTypes.
+func (client *Client) GetStreamAlertsPaginated(
+	ctx context.Context, streamID string, skip, limit int, state string,
+) ([]graylog.Alert, int, *ErrorInfo, error) {
+	// GET /streams/{streamId}/alerts/paginated Get the alarms of this stream, filtered by specifying limit and offset parameters.
+	if streamID == "" {
+		return nil, 0, nil, errors.New("stream id is empty")
+	}
+	body := &graylog.AlertsBody{}
+	ei, err := client.callGet(
+		ctx, client.Endpoints().StreamAlertsPaginated(streamID)+"?"+alertsQuery(skip, limit, state).Encode(), nil, body)
+	return body.Alerts, body.Total, ei, err
+}
+
+// GetStreamUnresolvedAlerts returns the unresolved alerts of a stream.
+func (client *Client) GetStreamUnresolvedAlerts(
+	ctx context.Context, streamID string, skip, limit int,
+) ([]graylog.Alert, int, *ErrorInfo, error) {
+	return client.GetStreamAlertsPaginated(ctx, streamID, skip, limit, graylog.AlertStateUnresolved)
+}
+
+// AddStreamAlertReceiver adds an alert receiver to a stream.
+// The receiver type is graylog.AlertReceiverTypeUsers or graylog.AlertReceiverTypeEmails,
+// and the entity is a user name or an email address.
+func (client *Client) AddStreamAlertReceiver(
+	ctx context.Context, streamID, receiverType, entity string,
+) (*ErrorInfo, error) {
+	// POST /streams/{streamId}/alerts/receivers Add an alert receiver
+	u, err := client.streamAlertReceiversURL(streamID, receiverType, entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add a stream alert receiver: %v", err)
+	}
+	return client.callPost(ctx, u, nil, nil)
+}
+
+// RemoveStreamAlertReceiver removes an alert receiver from a stream.
+// The receiver type is graylog.AlertReceiverTypeUsers or graylog.AlertReceiverTypeEmails,
+// and the entity is a user name or an email address.
+func (client *Client) RemoveStreamAlertReceiver(
+	ctx context.Context, streamID, receiverType, entity string,
+) (*ErrorInfo, error) {
+	// DELETE /streams/{streamId}/alerts/receivers Remove an alert receiver
+	u, err := client.streamAlertReceiversURL(streamID, receiverType, entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove a stream alert receiver: %v", err)
+	}
+	return client.callDelete(ctx, u, nil, nil)
+}
+
+// AddStreamAlertReceiverUser adds a user to a stream's alert receivers.
+func (client *Client) AddStreamAlertReceiverUser(
+	ctx context.Context, streamID, userName string,
+) (*ErrorInfo, error) {
+	return client.AddStreamAlertReceiver(ctx, streamID, graylog.AlertReceiverTypeUsers, userName)
+}
+
+// RemoveStreamAlertReceiverUser removes a user from a stream's alert receivers.
+func (client *Client) RemoveStreamAlertReceiverUser(
+	ctx context.Context, streamID, userName string,
+) (*ErrorInfo, error) {
+	return client.RemoveStreamAlertReceiver(ctx, streamID, graylog.AlertReceiverTypeUsers, userName)
+}
+
+// AddStreamAlertReceiverEmail adds an email address to a stream's alert receivers.
+func (client *Client) AddStreamAlertReceiverEmail(
+	ctx context.Context, streamID, email string,
+) (*ErrorInfo, error) {
+	return client.AddStreamAlertReceiver(ctx, streamID, graylog.AlertReceiverTypeEmails, email)
+}
+
+// RemoveStreamAlertReceiverEmail removes an email address from a stream's alert receivers.
+func (client *Client) RemoveStreamAlertReceiverEmail(
+	ctx context.Context, streamID, email string,
+) (*ErrorInfo, error) {
+	return client.RemoveStreamAlertReceiver(ctx, streamID, graylog.AlertReceiverTypeEmails, email)
+}
+
+func (client *Client) streamAlertReceiversURL(streamID, receiverType, entity string) (string, error) {
+	if streamID == "" {
+		return "", errors.New("stream id is empty")
+	}
+	if receiverType != graylog.AlertReceiverTypeUsers && receiverType != graylog.AlertReceiverTypeEmails {
+		return "", fmt.Errorf(`receiver type must be "%s" or "%s": %s`,
+			graylog.AlertReceiverTypeUsers, graylog.AlertReceiverTypeEmails, receiverType)
+	}
+	if entity == "" {
+		return "", errors.New("entity is empty")
+	}
+	v := url.Values{
+		"entity": []string{entity},
+		"type":   []string{receiverType},
+	}
+	return client.Endpoints().StreamAlertReceivers(streamID) + "?" + v.Encode(), nil
+}
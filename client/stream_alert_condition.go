@@ -91,3 +91,22 @@ func (client *Client) DeleteStreamAlertCondition(
 	}
 	return client.callDelete(ctx, client.Endpoints().StreamAlertCondition(streamID, id), nil, nil)
 }
+
+// TestStreamAlertCondition runs the check of an alert condition without triggering alerts.
+func (client *Client) TestStreamAlertCondition(
+	ctx context.Context, streamID, id string,
+) (*graylog.AlertConditionTestResult, *ErrorInfo, error) {
+	if streamID == "" {
+		return nil, nil, errors.New("stream id is empty")
+	}
+	if id == "" {
+		return nil, nil, errors.New("id is empty")
+	}
+	result := &graylog.AlertConditionTestResult{}
+	ei, err := client.callPost(
+		ctx, client.Endpoints().StreamAlertConditionTest(streamID, id), nil, result)
+	if err != nil {
+		return nil, ei, err
+	}
+	return result, ei, nil
+}
@@ -3,6 +3,7 @@ package client_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"testing"
 
 	"gopkg.in/h2non/gock.v1"
@@ -271,3 +272,41 @@ func TestClient_DeleteStreamAlertCondition(t *testing.T) {
 		d.checkErr(t, err)
 	}
 }
+
+func TestClient_TestStreamAlertCondition(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	_, _, err = cl.TestStreamAlertCondition(ctx, "", "yyyyy")
+	require.NotNil(t, err)
+	_, _, err = cl.TestStreamAlertCondition(ctx, "xxxxx", "")
+	require.NotNil(t, err)
+
+	buf, err := ioutil.ReadFile("../testdata/alert_condition_test_result.json")
+	require.Nil(t, err)
+	gock.New("http://example.com").
+		Post("/api/streams/xxxxx/alerts/conditions/yyyyy/test").
+		MatchType("json").Reply(200).
+		BodyString(string(buf))
+	result, _, err := cl.TestStreamAlertCondition(ctx, "xxxxx", "yyyyy")
+	require.Nil(t, err)
+	require.True(t, result.Triggered)
+	require.Equal(t, "2019-09-20T12:10:17.793Z", result.TriggeredAt)
+	require.Len(t, result.MatchingMessages, 1)
+	require.Equal(t, "graylog_0", result.MatchingMessages[0].Index)
+	require.Equal(t, "hoge hoge", result.MatchingMessages[0].Message["message"])
+
+	gock.New("http://example.com").
+		Post("/api/streams/xxxxx/alerts/conditions/yyyyy/test").
+		MatchType("json").Reply(200).
+		BodyString(`{"error": true, "error_messages": [{"type": "java.lang.IllegalArgumentException", "message": "invalid query"}]}`)
+	result, _, err = cl.TestStreamAlertCondition(ctx, "xxxxx", "yyyyy")
+	require.Nil(t, err)
+	require.False(t, result.Triggered)
+	require.True(t, result.Error)
+	require.Equal(t, []graylog.AlertConditionTestError{
+		{Type: "java.lang.IllegalArgumentException", Message: "invalid query"},
+	}, result.ErrorMessages)
+}
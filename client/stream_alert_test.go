@@ -0,0 +1,128 @@
+package client_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func TestClient_GetStreamAlerts(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	_, _, _, err = cl.GetStreamAlerts(ctx, "", 0, 10)
+	require.NotNil(t, err)
+
+	alerts, err := ioutil.ReadFile("../testdata/stream_alerts.json")
+	require.Nil(t, err)
+	conds, err := ioutil.ReadFile("../testdata/stream_alert_conditions.json")
+	require.Nil(t, err)
+	gock.New("http://example.com").
+		Get("/api/streams/5d84c1a92ab79c000d35d6ca/alerts").
+		MatchParams(map[string]string{"since": "0", "limit": "10"}).
+		MatchType("json").Reply(200).
+		BodyString(string(alerts))
+	as, total, _, err := cl.GetStreamAlerts(ctx, "5d84c1a92ab79c000d35d6ca", 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, as, 2)
+	// the alert conditions aren't got
+	require.Equal(t, "", as[0].ConditionParameters.AlertConditionType())
+
+	gock.New("http://example.com").
+		Get("/api/alerts/conditions").
+		MatchType("json").Reply(200).
+		BodyString(string(conds))
+	_, err = cl.ResolveAlertConditionTypes(ctx, as)
+	require.Nil(t, err)
+	require.Equal(t, graylog.FieldContentAlertConditionParameters{
+		Backlog: 2, Field: "message", Query: "*", Value: "hoge hoge",
+	}, as[0].ConditionParameters)
+	require.False(t, as[0].IsResolved())
+	// the alert condition has been removed
+	require.Equal(t, graylog.GeneralAlertConditionParameters{
+		Parameters: map[string]interface{}{"grace": float64(0), "threshold": float64(1)},
+	}, as[1].ConditionParameters)
+	require.True(t, as[1].IsResolved())
+}
+
+func TestClient_GetStreamUnresolvedAlerts(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	_, _, _, err = cl.GetStreamUnresolvedAlerts(ctx, "", 0, 10)
+	require.NotNil(t, err)
+
+	gock.New("http://example.com").
+		Get("/api/streams/xxxxx/alerts/paginated").
+		MatchParams(map[string]string{"skip": "0", "limit": "10", "state": "unresolved"}).
+		MatchType("json").Reply(200).
+		BodyString(`{"total": 0, "alerts": []}`)
+	alerts, total, _, err := cl.GetStreamUnresolvedAlerts(ctx, "xxxxx", 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, 0, total)
+	require.Empty(t, alerts)
+}
+
+func TestClient_AddStreamAlertReceiver(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	_, err = cl.AddStreamAlertReceiver(ctx, "", graylog.AlertReceiverTypeUsers, "admin")
+	require.NotNil(t, err)
+	_, err = cl.AddStreamAlertReceiver(ctx, "xxxxx", "groups", "admin")
+	require.NotNil(t, err)
+	_, err = cl.AddStreamAlertReceiver(ctx, "xxxxx", graylog.AlertReceiverTypeUsers, "")
+	require.NotNil(t, err)
+
+	gock.New("http://example.com").
+		Post("/api/streams/xxxxx/alerts/receivers").
+		MatchParams(map[string]string{"entity": "admin", "type": "users"}).
+		MatchType("json").Reply(201)
+	_, err = cl.AddStreamAlertReceiverUser(ctx, "xxxxx", "admin")
+	require.Nil(t, err)
+
+	gock.New("http://example.com").
+		Post("/api/streams/xxxxx/alerts/receivers").
+		MatchParams(map[string]string{"entity": "foo@example.com", "type": "emails"}).
+		MatchType("json").Reply(201)
+	_, err = cl.AddStreamAlertReceiverEmail(ctx, "xxxxx", "foo@example.com")
+	require.Nil(t, err)
+}
+
+func TestClient_RemoveStreamAlertReceiver(t *testing.T) {
+	ctx := context.Background()
+	defer gock.Off()
+	cl, err := client.NewClient("http://example.com/api", "admin", "password")
+	require.Nil(t, err)
+
+	_, err = cl.RemoveStreamAlertReceiver(ctx, "xxxxx", "", "admin")
+	require.NotNil(t, err)
+
+	gock.New("http://example.com").
+		Delete("/api/streams/xxxxx/alerts/receivers").
+		MatchParams(map[string]string{"entity": "admin", "type": "users"}).
+		MatchType("json").Reply(204)
+	_, err = cl.RemoveStreamAlertReceiverUser(ctx, "xxxxx", "admin")
+	require.Nil(t, err)
+
+	gock.New("http://example.com").
+		Delete("/api/streams/xxxxx/alerts/receivers").
+		MatchParams(map[string]string{"entity": "foo@example.com", "type": "emails"}).
+		MatchType("json").Reply(404).
+		BodyString(`{"type": "ApiError", "message": "not found"}`)
+	_, err = cl.RemoveStreamAlertReceiverEmail(ctx, "xxxxx", "foo@example.com")
+	require.NotNil(t, err)
+}
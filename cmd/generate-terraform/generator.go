@@ -0,0 +1,467 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	tfgraylog "github.com/suzuki-shunsuke/go-graylog/terraform/graylog"
+)
+
+type (
+	generator struct {
+		cl        *client.Client
+		meta      *tfgraylog.Config
+		resources map[string]*schema.Resource
+		// targets is the set of the generated resource types.
+		// If targets is empty, all resource types are generated.
+		targets map[string]bool
+		// names maps a resource type and a resource id to the resource name.
+		names map[string]map[string]string
+		// used is the set of used resource names per resource type.
+		used    map[string]map[string]bool
+		files   map[string]*bytes.Buffer
+		imports []string
+	}
+)
+
+// refAttrs maps an attribute name to the resource type which the attribute refers.
+var refAttrs = map[string]string{
+	"index_set_id": "graylog_index_set",
+	"stream_id":    "graylog_stream",
+	"input_id":     "graylog_input",
+	"dashboard_id": "graylog_dashboard",
+	"pipeline_ids": "graylog_pipeline",
+	"widget_id":    "graylog_dashboard_widget",
+	"roles":        "graylog_role",
+}
+
+// permissionTypes maps a permission's domain to the resource type.
+var permissionTypes = map[string]string{
+	"streams":    "graylog_stream",
+	"dashboards": "graylog_dashboard",
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+func newGenerator(cl *client.Client, meta *tfgraylog.Config, targets []string) (*generator, error) {
+	resources := tfgraylog.Provider().ResourcesMap
+	g := &generator{
+		cl:        cl,
+		meta:      meta,
+		resources: resources,
+		targets:   map[string]bool{},
+		names:     map[string]map[string]string{},
+		used:      map[string]map[string]bool{},
+		files:     map[string]*bytes.Buffer{},
+	}
+	for _, t := range targets {
+		if _, ok := resources[t]; !ok {
+			return nil, fmt.Errorf("unsupported resource type: %s", t)
+		}
+		g.targets[t] = true
+	}
+	return g, nil
+}
+
+// isTarget returns true if any of given resource types is generated.
+func (g *generator) isTarget(types ...string) bool {
+	if len(g.targets) == 0 {
+		return true
+	}
+	for _, typ := range types {
+		if g.targets[typ] {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceName returns a unique resource name generated from a given label.
+func (g *generator) resourceName(typ, label string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(label), "_"), "_-")
+	if name == "" {
+		name = strings.TrimPrefix(typ, "graylog_")
+	}
+	if name[0] >= '0' && name[0] <= '9' || name[0] == '-' {
+		name = "_" + name
+	}
+	used, ok := g.used[typ]
+	if !ok {
+		used = map[string]bool{}
+		g.used[typ] = used
+	}
+	base := name
+	for i := 2; used[name]; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+	used[name] = true
+	return name
+}
+
+// resolve returns a reference expression of a given attribute's value.
+func (g *generator) resolve(key, val string) (string, bool) {
+	if key == "permissions" {
+		return g.resolvePermission(val)
+	}
+	typ, ok := refAttrs[key]
+	if !ok {
+		return "", false
+	}
+	name, ok := g.names[typ][val]
+	if !ok {
+		return "", false
+	}
+	return typ + "." + name + ".id", true
+}
+
+// resolvePermission converts a permission "streams:read:<stream id>" to
+// "streams:read:${graylog_stream.<name>.id}".
+func (g *generator) resolvePermission(perm string) (string, bool) {
+	a := strings.Split(perm, ":")
+	if len(a) != 3 {
+		return "", false
+	}
+	typ, ok := permissionTypes[a[0]]
+	if !ok {
+		return "", false
+	}
+	name, ok := g.names[typ][a[2]]
+	if !ok {
+		return "", false
+	}
+	prefix := quote(a[0] + ":" + a[1] + ":")
+	return prefix[:len(prefix)-1] + "${" + typ + "." + name + ".id}\"", true
+}
+
+// add reads a resource by the resource's importer and writes the HCL and import command.
+// label is used to generate the resource name.
+func (g *generator) add(typ, importID, label string) error {
+	if !g.isTarget(typ) {
+		return nil
+	}
+	res := g.resources[typ]
+	d := res.Data(nil)
+	d.SetId(importID)
+	ds, err := res.Importer.State(d, g.meta)
+	if err != nil {
+		return errors.Wrapf(err, "failed to import %s %s", typ, importID)
+	}
+	for _, d := range ds {
+		if err := res.Read(d, g.meta); err != nil {
+			return errors.Wrapf(err, "failed to read %s %s", typ, importID)
+		}
+		if d.Id() == "" {
+			// the resource has been removed
+			continue
+		}
+		vals := make(map[string]interface{}, len(res.Schema))
+		for k := range res.Schema {
+			vals[k] = d.Get(k)
+		}
+		name := g.resourceName(typ, label)
+		ids, ok := g.names[typ]
+		if !ok {
+			ids = map[string]string{}
+			g.names[typ] = ids
+		}
+		ids[d.Id()] = name
+
+		buf, ok := g.files[typ]
+		if !ok {
+			buf = &bytes.Buffer{}
+			g.files[typ] = buf
+		} else {
+			buf.WriteString("\n")
+		}
+		hw := &hclWriter{w: buf, resolve: g.resolve}
+		if err := hw.writeResource(typ, name, res.Schema, vals); err != nil {
+			return err
+		}
+		g.imports = append(g.imports, fmt.Sprintf(
+			"terraform import %s.%s %s", typ, name, shellQuote(importID)))
+	}
+	return nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// generate reads all resources.
+// Resources are read in order of dependency so that references can be resolved.
+func (g *generator) generate(ctx context.Context) error {
+	indexSets, _, _, _, err := g.cl.GetIndexSets(ctx, 0, 0, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get index sets")
+	}
+	for _, is := range indexSets {
+		if err := g.add("graylog_index_set", is.ID, is.Title); err != nil {
+			return err
+		}
+	}
+
+	streams, _, _, err := g.cl.GetStreams(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get streams")
+	}
+	streamNames := map[string]string{}
+	for _, stream := range streams {
+		if stream.IsDefault {
+			// the default stream can't be managed by terraform
+			continue
+		}
+		streamNames[stream.ID] = stream.Title
+		if err := g.add("graylog_stream", stream.ID, stream.Title); err != nil {
+			return err
+		}
+	}
+	for _, stream := range streams {
+		if stream.IsDefault {
+			continue
+		}
+		for _, rule := range stream.Rules {
+			if err := g.add(
+				"graylog_stream_rule", stream.ID+"/"+rule.ID,
+				stream.Title+"_"+rule.Field); err != nil {
+				return err
+			}
+		}
+		for _, cond := range stream.AlertConditions {
+			if err := g.add(
+				"graylog_alert_condition", stream.ID+"/"+cond.ID, cond.Title); err != nil {
+				return err
+			}
+		}
+	}
+	if g.isTarget("graylog_alarm_callback") {
+		acs, _, _, err := g.cl.GetAlarmCallbacks(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get alarm callbacks")
+		}
+		for _, ac := range acs {
+			if _, ok := streamNames[ac.StreamID]; !ok {
+				// the stream of the alarm callback isn't generated
+				continue
+			}
+			if err := g.add(
+				"graylog_alarm_callback", ac.StreamID+"/"+ac.ID, ac.Title); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := g.generateInputs(ctx); err != nil {
+		return err
+	}
+
+	if g.isTarget("graylog_grok_pattern") {
+		patterns, _, err := g.cl.GetGrokPatterns(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get grok patterns")
+		}
+		for _, pattern := range patterns {
+			if err := g.add("graylog_grok_pattern", pattern.ID, pattern.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := g.generatePipelines(ctx, streamNames); err != nil {
+		return err
+	}
+	if err := g.generateDashboards(ctx); err != nil {
+		return err
+	}
+
+	if g.isTarget("graylog_role", "graylog_user") {
+		roles, _, _, err := g.cl.GetRoles(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get roles")
+		}
+		for _, role := range roles {
+			if role.ReadOnly {
+				// built-in roles such as Admin and Reader
+				continue
+			}
+			if err := g.add("graylog_role", role.Name, role.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if g.isTarget("graylog_user") {
+		users, _, err := g.cl.GetUsers(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get users")
+		}
+		for _, user := range users {
+			if user.ReadOnly || user.External {
+				// the root user and LDAP users
+				continue
+			}
+			if err := g.add("graylog_user", user.Username, user.Username); err != nil {
+				return err
+			}
+		}
+	}
+
+	if g.isTarget("graylog_ldap_setting") {
+		setting, _, err := g.cl.GetLDAPSetting(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the LDAP setting")
+		}
+		if setting.Enabled {
+			if err := g.add("graylog_ldap_setting", "ldap_setting_id", "ldap_setting"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) generateInputs(ctx context.Context) error {
+	if !g.isTarget("graylog_input", "graylog_input_static_fields", "graylog_extractor") {
+		return nil
+	}
+	inputs, _, _, err := g.cl.GetInputs(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get inputs")
+	}
+	for _, input := range inputs {
+		if err := g.add("graylog_input", input.ID, input.Title); err != nil {
+			return err
+		}
+		if len(input.StaticFields) != 0 {
+			if err := g.add("graylog_input_static_fields", input.ID, input.Title); err != nil {
+				return err
+			}
+		}
+		if !g.isTarget("graylog_extractor") {
+			continue
+		}
+		extractors, _, _, err := g.cl.GetExtractors(ctx, input.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get extractors of the input %s", input.Title)
+		}
+		for _, extractor := range extractors {
+			if err := g.add(
+				"graylog_extractor", input.ID+"/"+extractor.ID, extractor.Title); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) generatePipelines(ctx context.Context, streamNames map[string]string) error {
+	if g.isTarget("graylog_pipeline_rule") {
+		rules, _, err := g.cl.GetPipelineRules(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get pipeline rules")
+		}
+		for _, rule := range rules {
+			if err := g.add("graylog_pipeline_rule", rule.ID, rule.Title); err != nil {
+				return err
+			}
+		}
+	}
+	if !g.isTarget("graylog_pipeline", "graylog_pipeline_connection") {
+		return nil
+	}
+	pipelines, _, err := g.cl.GetPipelines(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pipelines")
+	}
+	for _, pipeline := range pipelines {
+		if err := g.add("graylog_pipeline", pipeline.ID, pipeline.Title); err != nil {
+			return err
+		}
+	}
+	if !g.isTarget("graylog_pipeline_connection") {
+		return nil
+	}
+	conns, _, err := g.cl.GetPipelineConnections(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pipeline connections")
+	}
+	for _, conn := range conns {
+		if len(conn.PipelineIDs) == 0 {
+			continue
+		}
+		label, ok := streamNames[conn.StreamID]
+		if !ok {
+			label = "default"
+		}
+		if err := g.add("graylog_pipeline_connection", conn.StreamID, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) generateDashboards(ctx context.Context) error {
+	if !g.isTarget(
+		"graylog_dashboard", "graylog_dashboard_widget",
+		"graylog_dashboard_widget_positions", "graylog_role", "graylog_user") {
+		return nil
+	}
+	dashboards, _, _, err := g.cl.GetDashboards(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get dashboards")
+	}
+	for _, dashboard := range dashboards {
+		if err := g.add("graylog_dashboard", dashboard.ID, dashboard.Title); err != nil {
+			return err
+		}
+		for _, widget := range dashboard.Widgets {
+			if err := g.add(
+				"graylog_dashboard_widget", dashboard.ID+"/"+widget.ID,
+				dashboard.Title+"_"+widget.Description); err != nil {
+				return err
+			}
+		}
+		if len(dashboard.Positions) != 0 {
+			if err := g.add(
+				"graylog_dashboard_widget_positions", dashboard.ID, dashboard.Title); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// write writes HCL files per resource type and the import script to a given directory.
+func (g *generator) write(dir, script string) error {
+	types := make([]string, 0, len(g.files))
+	for typ := range g.files {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		p := filepath.Join(dir, typ+".tf")
+		if err := ioutil.WriteFile(p, g.files[typ].Bytes(), 0644); err != nil {
+			return errors.Wrapf(err, "failed to write %s", p)
+		}
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString("#!/bin/sh\n\nset -eu\n\n")
+	for _, cmd := range g.imports {
+		buf.WriteString(cmd + "\n")
+	}
+	p := filepath.Join(dir, script)
+	if err := ioutil.WriteFile(p, buf.Bytes(), 0755); err != nil {
+		return errors.Wrapf(err, "failed to write %s", p)
+	}
+	return nil
+}
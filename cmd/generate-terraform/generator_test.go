@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+	tfgraylog "github.com/suzuki-shunsuke/go-graylog/terraform/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGenerator(t *testing.T) {
+	ctx := context.Background()
+	server, cl, err := testutil.GetServerAndClient()
+	require.Nil(t, err)
+	if server == nil {
+		t.Skip("the mock server is required")
+	}
+	defer server.Close()
+	stream, f, err := testutil.GetStream(ctx, cl, server, 2)
+	require.Nil(t, err)
+	if f != nil {
+		defer f(stream.ID)
+	}
+
+	// the children of the default stream aren't generated because the default stream isn't generated
+	_, err = cl.CreateStreamRule(ctx, &graylog.StreamRule{
+		StreamID: mockserver.DefaultStreamID, Field: "tag", Value: "default", Type: 1,
+	})
+	require.Nil(t, err)
+	_, err = cl.CreateStreamAlertCondition(ctx, mockserver.DefaultStreamID, &graylog.AlertCondition{
+		Title: "default",
+		Parameters: &graylog.MessageCountAlertConditionParameters{
+			Time: 5, ThresholdType: "MORE",
+		},
+	})
+	require.Nil(t, err)
+
+	meta := &tfgraylog.Config{
+		Endpoint:     server.Endpoint(),
+		AuthName:     "admin",
+		AuthPassword: "admin",
+		XRequestedBy: "terraform-go-graylog",
+		APIVersion:   "v2",
+	}
+	_, err = newGenerator(cl, meta, []string{"graylog_foo"})
+	require.NotNil(t, err)
+
+	g, err := newGenerator(cl, meta, []string{
+		"graylog_index_set", "graylog_stream", "graylog_stream_rule", "graylog_alert_condition"})
+	require.Nil(t, err)
+	require.Nil(t, g.generate(ctx))
+	dir, err := ioutil.TempDir("", "")
+	require.Nil(t, err)
+	require.Nil(t, g.write(dir, "import.sh"))
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "graylog_stream.tf"))
+	require.Nil(t, err)
+	name := g.names["graylog_stream"][stream.ID]
+	require.NotEmpty(t, name)
+	require.Contains(t, string(b), `resource "graylog_stream" "`+name+`" {`)
+	require.Contains(t, string(b), "= graylog_index_set.")
+
+	b, err = ioutil.ReadFile(filepath.Join(dir, "import.sh"))
+	require.Nil(t, err)
+	require.Contains(t, string(b), "terraform import graylog_stream."+name+" '"+stream.ID+"'")
+	require.False(t, strings.Contains(string(b), "graylog_dashboard"))
+	require.False(t, strings.Contains(string(b), mockserver.DefaultStreamID))
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+type (
+	// resolver returns a reference expression (ex. graylog_stream.foo.id) of a given attribute's value.
+	// If the value isn't a reference, the second return value is false.
+	resolver func(key, val string) (string, bool)
+
+	hclWriter struct {
+		w       io.Writer
+		resolve resolver
+		err     error
+	}
+)
+
+func (hw *hclWriter) printf(depth int, format string, a ...interface{}) {
+	if hw.err != nil {
+		return
+	}
+	if _, err := io.WriteString(hw.w, strings.Repeat("  ", depth)); err != nil {
+		hw.err = err
+		return
+	}
+	if _, err := fmt.Fprintf(hw.w, format, a...); err != nil {
+		hw.err = err
+	}
+}
+
+// writeResource writes a resource block.
+func (hw *hclWriter) writeResource(
+	typ, name string, sch map[string]*schema.Schema, vals map[string]interface{},
+) error {
+	hw.printf(0, "resource %q %q {\n", typ, name)
+	hw.writeBody(1, sch, vals)
+	hw.printf(0, "}\n")
+	return hw.err
+}
+
+// writeBody writes the attributes and blocks in the schema order.
+// Computed attributes and attributes whose value is the default value are omitted.
+func (hw *hclWriter) writeBody(depth int, sch map[string]*schema.Schema, vals map[string]interface{}) {
+	keys := make([]string, 0, len(sch))
+	for k := range sch {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// attributes first, then blocks
+	attrs := []string{}
+	width := 0
+	for _, k := range keys {
+		s := sch[k]
+		if isBlock(s) || skipAttr(s, vals[k]) {
+			continue
+		}
+		attrs = append(attrs, k)
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+	// align "=" like "terraform fmt"
+	for _, k := range attrs {
+		hw.printf(depth, "%-*s = %s\n", width, k, hw.value(k, sch[k], vals[k]))
+	}
+	for _, k := range keys {
+		s := sch[k]
+		if !isBlock(s) || skipAttr(s, vals[k]) {
+			continue
+		}
+		elem := s.Elem.(*schema.Resource)
+		for _, v := range listValue(vals[k]) {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			hw.printf(depth, "%s {\n", k)
+			hw.writeBody(depth+1, elem.Schema, m)
+			hw.printf(depth, "}\n")
+		}
+	}
+}
+
+func isBlock(s *schema.Schema) bool {
+	if s.Type != schema.TypeList && s.Type != schema.TypeSet {
+		return false
+	}
+	_, ok := s.Elem.(*schema.Resource)
+	return ok
+}
+
+// skipAttr returns true if the attribute shouldn't be written.
+func skipAttr(s *schema.Schema, val interface{}) bool {
+	if s.Deprecated != "" || s.Removed != "" {
+		return true
+	}
+	if s.Computed {
+		// computed attributes such as created_at are set by Graylog
+		return true
+	}
+	if s.Required {
+		return false
+	}
+	if val == nil {
+		return true
+	}
+	if s.Default != nil {
+		return reflect.DeepEqual(s.Default, val)
+	}
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet, schema.TypeMap:
+		return reflect.ValueOf(listOrMap(val)).Len() == 0
+	}
+	return reflect.ValueOf(val).IsZero()
+}
+
+func listOrMap(val interface{}) interface{} {
+	if set, ok := val.(*schema.Set); ok {
+		return set.List()
+	}
+	return val
+}
+
+// listValue converts a list or set value to a slice.
+// Elements of a set are sorted to generate the stable output.
+func listValue(val interface{}) []interface{} {
+	switch v := val.(type) {
+	case *schema.Set:
+		list := v.List()
+		sort.SliceStable(list, func(i, j int) bool {
+			return fmt.Sprint(list[i]) < fmt.Sprint(list[j])
+		})
+		return list
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+// value returns HCL expression of an attribute's value.
+func (hw *hclWriter) value(key string, s *schema.Schema, val interface{}) string {
+	switch s.Type {
+	case schema.TypeString:
+		str, _ := val.(string)
+		return hw.str(key, str)
+	case schema.TypeList, schema.TypeSet:
+		list := listValue(val)
+		a := make([]string, len(list))
+		for i, v := range list {
+			a[i] = hw.primitive(key, v)
+		}
+		return "[" + strings.Join(a, ", ") + "]"
+	case schema.TypeMap:
+		m, _ := val.(map[string]interface{})
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		a := make([]string, len(keys))
+		for i, k := range keys {
+			a[i] = quote(k) + " = " + hw.primitive("", m[k])
+		}
+		return "{" + strings.Join(a, ", ") + "}"
+	}
+	return hw.primitive(key, val)
+}
+
+func (hw *hclWriter) primitive(key string, val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return hw.str(key, v)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return quote(fmt.Sprint(val))
+}
+
+func (hw *hclWriter) str(key, val string) string {
+	if hw.resolve != nil {
+		if ref, ok := hw.resolve(key, val); ok {
+			return ref
+		}
+	}
+	if strings.Contains(val, "\n") && !strings.Contains("\n"+val+"\n", "\nEOF\n") {
+		return "<<EOF\n" + escapeTemplate(strings.TrimSuffix(val, "\n")) + "\nEOF"
+	}
+	return quote(val)
+}
+
+// escapeTemplate escapes the template sequences "${" and "%{".
+func escapeTemplate(s string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+}
+
+// quote returns a quoted HCL string.
+func quote(s string) string {
+	s = strings.NewReplacer(
+		`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+	).Replace(s)
+	return `"` + escapeTemplate(s) + `"`
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote(t *testing.T) {
+	require.Equal(t, `"foo \"bar\" $${baz} %%{if}\n"`, quote("foo \"bar\" ${baz} %{if}\n"))
+}
+
+func TestHCLWriter(t *testing.T) {
+	sch := map[string]*schema.Schema{
+		"title":      {Type: schema.TypeString, Required: true},
+		"stream_id":  {Type: schema.TypeString, Required: true},
+		"disabled":   {Type: schema.TypeBool, Optional: true},
+		"created_at": {Type: schema.TypeString, Optional: true, Computed: true},
+		"source":     {Type: schema.TypeString, Optional: true},
+		"config": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"field": {Type: schema.TypeString, Optional: true},
+				},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+	hw := &hclWriter{w: buf, resolve: func(key, val string) (string, bool) {
+		if key == "stream_id" && val == "xxx" {
+			return "graylog_stream.test.id", true
+		}
+		return "", false
+	}}
+	require.Nil(t, hw.writeResource("graylog_foo", "test", sch, map[string]interface{}{
+		"title":      "test",
+		"stream_id":  "xxx",
+		"disabled":   false,
+		"created_at": "2019-09-01",
+		"source":     "rule \"foo\"\nwhen\n  true\nend\n",
+		"config": []interface{}{
+			map[string]interface{}{"field": "message"},
+		},
+	}))
+	require.Equal(t, `resource "graylog_foo" "test" {
+  source    = <<EOF
+rule "foo"
+when
+  true
+end
+EOF
+  stream_id = graylog_stream.test.id
+  title     = "test"
+  config {
+    field = "message"
+  }
+}
+`, buf.String())
+}
@@ -0,0 +1,74 @@
+package main
+
+// Generate Terraform configuration and import commands from an existing Graylog cluster.
+//
+// The connection is configured with the same environment variables as the terraform provider.
+//
+//   $ export GRAYLOG_WEB_ENDPOINT_URI=http://localhost:9000/api
+//   $ export GRAYLOG_AUTH_NAME=admin
+//   $ export GRAYLOG_AUTH_PASSWORD=admin
+//   $ generate-terraform -out tf [-resources graylog_stream,graylog_stream_rule]
+//   $ cd tf && sh import.sh
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/cmd/internal/env"
+	tfgraylog "github.com/suzuki-shunsuke/go-graylog/terraform/graylog"
+)
+
+func main() {
+	if err := Main(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func Main() error {
+	out := flag.String("out", ".", "the directory where files are generated")
+	resources := flag.String(
+		"resources", "", "comma separated resource types to generate. By default all resource types are generated")
+	script := flag.String("script", "import.sh", "the file name of the import script")
+	flag.Parse()
+
+	cfg, err := env.Read()
+	if err != nil {
+		return err
+	}
+	if cfg.XRequestedBy == "" {
+		cfg.XRequestedBy = "terraform-go-graylog"
+	}
+	if cfg.APIVersion == "" {
+		cfg.APIVersion = "v2"
+	}
+	cl, err := cfg.NewClient()
+	if err != nil {
+		return err
+	}
+	meta := &tfgraylog.Config{
+		Endpoint:     cfg.Endpoint,
+		AuthName:     cfg.AuthName,
+		AuthPassword: cfg.AuthPassword,
+		XRequestedBy: cfg.XRequestedBy,
+		APIVersion:   cfg.APIVersion,
+	}
+
+	var targets []string
+	if *resources != "" {
+		targets = strings.Split(*resources, ",")
+	}
+	g, err := newGenerator(cl, meta, targets)
+	if err != nil {
+		return err
+	}
+	if err := g.generate(context.Background()); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		return err
+	}
+	return g.write(*out, *script)
+}
@@ -90,6 +90,13 @@ var (
 		"http_stream_alarm_callback":  StreamAlarmCallback{},
 		"email_stream_alarm_callback": StreamAlarmCallback{},
 		"stream_alert_conditions":     StreamAlertConditions{},
+
+		"pagerduty_stream_alarm_callback": StreamAlarmCallback{},
+		"script_stream_alarm_callback":    StreamAlarmCallback{},
+		"teams_stream_alarm_callback":     StreamAlarmCallback{},
+		"world_map_dashboard_widget":      DashboardWidget{},
+		"stacked_chart_dashboard_widget":  DashboardWidget{},
+		"message_count_dashboard_widget":  DashboardWidget{},
 	}
 )
 
@@ -150,6 +157,10 @@ type (
 		data graylog.Dashboard
 	}
 
+	DashboardWidget struct {
+		data graylog.Widget
+	}
+
 	StreamAlarmCallbacks struct {
 		data graylog.AlarmCallbacksBody
 	}
@@ -219,6 +230,10 @@ func (db Dashboards) dump(input string) error {
 	return dump(input, &db.data)
 }
 
+func (widget DashboardWidget) dump(input string) error {
+	return dump(input, &widget.data)
+}
+
 func (ac StreamAlarmCallbacks) dump(input string) error {
 	return dump(input, &ac.data)
 }
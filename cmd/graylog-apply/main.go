@@ -0,0 +1,105 @@
+package main
+
+// Converge Graylog's configuration to YAML documents.
+//
+// The connection is configured with the same environment variables as the terraform provider.
+//
+//   $ graylog-apply diff [-prune] [-o json] [-detailed-exitcode] -f graylog.yaml [-f dir]
+//   $ graylog-apply apply [-prune] [-o json] -f graylog.yaml [-f dir]
+//
+// With -detailed-exitcode, diff exits with 2 if there are changes.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/apply"
+	"github.com/suzuki-shunsuke/go-graylog/cmd/internal/env"
+)
+
+type files []string
+
+func (f *files) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *files) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+func main() {
+	code, err := Main(os.Args[1:], os.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(code)
+}
+
+// Main runs the command and returns the exit code.
+func Main(args []string, out io.Writer) (int, error) {
+	if len(args) == 0 || (args[0] != "diff" && args[0] != "apply") {
+		return 0, errors.New("usage: graylog-apply diff|apply [options] -f FILE")
+	}
+	cmd := args[0]
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	var paths files
+	fs.Var(&paths, "f", "YAML file or directory. If it is '-', documents are read from the standard input")
+	prune := fs.Bool("prune", false, "delete resources which aren't declared")
+	format := fs.String("o", "text", "output format. text or json")
+	detailedExitCode := fs.Bool("detailed-exitcode", false, "exit with 2 if there are changes")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 0, err
+	}
+	if len(paths) == 0 {
+		return 0, errors.New("-f is required")
+	}
+	if *format != "text" && *format != "json" {
+		return 0, fmt.Errorf("unsupported output format: %s", *format)
+	}
+	docs, err := apply.ReadFiles(paths...)
+	if err != nil {
+		return 0, err
+	}
+	cl, err := env.NewClient()
+	if err != nil {
+		return 0, err
+	}
+	applier := apply.NewApplier(cl, &apply.Config{Prune: *prune})
+	ctx := context.Background()
+	var plan *apply.Plan
+	if cmd == "diff" {
+		plan, err = applier.Plan(ctx, docs)
+	} else {
+		plan, err = applier.Apply(ctx, docs)
+	}
+	if plan != nil {
+		if e := output(out, plan, *format); e != nil && err == nil {
+			err = e
+		}
+	}
+	if err != nil {
+		return 1, err
+	}
+	if *detailedExitCode && plan.HasChanges() {
+		return 2, nil
+	}
+	return 0, nil
+}
+
+func output(out io.Writer, plan *apply.Plan, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	}
+	_, err := io.WriteString(out, plan.String())
+	return err
+}
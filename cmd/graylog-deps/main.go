@@ -0,0 +1,102 @@
+package main
+
+// Show the dependencies of Graylog's resources and delete resources with their dependents.
+//
+// The connection is configured with the same environment variables as the terraform provider.
+//
+//   $ graylog-deps dependents <kind> <id>
+//   $ graylog-deps delete [-y] <kind> <id> [<kind> <id> ...]
+//
+// delete prints the plan and asks the confirmation unless -y is set.
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/cmd/internal/env"
+	"github.com/suzuki-shunsuke/go-graylog/depgraph"
+	"github.com/suzuki-shunsuke/go-graylog/lint"
+)
+
+func main() {
+	if err := Main(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseRefs(args []string) ([]depgraph.Ref, error) {
+	if len(args) == 0 || len(args)%2 != 0 {
+		return nil, errors.New("pairs of the kind and the id are required")
+	}
+	refs := make([]depgraph.Ref, 0, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		refs = append(refs, depgraph.Ref{Kind: args[i], ID: args[i+1]})
+	}
+	return refs, nil
+}
+
+// Main runs the command.
+func Main(args []string, in io.Reader, out io.Writer) error {
+	if len(args) == 0 || (args[0] != "dependents" && args[0] != "delete") {
+		return errors.New("usage: graylog-deps dependents|delete [options] KIND ID")
+	}
+	cmd := args[0]
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	yes := fs.Bool("y", false, "delete resources without the confirmation")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	refs, err := parseRefs(fs.Args())
+	if err != nil {
+		return err
+	}
+	cl, err := env.NewClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	s, err := lint.Fetch(ctx, cl)
+	if err != nil {
+		return err
+	}
+	g := depgraph.Build(s)
+
+	if cmd == "dependents" {
+		for _, ref := range refs {
+			if _, ok := g.Node(ref); !ok {
+				return fmt.Errorf("%s isn't found", ref)
+			}
+			for _, d := range g.AllDependents(ref) {
+				node, _ := g.Node(d)
+				fmt.Fprintf(out, "%s %q\n", d, node.Name)
+			}
+		}
+		return nil
+	}
+
+	plan, err := g.PlanDelete(refs...)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(out, plan.String()); err != nil {
+		return err
+	}
+	if !*yes {
+		fmt.Fprint(out, "Do you want to continue? [y/N] ")
+		answer, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			return errors.New("canceled")
+		}
+	}
+	return plan.Execute(ctx, cl)
+}
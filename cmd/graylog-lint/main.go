@@ -0,0 +1,115 @@
+package main
+
+// Lint Graylog's configuration.
+//
+// Lint a live cluster. The connection is configured with the same environment variables as the terraform provider.
+//
+//   $ graylog-lint [-save snapshot.json] [-o json]
+//
+// Lint a saved snapshot offline.
+//
+//   $ graylog-lint -snapshot snapshot.json
+//
+// Unused grok patterns are checked only if -grok-pattern-prefix is set,
+// because Graylog has many built-in grok patterns which are usually unused.
+//
+//   $ graylog-lint -grok-pattern-prefix APP_
+//
+// The command exits with 1 if any issue is found.
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/suzuki-shunsuke/go-graylog/cmd/internal/env"
+	"github.com/suzuki-shunsuke/go-graylog/lint"
+)
+
+func main() {
+	code, err := Main()
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(code)
+}
+
+func readSnapshot(path string) (*lint.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return lint.LoadSnapshot(f)
+}
+
+func saveSnapshot(path string, s *lint.Snapshot) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := s.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Main runs the command and returns the exit code.
+func Main() (int, error) {
+	snapshot := flag.String("snapshot", "", "lint a saved snapshot instead of the live cluster")
+	save := flag.String("save", "", "save the snapshot of the live cluster to a given file")
+	format := flag.String("o", "text", "output format. text or json")
+	grokPrefix := flag.String(
+		"grok-pattern-prefix", "",
+		"check only the grok patterns whose names start with the prefix for unused grok patterns. If empty, unused grok patterns aren't checked")
+	flag.Parse()
+	if *format != "text" && *format != "json" {
+		return 0, fmt.Errorf("unsupported output format: %s", *format)
+	}
+
+	var (
+		s   *lint.Snapshot
+		err error
+	)
+	if *snapshot != "" {
+		s, err = readSnapshot(*snapshot)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		cl, err := env.NewClient()
+		if err != nil {
+			return 0, err
+		}
+		s, err = lint.Fetch(context.Background(), cl)
+		if err != nil {
+			return 0, err
+		}
+		if *save != "" {
+			if err := saveSnapshot(*save, s); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	issues := lint.Lint(s, &lint.Options{GrokPatternPrefix: *grokPrefix})
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(issues); err != nil {
+			return 0, err
+		}
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	}
+	if len(issues) != 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,65 @@
+// Package env creates the Graylog API client of the commands from the environment variables.
+// The environment variables are same as the terraform provider's.
+package env
+
+import (
+	"errors"
+	"os"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+// Config is the connection setting which is read from the environment variables.
+type Config struct {
+	Endpoint     string
+	AuthName     string
+	AuthPassword string
+	XRequestedBy string
+	APIVersion   string
+}
+
+// Read reads the connection setting from the environment variables.
+// GRAYLOG_WEB_ENDPOINT_URI is required.
+func Read() (*Config, error) {
+	cfg := &Config{
+		Endpoint:     os.Getenv("GRAYLOG_WEB_ENDPOINT_URI"),
+		AuthName:     os.Getenv("GRAYLOG_AUTH_NAME"),
+		AuthPassword: os.Getenv("GRAYLOG_AUTH_PASSWORD"),
+		XRequestedBy: os.Getenv("GRAYLOG_X_REQUESTED_BY"),
+		APIVersion:   os.Getenv("GRAYLOG_API_VERSION"),
+	}
+	if cfg.Endpoint == "" {
+		return nil, errors.New("GRAYLOG_WEB_ENDPOINT_URI is required")
+	}
+	return cfg, nil
+}
+
+// NewClient returns a new client.
+// If APIVersion is "v3", the client for Graylog v3 is returned.
+func (cfg *Config) NewClient() (*client.Client, error) {
+	var (
+		cl  *client.Client
+		err error
+	)
+	if cfg.APIVersion == "v3" {
+		cl, err = client.NewClientV3(cfg.Endpoint, cfg.AuthName, cfg.AuthPassword)
+	} else {
+		cl, err = client.NewClient(cfg.Endpoint, cfg.AuthName, cfg.AuthPassword)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.XRequestedBy != "" {
+		cl.SetXRequestedBy(cfg.XRequestedBy)
+	}
+	return cl, nil
+}
+
+// NewClient reads the environment variables and returns a new client.
+func NewClient() (*client.Client, error) {
+	cfg, err := Read()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient()
+}
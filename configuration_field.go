@@ -0,0 +1,18 @@
+package graylog
+
+type (
+	// ConfigurationField represents a field of the requested configuration of a plugin type
+	// such as alert condition types and alarm callback types.
+	ConfigurationField struct {
+		// ex. "text", "number", "boolean", "dropdown", "list"
+		Type         string      `json:"type"`
+		HumanName    string      `json:"human_name"`
+		Description  string      `json:"description,omitempty"`
+		DefaultValue interface{} `json:"default_value,omitempty"`
+		IsOptional   bool        `json:"is_optional"`
+		// ex. ["is_password"]
+		Attributes     []string               `json:"attributes,omitempty"`
+		AdditionalInfo map[string]interface{} `json:"additional_info,omitempty"`
+		Position       int                    `json:"position,omitempty"`
+	}
+)
@@ -82,6 +82,40 @@ type (
 		Trend         bool       `json:"trend"`
 	}
 
+	// WidgetConfigWorldMap is the config of the world map widget.
+	WidgetConfigWorldMap struct {
+		Timerange *Timerange `json:"timerange" v-create:"required"`
+		StreamID  string     `json:"stream_id,omitempty"`
+		Query     string     `json:"query"`
+		Field     string     `json:"field" v-create:"required"`
+	}
+
+	// WidgetConfigStackedChart is the config of the stacked chart widget.
+	WidgetConfigStackedChart struct {
+		Timerange     *Timerange                       `json:"timerange" v-create:"required"`
+		StreamID      string                           `json:"stream_id,omitempty"`
+		Interval      string                           `json:"interval,omitempty"`
+		Renderer      string                           `json:"renderer,omitempty"`
+		Interpolation string                           `json:"interpolation,omitempty"`
+		Series        []WidgetConfigStackedChartSeries `json:"series" v-create:"required"`
+	}
+
+	// WidgetConfigStackedChartSeries represents a series of a stacked chart.
+	WidgetConfigStackedChartSeries struct {
+		Query               string `json:"query"`
+		Field               string `json:"field,omitempty"`
+		StatisticalFunction string `json:"statistical_function,omitempty"`
+	}
+
+	// WidgetConfigSearchResultCount is the config of the message count widget.
+	WidgetConfigSearchResultCount struct {
+		Timerange     *Timerange `json:"timerange" v-create:"required"`
+		StreamID      string     `json:"stream_id,omitempty"`
+		Query         string     `json:"query"`
+		LowerIsBetter bool       `json:"lower_is_better"`
+		Trend         bool       `json:"trend"`
+	}
+
 	WidgetConfigUnknownType struct {
 		T      string
 		Fields map[string]interface{}
@@ -118,27 +152,8 @@ func (widget *Widget) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &a); err != nil {
 		return err
 	}
-	switch a.Type {
-	case "STATS_COUNT":
-		cfg := &WidgetConfigStatsCount{}
+	if cfg, ok := NewWidgetConfigByType(a.Type); ok {
 		return setConfig(widget, a.Config, cfg)
-	case "QUICKVALUES":
-		cfg := &WidgetConfigQuickValues{}
-		return setConfig(widget, a.Config, cfg)
-	case "QUICKVALUES_HISTOGRAM":
-		cfg := &WidgetConfigQuickValuesHistogram{}
-		return setConfig(widget, a.Config, cfg)
-	case "FIELD_CHART":
-		cfg := &WidgetConfigFieldChart{}
-		return setConfig(widget, a.Config, cfg)
-	case "STREAM_SEARCH_RESULT_COUNT":
-		cfg := &WidgetConfigStreamSearchResultCount{}
-		return setConfig(widget, a.Config, cfg)
-	case "SEARCH_RESULT_CHART":
-		cfg := &WidgetConfigSearchResultChart{}
-		return setConfig(widget, a.Config, cfg)
-		// case "STACKED_CHART":
-		// case "SEARCH_RESULT_COUNT":
 	}
 	cfg := &WidgetConfigUnknownType{
 		T: a.Type,
@@ -181,6 +196,18 @@ func (cfg *WidgetConfigSearchResultChart) Type() string {
 	return "SEARCH_RESULT_CHART"
 }
 
+func (cfg *WidgetConfigWorldMap) Type() string {
+	return "org.graylog.plugins.map.widget.strategy.MapWidgetStrategy"
+}
+
+func (cfg *WidgetConfigStackedChart) Type() string {
+	return "STACKED_CHART"
+}
+
+func (cfg *WidgetConfigSearchResultCount) Type() string {
+	return "SEARCH_RESULT_COUNT"
+}
+
 func (cfg *WidgetConfigUnknownType) Type() string {
 	return cfg.T
 }
@@ -188,3 +215,7 @@ func (cfg *WidgetConfigUnknownType) Type() string {
 func (cfg *WidgetConfigUnknownType) UnmarshalJSON(b []byte) error {
 	return json.Unmarshal(b, &cfg.Fields)
 }
+
+func (cfg *WidgetConfigUnknownType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cfg.Fields)
+}
@@ -0,0 +1,33 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testdata"
+)
+
+func TestWidget_JSON(t *testing.T) {
+	data := map[string]*graylog.Widget{
+		"world_map_dashboard_widget.json":     testdata.WorldMapDashboardWidget,
+		"stacked_chart_dashboard_widget.json": testdata.StackedChartDashboardWidget,
+		"message_count_dashboard_widget.json": testdata.MessageCountDashboardWidget,
+	}
+	for file, exp := range data {
+		t.Run(file, func(t *testing.T) {
+			buf, err := ioutil.ReadFile("testdata/" + file)
+			require.Nil(t, err)
+			widget := &graylog.Widget{}
+			require.Nil(t, json.Unmarshal(buf, widget))
+			require.Equal(t, exp, widget)
+
+			b, err := json.Marshal(widget)
+			require.Nil(t, err)
+			require.JSONEq(t, string(buf), string(b))
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package depgraph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-ptr"
+	"github.com/suzuki-shunsuke/go-set"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+const (
+	// ActionDelete means the resource is deleted.
+	ActionDelete = "delete"
+	// ActionDetach means the references to deleted resources are removed from the resource.
+	ActionDetach = "detach"
+)
+
+type (
+	// Step is a step of a delete plan.
+	Step struct {
+		Action string `json:"action"`
+		Node   Node   `json:"node"`
+		// Detached is the list of references removed from the resource.
+		// It is set only if Action is ActionDetach.
+		Detached []Ref `json:"detached,omitempty"`
+	}
+
+	// DeletePlan is the ordered steps to delete resources and the resources which depend on them.
+	// Resources are deleted after resources which depend on them.
+	DeletePlan struct {
+		Targets []Ref  `json:"targets"`
+		Steps   []Step `json:"steps"`
+		graph   *Graph
+	}
+)
+
+// PlanDelete returns the plan to delete given resources with their dependents.
+// Roles whose permissions refer deleted resources, pipeline connections which refer
+// deleted pipelines and pipelines which refer deleted pipeline rules aren't deleted
+// but the references are removed.
+// An error is returned if a protected resource such as the default stream would be deleted.
+func (g *Graph) PlanDelete(targets ...Ref) (*DeletePlan, error) {
+	deleted := map[Ref]bool{}
+	queue := []Ref{}
+	for _, ref := range targets {
+		if _, ok := g.nodes[ref]; !ok {
+			return nil, fmt.Errorf("%s isn't found", ref)
+		}
+		if !deleted[ref] {
+			deleted[ref] = true
+			queue = append(queue, ref)
+		}
+	}
+	for len(queue) != 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		if node := g.nodes[ref]; node.Protected {
+			return nil, fmt.Errorf("%s (%s) is protected and can't be deleted", ref, node.Name)
+		}
+		for _, e := range g.dependents[ref] {
+			if e.Detach || deleted[e.From] {
+				continue
+			}
+			deleted[e.From] = true
+			queue = append(queue, e.From)
+		}
+	}
+
+	refs := make([]Ref, 0, len(deleted))
+	for ref := range deleted {
+		refs = append(refs, ref)
+	}
+	sortRefs(refs)
+
+	plan := &DeletePlan{Targets: targets, Steps: []Step{}, graph: g}
+	// detach references first
+	detached := map[Ref][]Ref{}
+	for _, ref := range refs {
+		for _, e := range g.dependents[ref] {
+			if e.Detach && !deleted[e.From] {
+				detached[e.From] = append(detached[e.From], ref)
+			}
+		}
+	}
+	detachedRefs := make([]Ref, 0, len(detached))
+	for ref := range detached {
+		detachedRefs = append(detachedRefs, ref)
+	}
+	sortRefs(detachedRefs)
+	for _, ref := range detachedRefs {
+		d := detached[ref]
+		sortRefs(d)
+		plan.Steps = append(plan.Steps, Step{Action: ActionDetach, Node: *g.nodes[ref], Detached: d})
+	}
+
+	// delete dependents before their dependencies
+	visited := map[Ref]bool{}
+	var visit func(ref Ref)
+	visit = func(ref Ref) {
+		if visited[ref] {
+			return
+		}
+		visited[ref] = true
+		dependents := g.Dependents(ref)
+		for _, d := range dependents {
+			if deleted[d] {
+				visit(d)
+			}
+		}
+		plan.Steps = append(plan.Steps, Step{Action: ActionDelete, Node: *g.nodes[ref]})
+	}
+	for _, ref := range refs {
+		visit(ref)
+	}
+	return plan, nil
+}
+
+// String returns a human readable plan to be confirmed.
+func (plan *DeletePlan) String() string {
+	buf := &strings.Builder{}
+	buf.WriteString("The following resources will be changed:\n")
+	for _, step := range plan.Steps {
+		fmt.Fprintf(buf, "  %s %s %q (%s)", step.Action, step.Node.Kind, step.Node.Name, step.Node.ID)
+		if len(step.Detached) != 0 {
+			a := make([]string, len(step.Detached))
+			for i, ref := range step.Detached {
+				a[i] = ref.String()
+			}
+			fmt.Fprintf(buf, ": remove %s", strings.Join(a, ", "))
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// detachPermissions removes the IDs of given references from permissions.
+// Permissions which refer no resource are removed.
+func detachPermissions(perms []string, refs []Ref) []string {
+	removed := map[Ref]bool{}
+	for _, ref := range refs {
+		removed[ref] = true
+	}
+	ret := []string{}
+	for _, perm := range perms {
+		permRefs := permissionRefs(perm)
+		if permRefs == nil {
+			ret = append(ret, perm)
+			continue
+		}
+		ids := []string{}
+		for _, ref := range permRefs {
+			if !removed[ref] {
+				ids = append(ids, ref.ID)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		a := strings.Split(perm, ":")
+		a[2] = strings.Join(ids, ",")
+		ret = append(ret, strings.Join(a, ":"))
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func (plan *DeletePlan) detach(ctx context.Context, cl *client.Client, step Step) error {
+	switch step.Node.Kind {
+	case KindRole:
+		role := plan.graph.roles[step.Node.ID]
+		perms := detachPermissions(role.Permissions.ToList(), step.Detached)
+		_, _, err := cl.UpdateRole(ctx, role.Name, &graylog.RoleUpdateParams{
+			Name:        role.Name,
+			Description: ptr.PStr(role.Description),
+			Permissions: set.NewStrSet(perms...),
+		})
+		return err
+	case KindPipelineConnection:
+		conn := plan.graph.connections[step.Node.ID]
+		removed := map[string]bool{}
+		for _, ref := range step.Detached {
+			removed[ref.ID] = true
+		}
+		ids := []string{}
+		for _, id := range conn.PipelineIDs {
+			if !removed[id] {
+				ids = append(ids, id)
+			}
+		}
+		_, err := cl.ConnectPipelinesToStream(ctx, &graylog.PipelineConnection{
+			StreamID: conn.StreamID, PipelineIDs: ids,
+		})
+		return err
+	case KindPipeline:
+		// a pipeline refers rules by the title, so the source is regenerated without them
+		pipeline := plan.graph.pipelines[step.Node.ID]
+		removed := map[string]bool{}
+		for _, ref := range step.Detached {
+			removed[plan.graph.nodes[ref].Name] = true
+		}
+		stages := make([]graylog.PipelineStage, len(pipeline.Stages))
+		for i, stage := range pipeline.Stages {
+			rules := []string{}
+			for _, rule := range stage.Rules {
+				if !removed[rule] {
+					rules = append(rules, rule)
+				}
+			}
+			stages[i] = graylog.PipelineStage{Stage: stage.Stage, MatchAll: stage.MatchAll, Rules: rules}
+		}
+		_, err := cl.UpdatePipeline(ctx, &graylog.Pipeline{
+			ID:          pipeline.ID,
+			Source:      graylog.NewPipelineSource(pipeline.Title, stages),
+			Description: pipeline.Description,
+		})
+		return err
+	}
+	return fmt.Errorf("%s can't be detached", step.Node.Kind)
+}
+
+func deleteNode(ctx context.Context, cl *client.Client, node Node) error {
+	var err error
+	switch node.Kind {
+	case KindIndexSet:
+		_, err = cl.DeleteIndexSet(ctx, node.ID)
+	case KindStream:
+		_, err = cl.DeleteStream(ctx, node.ID)
+	case KindStreamRule:
+		_, err = cl.DeleteStreamRule(ctx, node.Parent, node.ID)
+	case KindAlertCondition:
+		_, err = cl.DeleteStreamAlertCondition(ctx, node.Parent, node.ID)
+	case KindAlarmCallback:
+		_, err = cl.DeleteStreamAlarmCallback(ctx, node.Parent, node.ID)
+	case KindPipelineConnection:
+		// a pipeline connection is deleted by connecting no pipeline
+		_, err = cl.ConnectPipelinesToStream(ctx, &graylog.PipelineConnection{
+			StreamID: node.ID, PipelineIDs: []string{},
+		})
+	case KindPipeline:
+		_, err = cl.DeletePipeline(ctx, node.ID)
+	case KindPipelineRule:
+		_, err = cl.DeletePipelineRule(ctx, node.ID)
+	case KindDashboard:
+		_, err = cl.DeleteDashboard(ctx, node.ID)
+	case KindWidget:
+		_, err = cl.DeleteDashboardWidget(ctx, node.Parent, node.ID)
+	case KindRole:
+		_, err = cl.DeleteRole(ctx, node.ID)
+	default:
+		err = fmt.Errorf("unsupported kind: %s", node.Kind)
+	}
+	return err
+}
+
+// Execute runs the plan's steps in order.
+// If a step fails, the rest steps aren't run.
+func (plan *DeletePlan) Execute(ctx context.Context, cl *client.Client) error {
+	for _, step := range plan.Steps {
+		var err error
+		if step.Action == ActionDetach {
+			err = plan.detach(ctx, cl, step)
+		} else {
+			err = deleteNode(ctx, cl, step.Node)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to %s %s (%s)", step.Action, step.Node.Ref, step.Node.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+/*
+Package depgraph builds a dependency graph of Graylog's resources and deletes resources with their dependents safely.
+
+The graph is built from a snapshot of the lint package.
+
+	s, err := lint.Fetch(ctx, cl)
+	g := depgraph.Build(s)
+	// what depends on the index set
+	refs := g.AllDependents(depgraph.Ref{Kind: depgraph.KindIndexSet, ID: id})
+
+A delete plan deletes resources after the resources which depend on them.
+Roles, pipeline connections and pipelines aren't deleted when the resources which they refer are deleted,
+but the references are removed from them.
+
+	plan, err := g.PlanDelete(depgraph.Ref{Kind: depgraph.KindIndexSet, ID: id})
+	fmt.Println(plan)
+	// after the confirmation
+	err = plan.Execute(ctx, cl)
+*/
+package depgraph
@@ -0,0 +1,310 @@
+package depgraph
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/lint"
+)
+
+const (
+	// KindIndexSet is the kind of index sets.
+	KindIndexSet = "index_set"
+	// KindStream is the kind of streams.
+	KindStream = "stream"
+	// KindStreamRule is the kind of stream rules.
+	KindStreamRule = "stream_rule"
+	// KindAlertCondition is the kind of alert conditions.
+	KindAlertCondition = "alert_condition"
+	// KindAlarmCallback is the kind of alarm callbacks.
+	KindAlarmCallback = "alarm_callback"
+	// KindPipelineConnection is the kind of pipeline connections.
+	// The ID of a pipeline connection is the stream ID.
+	KindPipelineConnection = "pipeline_connection"
+	// KindPipeline is the kind of pipelines.
+	KindPipeline = "pipeline"
+	// KindPipelineRule is the kind of pipeline rules.
+	KindPipelineRule = "pipeline_rule"
+	// KindDashboard is the kind of dashboards.
+	KindDashboard = "dashboard"
+	// KindWidget is the kind of dashboard widgets.
+	KindWidget = "widget"
+	// KindRole is the kind of roles. The ID of a role is the name.
+	KindRole = "role"
+)
+
+type (
+	// Ref is a reference to a resource.
+	Ref struct {
+		Kind string `json:"kind"`
+		ID   string `json:"id"`
+	}
+
+	// Node is a resource in the graph.
+	Node struct {
+		Ref
+		Name string `json:"name"`
+		// Parent is the ID of the stream or dashboard which the resource belongs to.
+		// It is set to stream rules, alert conditions, alarm callbacks and widgets.
+		Parent string `json:"parent,omitempty"`
+		// Protected resources such as the default stream can't be deleted.
+		Protected bool `json:"protected,omitempty"`
+	}
+
+	// Edge means that From depends on To.
+	Edge struct {
+		From Ref
+		To   Ref
+		// Detach means that the reference can be removed without deleting From.
+		// Roles' permissions, pipeline connections' pipelines and pipelines' rules are detached.
+		Detach bool
+	}
+
+	// Graph is a dependency graph of resources.
+	Graph struct {
+		nodes map[Ref]*Node
+		// dependents maps a resource to the edges from resources which depend on it.
+		dependents map[Ref][]Edge
+		// dependencies maps a resource to the edges to resources which it depends on.
+		dependencies map[Ref][]Edge
+
+		roles       map[string]graylog.Role
+		connections map[string]graylog.PipelineConnection
+		pipelines   map[string]graylog.Pipeline
+	}
+)
+
+// String returns a reference's string expression "<kind>:<id>".
+func (ref Ref) String() string {
+	return ref.Kind + ":" + ref.ID
+}
+
+func (g *Graph) addNode(node *Node) {
+	g.nodes[node.Ref] = node
+}
+
+func (g *Graph) addEdge(from, to Ref, detach bool) {
+	for _, e := range g.dependencies[from] {
+		if e.To == to {
+			return
+		}
+	}
+	e := Edge{From: from, To: to, Detach: detach}
+	g.dependents[to] = append(g.dependents[to], e)
+	g.dependencies[from] = append(g.dependencies[from], e)
+}
+
+// widgetStreamID returns the stream id of a widget's config.
+func widgetStreamID(widget graylog.Widget) string {
+	if widget.Config == nil {
+		return ""
+	}
+	if cfg, ok := widget.Config.(*graylog.WidgetConfigUnknownType); ok {
+		id, _ := cfg.Fields["stream_id"].(string)
+		return id
+	}
+	b, err := json.Marshal(widget.Config)
+	if err != nil {
+		return ""
+	}
+	cfg := struct {
+		StreamID string `json:"stream_id"`
+	}{}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return ""
+	}
+	return cfg.StreamID
+}
+
+// permissionRefs returns resources which a permission such as "streams:read:<id>,<id>" refers.
+func permissionRefs(perm string) []Ref {
+	kinds := map[string]string{
+		"streams":    KindStream,
+		"dashboards": KindDashboard,
+	}
+	a := strings.Split(perm, ":")
+	if len(a) != 3 || a[2] == "*" {
+		return nil
+	}
+	kind, ok := kinds[a[0]]
+	if !ok {
+		return nil
+	}
+	ids := strings.Split(a[2], ",")
+	refs := make([]Ref, len(ids))
+	for i, id := range ids {
+		refs[i] = Ref{Kind: kind, ID: id}
+	}
+	return refs
+}
+
+// Build builds a dependency graph from a snapshot.
+// Dependencies on resources which don't exist in the snapshot are ignored.
+func Build(s *lint.Snapshot) *Graph {
+	g := &Graph{
+		nodes:        map[Ref]*Node{},
+		dependents:   map[Ref][]Edge{},
+		dependencies: map[Ref][]Edge{},
+		roles:        map[string]graylog.Role{},
+		connections:  map[string]graylog.PipelineConnection{},
+		pipelines:    map[string]graylog.Pipeline{},
+	}
+	for _, is := range s.IndexSets {
+		g.addNode(&Node{Ref: Ref{KindIndexSet, is.ID}, Name: is.Title, Protected: is.Default})
+	}
+	for _, stream := range s.Streams {
+		ref := Ref{KindStream, stream.ID}
+		g.addNode(&Node{Ref: ref, Name: stream.Title, Protected: stream.IsDefault})
+		for _, rule := range stream.Rules {
+			g.addNode(&Node{
+				Ref: Ref{KindStreamRule, rule.ID}, Name: rule.Field + " " + rule.Value, Parent: stream.ID})
+		}
+		for _, cond := range stream.AlertConditions {
+			g.addNode(&Node{Ref: Ref{KindAlertCondition, cond.ID}, Name: cond.Title, Parent: stream.ID})
+		}
+	}
+	for _, ac := range s.AlarmCallbacks {
+		g.addNode(&Node{Ref: Ref{KindAlarmCallback, ac.ID}, Name: ac.Title, Parent: ac.StreamID})
+	}
+	for _, rule := range s.PipelineRules {
+		g.addNode(&Node{Ref: Ref{KindPipelineRule, rule.ID}, Name: rule.Title})
+	}
+	for _, pipeline := range s.Pipelines {
+		g.addNode(&Node{Ref: Ref{KindPipeline, pipeline.ID}, Name: pipeline.Title})
+		g.pipelines[pipeline.ID] = pipeline
+	}
+	for _, conn := range s.PipelineConnections {
+		g.addNode(&Node{Ref: Ref{KindPipelineConnection, conn.StreamID}, Name: conn.StreamID})
+		g.connections[conn.StreamID] = conn
+	}
+	for _, dashboard := range s.Dashboards {
+		g.addNode(&Node{Ref: Ref{KindDashboard, dashboard.ID}, Name: dashboard.Title})
+		for _, widget := range dashboard.Widgets {
+			g.addNode(&Node{
+				Ref: Ref{KindWidget, widget.ID}, Name: widget.Description, Parent: dashboard.ID})
+		}
+	}
+	for _, role := range s.Roles {
+		g.addNode(&Node{Ref: Ref{KindRole, role.Name}, Name: role.Name, Protected: role.ReadOnly})
+		g.roles[role.Name] = role
+	}
+
+	link := func(from, to Ref, detach bool) {
+		if _, ok := g.nodes[to]; ok {
+			g.addEdge(from, to, detach)
+		}
+	}
+	for _, stream := range s.Streams {
+		ref := Ref{KindStream, stream.ID}
+		link(ref, Ref{KindIndexSet, stream.IndexSetID}, false)
+		for _, rule := range stream.Rules {
+			link(Ref{KindStreamRule, rule.ID}, ref, false)
+		}
+		for _, cond := range stream.AlertConditions {
+			link(Ref{KindAlertCondition, cond.ID}, ref, false)
+		}
+	}
+	for _, ac := range s.AlarmCallbacks {
+		link(Ref{KindAlarmCallback, ac.ID}, Ref{KindStream, ac.StreamID}, false)
+	}
+	ruleIDs := make(map[string]string, len(s.PipelineRules))
+	for _, rule := range s.PipelineRules {
+		ruleIDs[rule.Title] = rule.ID
+	}
+	for _, pipeline := range s.Pipelines {
+		for _, stage := range pipeline.Stages {
+			for _, title := range stage.Rules {
+				if id, ok := ruleIDs[title]; ok {
+					link(Ref{KindPipeline, pipeline.ID}, Ref{KindPipelineRule, id}, true)
+				}
+			}
+		}
+	}
+	for _, conn := range s.PipelineConnections {
+		ref := Ref{KindPipelineConnection, conn.StreamID}
+		link(ref, Ref{KindStream, conn.StreamID}, false)
+		for _, id := range conn.PipelineIDs {
+			link(ref, Ref{KindPipeline, id}, true)
+		}
+	}
+	for _, dashboard := range s.Dashboards {
+		ref := Ref{KindDashboard, dashboard.ID}
+		for _, widget := range dashboard.Widgets {
+			wRef := Ref{KindWidget, widget.ID}
+			link(wRef, ref, false)
+			if id := widgetStreamID(widget); id != "" {
+				link(wRef, Ref{KindStream, id}, false)
+			}
+		}
+	}
+	for _, role := range s.Roles {
+		perms := role.Permissions.ToList()
+		sort.Strings(perms)
+		for _, perm := range perms {
+			for _, ref := range permissionRefs(perm) {
+				link(Ref{KindRole, role.Name}, ref, true)
+			}
+		}
+	}
+	return g
+}
+
+// Node returns a resource of the graph.
+func (g *Graph) Node(ref Ref) (*Node, bool) {
+	node, ok := g.nodes[ref]
+	return node, ok
+}
+
+func sortRefs(refs []Ref) {
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Kind != refs[j].Kind {
+			return refs[i].Kind < refs[j].Kind
+		}
+		return refs[i].ID < refs[j].ID
+	})
+}
+
+// Dependents returns resources which directly depend on a given resource.
+func (g *Graph) Dependents(ref Ref) []Ref {
+	edges := g.dependents[ref]
+	refs := make([]Ref, len(edges))
+	for i, e := range edges {
+		refs[i] = e.From
+	}
+	sortRefs(refs)
+	return refs
+}
+
+// Dependencies returns resources which a given resource directly depends on.
+func (g *Graph) Dependencies(ref Ref) []Ref {
+	edges := g.dependencies[ref]
+	refs := make([]Ref, len(edges))
+	for i, e := range edges {
+		refs[i] = e.To
+	}
+	sortRefs(refs)
+	return refs
+}
+
+// AllDependents returns resources which directly or indirectly depend on a given resource.
+func (g *Graph) AllDependents(ref Ref) []Ref {
+	visited := map[Ref]bool{ref: true}
+	queue := []Ref{ref}
+	refs := []Ref{}
+	for len(queue) != 0 {
+		r := queue[0]
+		queue = queue[1:]
+		for _, e := range g.dependents[r] {
+			if visited[e.From] {
+				continue
+			}
+			visited[e.From] = true
+			refs = append(refs, e.From)
+			queue = append(queue, e.From)
+		}
+	}
+	sortRefs(refs)
+	return refs
+}
@@ -0,0 +1,129 @@
+package depgraph_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/go-set"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/depgraph"
+	"github.com/suzuki-shunsuke/go-graylog/lint"
+)
+
+func testSnapshot() *lint.Snapshot {
+	return &lint.Snapshot{
+		IndexSets: []graylog.IndexSet{
+			{ID: "default", Title: "Default index set", Default: true},
+			{ID: "is", Title: "app"},
+		},
+		Streams: []graylog.Stream{
+			{ID: "all", Title: "All messages", IndexSetID: "default", IsDefault: true},
+			{
+				ID: "s1", Title: "app", IndexSetID: "is",
+				Rules:           []graylog.StreamRule{{ID: "r1", Field: "tag", Value: "app"}},
+				AlertConditions: []graylog.AlertCondition{{ID: "ac1", Title: "errors"}},
+			},
+		},
+		AlarmCallbacks: []graylog.AlarmCallback{{ID: "cb1", StreamID: "s1", Title: "notify"}},
+		PipelineRules:  []graylog.PipelineRule{{ID: "pr1", Title: "drop debug"}},
+		Pipelines: []graylog.Pipeline{
+			{
+				ID: "p1", Title: "app",
+				Stages: []graylog.PipelineStage{{Stage: 0, Rules: []string{"drop debug"}}},
+			},
+		},
+		PipelineConnections: []graylog.PipelineConnection{
+			{ID: "c1", StreamID: "s1", PipelineIDs: []string{"p1"}},
+			{ID: "c2", StreamID: "all", PipelineIDs: []string{"p1"}},
+		},
+		Dashboards: []graylog.Dashboard{
+			{
+				ID: "d1", Title: "app",
+				Widgets: []graylog.Widget{
+					{
+						ID: "w1", Description: "count",
+						Config: &graylog.WidgetConfigUnknownType{
+							T: "STREAM_SEARCH_RESULT_COUNT", Fields: map[string]interface{}{"stream_id": "s1"},
+						},
+					},
+				},
+			},
+		},
+		Roles: []graylog.Role{
+			{Name: "reader", Permissions: set.NewStrSet("streams:read:s1,all", "dashboards:read:d1")},
+		},
+	}
+}
+
+func TestGraph_Dependents(t *testing.T) {
+	g := depgraph.Build(testSnapshot())
+	require.Equal(t, []depgraph.Ref{
+		{Kind: depgraph.KindAlarmCallback, ID: "cb1"},
+		{Kind: depgraph.KindAlertCondition, ID: "ac1"},
+		{Kind: depgraph.KindPipelineConnection, ID: "s1"},
+		{Kind: depgraph.KindRole, ID: "reader"},
+		{Kind: depgraph.KindStreamRule, ID: "r1"},
+		{Kind: depgraph.KindWidget, ID: "w1"},
+	}, g.Dependents(depgraph.Ref{Kind: depgraph.KindStream, ID: "s1"}))
+	require.Equal(t, []depgraph.Ref{
+		{Kind: depgraph.KindIndexSet, ID: "is"},
+	}, g.Dependencies(depgraph.Ref{Kind: depgraph.KindStream, ID: "s1"}))
+	require.Equal(t, []depgraph.Ref{
+		{Kind: depgraph.KindPipeline, ID: "p1"},
+		{Kind: depgraph.KindPipelineConnection, ID: "all"},
+		{Kind: depgraph.KindPipelineConnection, ID: "s1"},
+	}, g.AllDependents(depgraph.Ref{Kind: depgraph.KindPipelineRule, ID: "pr1"}))
+}
+
+func TestGraph_PlanDelete(t *testing.T) {
+	g := depgraph.Build(testSnapshot())
+	_, err := g.PlanDelete(depgraph.Ref{Kind: depgraph.KindStream, ID: "unknown"})
+	require.NotNil(t, err)
+	_, err = g.PlanDelete(depgraph.Ref{Kind: depgraph.KindIndexSet, ID: "default"})
+	require.NotNil(t, err, "the default stream depends on the default index set")
+
+	plan, err := g.PlanDelete(depgraph.Ref{Kind: depgraph.KindIndexSet, ID: "is"})
+	require.Nil(t, err)
+	actions := make([]string, len(plan.Steps))
+	for i, step := range plan.Steps {
+		actions[i] = step.Action + " " + step.Node.Ref.String()
+	}
+	require.Equal(t, []string{
+		"detach role:reader",
+		"delete alarm_callback:cb1",
+		"delete alert_condition:ac1",
+		"delete pipeline_connection:s1",
+		"delete stream_rule:r1",
+		"delete widget:w1",
+		"delete stream:s1",
+		"delete index_set:is",
+	}, actions)
+	require.Equal(t, []depgraph.Ref{{Kind: depgraph.KindStream, ID: "s1"}}, plan.Steps[0].Detached)
+	require.Contains(t, plan.String(), `detach role "reader" (reader): remove stream:s1`)
+
+	plan, err = g.PlanDelete(depgraph.Ref{Kind: depgraph.KindPipeline, ID: "p1"})
+	require.Nil(t, err)
+	actions = make([]string, len(plan.Steps))
+	for i, step := range plan.Steps {
+		actions[i] = step.Action + " " + step.Node.Ref.String()
+	}
+	require.Equal(t, []string{
+		"detach pipeline_connection:all",
+		"detach pipeline_connection:s1",
+		"delete pipeline:p1",
+	}, actions)
+
+	// the pipeline which refers the rule isn't deleted
+	plan, err = g.PlanDelete(depgraph.Ref{Kind: depgraph.KindPipelineRule, ID: "pr1"})
+	require.Nil(t, err)
+	actions = make([]string, len(plan.Steps))
+	for i, step := range plan.Steps {
+		actions[i] = step.Action + " " + step.Node.Ref.String()
+	}
+	require.Equal(t, []string{
+		"detach pipeline:p1",
+		"delete pipeline_rule:pr1",
+	}, actions)
+	require.Contains(t, plan.String(), `detach pipeline "app" (p1): remove pipeline_rule:pr1`)
+}
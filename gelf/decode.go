@@ -0,0 +1,187 @@
+package gelf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// DefaultChunkTimeout is the default time to wait for all chunks of a message, which is same as Graylog.
+const DefaultChunkTimeout = 5 * time.Second
+
+type (
+	// Reassembler reassembles chunked GELF UDP messages.
+	Reassembler struct {
+		// Timeout is the time to wait for all chunks of a message. The default is DefaultChunkTimeout.
+		Timeout time.Duration
+		// MaxChunks is the max number of chunks of a message. The default is MaxChunkCount.
+		MaxChunks int
+
+		mutex   sync.Mutex
+		pending map[string]*chunkSet
+	}
+
+	chunkSet struct {
+		createdAt time.Time
+		chunks    [][]byte
+		received  int
+	}
+)
+
+// Decompress decompresses a payload compressed by gzip or zlib.
+// The compression is detected by the magic bytes, and an uncompressed payload is returned as is.
+func Decompress(b []byte) ([]byte, error) {
+	var (
+		r   io.ReadCloser
+		err error
+	)
+	switch {
+	case len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b:
+		r, err = gzip.NewReader(bytes.NewReader(b))
+	case len(b) >= 2 && b[0] == 0x78 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0:
+		r, err = zlib.NewReader(bytes.NewReader(b))
+	default:
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// Decode decompresses a payload and decodes it into a message.
+func Decode(b []byte) (*Message, error) {
+	b, err := Decompress(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress a GELF message: %v", err)
+	}
+	m := &Message{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("failed to decode a GELF message: %v", err)
+	}
+	return m, nil
+}
+
+// IsChunk returns true if the UDP packet is a chunk.
+func IsChunk(b []byte) bool {
+	return len(b) >= 2 && b[0] == ChunkMagic[0] && b[1] == ChunkMagic[1]
+}
+
+// NewReassembler returns a new reassembler.
+func NewReassembler() *Reassembler {
+	return &Reassembler{}
+}
+
+// Add adds an UDP packet.
+// If the packet isn't a chunk, it is returned as is.
+// If the packet is the last chunk of a message, the reassembled payload is returned.
+// Otherwise Add returns nil.
+func (r *Reassembler) Add(b []byte) ([]byte, error) {
+	if !IsChunk(b) {
+		return b, nil
+	}
+	if len(b) < chunkHeaderSize {
+		return nil, fmt.Errorf("chunk is too short: %d bytes", len(b))
+	}
+	maxChunks := r.MaxChunks
+	if maxChunks <= 0 {
+		maxChunks = MaxChunkCount
+	}
+	id := string(b[2:10])
+	seq, count := int(b[10]), int(b[11])
+	if count == 0 || count > maxChunks {
+		return nil, fmt.Errorf("invalid chunk count %d: the max is %d", count, maxChunks)
+	}
+	if seq >= count {
+		return nil, fmt.Errorf("invalid sequence number %d: the count is %d", seq, count)
+	}
+
+	now := time.Now()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.expire(now)
+	if r.pending == nil {
+		r.pending = map[string]*chunkSet{}
+	}
+	set, ok := r.pending[id]
+	if !ok {
+		set = &chunkSet{createdAt: now, chunks: make([][]byte, count)}
+		r.pending[id] = set
+	}
+	if len(set.chunks) != count {
+		delete(r.pending, id)
+		return nil, fmt.Errorf("chunk count of the message is inconsistent")
+	}
+	if set.chunks[seq] == nil {
+		set.received++
+	}
+	set.chunks[seq] = append([]byte{}, b[chunkHeaderSize:]...)
+	if set.received < count {
+		return nil, nil
+	}
+	delete(r.pending, id)
+	return bytes.Join(set.chunks, nil), nil
+}
+
+// Expire drops the incomplete messages whose first chunk arrived before the timeout
+// and returns the number of the dropped messages.
+func (r *Reassembler) Expire() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.expire(time.Now())
+}
+
+func (r *Reassembler) expire(now time.Time) int {
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultChunkTimeout
+	}
+	n := 0
+	for id, set := range r.pending {
+		if now.Sub(set.createdAt) > timeout {
+			delete(r.pending, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Pending returns the number of the incomplete messages.
+func (r *Reassembler) Pending() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.pending)
+}
+
+// NewTCPScanner returns a scanner which splits GELF TCP frames delimited by null bytes.
+// If maxSize is zero, the max frame size is 2 MiB.
+func NewTCPScanner(r io.Reader, maxSize int) *bufio.Scanner {
+	if maxSize <= 0 {
+		maxSize = 2 * 1024 * 1024
+	}
+	initSize := 4096
+	if initSize > maxSize+1 {
+		initSize = maxSize + 1
+	}
+	scanner := bufio.NewScanner(r)
+	// the max token size is the larger of the max and the buffer's capacity
+	scanner.Buffer(make([]byte, 0, initSize), maxSize+1)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, 0); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) != 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	return scanner
+}
@@ -0,0 +1,87 @@
+package gelf_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/gelf"
+)
+
+func TestDecode(t *testing.T) {
+	m := &gelf.Message{Version: gelf.Version, Host: "example.org", ShortMessage: "hello", Level: gelf.LevelInfo}
+	b, err := json.Marshal(m)
+	require.Nil(t, err)
+
+	a, err := gelf.Decode(b)
+	require.Nil(t, err)
+	require.Equal(t, m, a)
+
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	_, err = w.Write(b)
+	require.Nil(t, err)
+	require.Nil(t, w.Close())
+	a, err = gelf.Decode(buf.Bytes())
+	require.Nil(t, err)
+	require.Equal(t, m, a)
+
+	_, err = gelf.Decode([]byte("foo"))
+	require.NotNil(t, err)
+}
+
+func TestReassembler(t *testing.T) {
+	r := gelf.NewReassembler()
+	b, err := r.Add([]byte("{}"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("{}"), b)
+
+	payload := []byte(strings.Repeat("abcdefghij", 10))
+	chunks, err := gelf.Chunk(payload, 32)
+	require.Nil(t, err)
+	require.Len(t, chunks, 5)
+	// chunks may arrive out of order and be duplicated
+	for _, i := range []int{4, 0, 2, 2, 1} {
+		b, err := r.Add(chunks[i])
+		require.Nil(t, err)
+		require.Nil(t, b)
+	}
+	require.Equal(t, 1, r.Pending())
+	b, err = r.Add(chunks[3])
+	require.Nil(t, err)
+	require.Equal(t, payload, b)
+	require.Equal(t, 0, r.Pending())
+
+	// timeout
+	r.Timeout = time.Nanosecond
+	_, err = r.Add(chunks[0])
+	require.Nil(t, err)
+	time.Sleep(time.Millisecond)
+	require.Equal(t, 1, r.Expire())
+
+	// chunk limit
+	r.MaxChunks = 4
+	_, err = r.Add(chunks[0])
+	require.NotNil(t, err)
+	_, err = r.Add(chunks[0][:5])
+	require.NotNil(t, err)
+}
+
+func TestNewTCPScanner(t *testing.T) {
+	scanner := gelf.NewTCPScanner(strings.NewReader("{\"a\":1}\x00{\"b\":2}\x00{\"c\":3}"), 0)
+	frames := []string{}
+	for scanner.Scan() {
+		frames = append(frames, scanner.Text())
+	}
+	require.Nil(t, scanner.Err())
+	require.Equal(t, []string{`{"a":1}`, `{"b":2}`, `{"c":3}`}, frames)
+
+	scanner = gelf.NewTCPScanner(strings.NewReader("0123456789\x00"), 5)
+	require.False(t, scanner.Scan())
+	require.NotNil(t, scanner.Err())
+}
@@ -0,0 +1,27 @@
+/*
+Package gelf sends GELF (Graylog Extended Log Format) messages to Graylog's GELF inputs.
+
+https://docs.graylog.org/en/latest/pages/gelf.html
+
+UDP (with chunking and compression), TCP (null byte delimiter) and HTTP are supported.
+
+	s, err := gelf.NewUDPSender("graylog.example.com:12201")
+	w := gelf.NewWriter(s)
+	defer w.Close()
+	err = w.WriteMessage(&gelf.Message{
+		ShortMessage: "hello",
+		Level:        gelf.LevelInfo,
+		Extra:        map[string]interface{}{"env": "dev"},
+	})
+	// Writer implements io.Writer
+	log.SetOutput(w)
+
+The sender can be created from a graylog.Input which the client or the terraform provider creates.
+
+	s, err := gelf.NewSenderFromInput(input, &gelf.InputOptions{Host: "graylog.example.com"})
+
+Hook is a logrus hook which sends the log entries.
+
+	logrus.AddHook(gelf.NewHook(w))
+*/
+package gelf
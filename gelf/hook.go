@@ -0,0 +1,65 @@
+package gelf
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is a logrus hook which sends log entries as GELF messages.
+// The entry's data is sent as the additional fields.
+type Hook struct {
+	Writer *Writer
+	// LogLevels is the levels which the hook fires. The default is all levels.
+	LogLevels []logrus.Level
+}
+
+// NewHook returns a new logrus hook.
+func NewHook(w *Writer) *Hook {
+	return &Hook{Writer: w}
+}
+
+// Levels returns the levels which the hook fires.
+func (h *Hook) Levels() []logrus.Level {
+	if h.LogLevels == nil {
+		return logrus.AllLevels
+	}
+	return h.LogLevels
+}
+
+// Fire sends a log entry.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	extra := make(map[string]interface{}, len(entry.Data))
+	for k, v := range entry.Data {
+		switch a := v.(type) {
+		case error:
+			extra[k] = a.Error()
+		case fmt.Stringer:
+			extra[k] = a.String()
+		default:
+			extra[k] = v
+		}
+	}
+	return h.Writer.WriteMessage(&Message{
+		ShortMessage: entry.Message,
+		Timestamp:    Time(entry.Time),
+		Level:        logrusLevel(entry.Level),
+		Extra:        extra,
+	})
+}
+
+func logrusLevel(level logrus.Level) int {
+	switch level {
+	case logrus.PanicLevel:
+		return LevelAlert
+	case logrus.FatalLevel:
+		return LevelCritical
+	case logrus.ErrorLevel:
+		return LevelError
+	case logrus.WarnLevel:
+		return LevelWarning
+	case logrus.InfoLevel:
+		return LevelInfo
+	}
+	return LevelDebug
+}
@@ -0,0 +1,116 @@
+package gelf
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// InputOptions is the options of NewSenderFromInput.
+type InputOptions struct {
+	// Host is the host name of the Graylog node where the input runs.
+	// If Host is empty, the input's bind address is used unless it is a wildcard address.
+	Host string
+	// TLSConfig is used when the input enables TLS. If it is nil, the default config is used.
+	TLSConfig *tls.Config
+	// Compression is the compression of the UDP and HTTP senders.
+	// If Compression is nil, the sender's default is used:
+	// UDP senders compress messages with gzip and HTTP senders don't compress messages.
+	Compression *Compression
+}
+
+// NewSenderFromInput returns a new sender which sends messages to a GELF UDP, TCP or HTTP input.
+func NewSenderFromInput(input *graylog.Input, opts *InputOptions) (Sender, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+	if opts == nil {
+		opts = &InputOptions{}
+	}
+	switch attrs := input.Attrs.(type) {
+	case *graylog.InputGELFUDPAttrs:
+		return newUDPSenderFromInput(attrs.BindAddress, attrs.Port, opts)
+	case graylog.InputGELFUDPAttrs:
+		return newUDPSenderFromInput(attrs.BindAddress, attrs.Port, opts)
+	case *graylog.InputGELFTCPAttrs:
+		return newTCPSenderFromInput(attrs.BindAddress, attrs.Port, attrs.TLSEnable, opts)
+	case graylog.InputGELFTCPAttrs:
+		return newTCPSenderFromInput(attrs.BindAddress, attrs.Port, attrs.TLSEnable, opts)
+	case *graylog.InputGELFHTTPAttrs:
+		return newHTTPSenderFromInput(attrs.BindAddress, attrs.Port, attrs.TLSEnable, opts)
+	case graylog.InputGELFHTTPAttrs:
+		return newHTTPSenderFromInput(attrs.BindAddress, attrs.Port, attrs.TLSEnable, opts)
+	}
+	return nil, fmt.Errorf("input type %s isn't a GELF UDP, TCP or HTTP input", input.Type())
+}
+
+func inputAddr(bindAddress string, port int, opts *InputOptions) (string, error) {
+	if port == 0 {
+		return "", fmt.Errorf("port of the input is empty")
+	}
+	host := opts.Host
+	if host == "" {
+		if ip := net.ParseIP(bindAddress); bindAddress == "" || (ip != nil && ip.IsUnspecified()) {
+			return "", fmt.Errorf("host is required because the input's bind address is %q", bindAddress)
+		}
+		host = bindAddress
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
+
+func newUDPSenderFromInput(bindAddress string, port int, opts *InputOptions) (Sender, error) {
+	addr, err := inputAddr(bindAddress, port, opts)
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewUDPSender(addr)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Compression != nil {
+		s.Compression = *opts.Compression
+	}
+	return s, nil
+}
+
+func newTCPSenderFromInput(bindAddress string, port int, tlsEnable bool, opts *InputOptions) (Sender, error) {
+	addr, err := inputAddr(bindAddress, port, opts)
+	if err != nil {
+		return nil, err
+	}
+	var cfg *tls.Config
+	if tlsEnable {
+		cfg = opts.TLSConfig
+		if cfg == nil {
+			cfg = &tls.Config{}
+		}
+	}
+	return NewTCPSender(addr, cfg)
+}
+
+func newHTTPSenderFromInput(bindAddress string, port int, tlsEnable bool, opts *InputOptions) (Sender, error) {
+	addr, err := inputAddr(bindAddress, port, opts)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "http"
+	s := NewHTTPSender("")
+	if opts.Compression != nil {
+		s.Compression = *opts.Compression
+	}
+	if tlsEnable {
+		scheme = "https"
+		if opts.TLSConfig != nil {
+			s.HTTPClient = &http.Client{
+				Timeout:   DefaultHTTPTimeout,
+				Transport: &http.Transport{TLSClientConfig: opts.TLSConfig},
+			}
+		}
+	}
+	s.URL = scheme + "://" + addr + "/gelf"
+	return s, nil
+}
@@ -0,0 +1,47 @@
+package gelf_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/gelf"
+)
+
+func TestNewSenderFromInput(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer conn.Close()
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+
+	input := &graylog.Input{
+		Attrs: &graylog.InputGELFUDPAttrs{BindAddress: "0.0.0.0", Port: port},
+	}
+	_, err = gelf.NewSenderFromInput(input, nil)
+	require.NotNil(t, err)
+	s, err := gelf.NewSenderFromInput(input, &gelf.InputOptions{Host: "127.0.0.1"})
+	require.Nil(t, err)
+	require.IsType(t, &gelf.UDPSender{}, s)
+	require.Equal(t, gelf.CompressionGzip, s.(*gelf.UDPSender).Compression)
+	require.Nil(t, s.Send(&gelf.Message{ShortMessage: "foo"}))
+	require.Nil(t, s.Close())
+
+	none := gelf.CompressionNone
+	s, err = gelf.NewSenderFromInput(input, &gelf.InputOptions{Host: "127.0.0.1", Compression: &none})
+	require.Nil(t, err)
+	require.Equal(t, gelf.CompressionNone, s.(*gelf.UDPSender).Compression)
+	require.Nil(t, s.Close())
+
+	s, err = gelf.NewSenderFromInput(&graylog.Input{
+		Attrs: &graylog.InputGELFHTTPAttrs{BindAddress: "127.0.0.1", Port: 12201, TLSEnable: true},
+	}, nil)
+	require.Nil(t, err)
+	require.Equal(t, "https://127.0.0.1:12201/gelf", s.(*gelf.HTTPSender).URL)
+
+	_, err = gelf.NewSenderFromInput(&graylog.Input{
+		Attrs: &graylog.InputBeatsAttrs{BindAddress: "127.0.0.1", Port: 5044},
+	}, nil)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,119 @@
+package gelf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Version is the version of GELF.
+const Version = "1.1"
+
+// The log levels of GELF, which are equal to the syslog severities.
+const (
+	LevelEmergency = 0
+	LevelAlert     = 1
+	LevelCritical  = 2
+	LevelError     = 3
+	LevelWarning   = 4
+	LevelNotice    = 5
+	LevelInfo      = 6
+	LevelDebug     = 7
+)
+
+// Message represents a GELF message.
+type Message struct {
+	Version      string
+	Host         string
+	ShortMessage string
+	FullMessage  string
+	// Timestamp is the seconds since UNIX epoch with optional decimal places for milliseconds.
+	Timestamp float64
+	Level     int
+	// Extra is the additional fields. The key must not have the prefix "_".
+	Extra map[string]interface{}
+}
+
+// messageJSON is the JSON representation of the standard fields.
+type messageJSON struct {
+	Version      string  `json:"version"`
+	Host         string  `json:"host"`
+	ShortMessage string  `json:"short_message"`
+	FullMessage  string  `json:"full_message,omitempty"`
+	Timestamp    float64 `json:"timestamp,omitempty"`
+	Level        *int    `json:"level,omitempty"`
+}
+
+// Time converts a time.Time to GELF's timestamp.
+func Time(t time.Time) float64 {
+	return float64(t.UnixNano()/int64(time.Millisecond)) / 1000
+}
+
+// MarshalJSON returns JSON encoding of a message.
+// The additional fields are prefixed with "_".
+func (m *Message) MarshalJSON() ([]byte, error) {
+	level := m.Level
+	b, err := json.Marshal(&messageJSON{
+		Version:      m.Version,
+		Host:         m.Host,
+		ShortMessage: m.ShortMessage,
+		FullMessage:  m.FullMessage,
+		Timestamp:    m.Timestamp,
+		Level:        &level,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(m.Extra) == 0 {
+		return b, nil
+	}
+	extra := make(map[string]interface{}, len(m.Extra))
+	for k, v := range m.Extra {
+		k = strings.TrimPrefix(k, "_")
+		if k == "id" {
+			return nil, fmt.Errorf(`the additional field "_id" isn't allowed`)
+		}
+		extra["_"+k] = v
+	}
+	e, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+	// merge two JSON objects
+	return append(append(b[:len(b)-1], ','), e[1:]...), nil
+}
+
+// UnmarshalJSON unmarshals JSON into a message.
+// The fields with the prefix "_" are set to Extra without the prefix.
+func (m *Message) UnmarshalJSON(b []byte) error {
+	a := messageJSON{}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	*m = Message{
+		Version:      a.Version,
+		Host:         a.Host,
+		ShortMessage: a.ShortMessage,
+		FullMessage:  a.FullMessage,
+		Timestamp:    a.Timestamp,
+		Level:        LevelAlert,
+	}
+	if a.Level != nil {
+		m.Level = *a.Level
+	}
+	for k, v := range fields {
+		if !strings.HasPrefix(k, "_") {
+			continue
+		}
+		if m.Extra == nil {
+			m.Extra = map[string]interface{}{}
+		}
+		m.Extra[k[1:]] = v
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package gelf_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/gelf"
+)
+
+func TestMessage_MarshalJSON(t *testing.T) {
+	m := &gelf.Message{
+		Version:      gelf.Version,
+		Host:         "example.org",
+		ShortMessage: "A short message",
+		Timestamp:    1385053862.307,
+		Level:        gelf.LevelInfo,
+		Extra:        map[string]interface{}{"user_id": 9001, "_some_info": "foo"},
+	}
+	b, err := json.Marshal(m)
+	require.Nil(t, err)
+	require.JSONEq(t, `{
+  "version": "1.1",
+  "host": "example.org",
+  "short_message": "A short message",
+  "timestamp": 1385053862.307,
+  "level": 6,
+  "_user_id": 9001,
+  "_some_info": "foo"
+}`, string(b))
+
+	a := &gelf.Message{}
+	require.Nil(t, json.Unmarshal(b, a))
+	require.Equal(t, map[string]interface{}{"user_id": float64(9001), "some_info": "foo"}, a.Extra)
+	require.Equal(t, gelf.LevelInfo, a.Level)
+
+	_, err = json.Marshal(&gelf.Message{Extra: map[string]interface{}{"_id": "foo"}})
+	require.NotNil(t, err)
+
+	require.Nil(t, json.Unmarshal([]byte(`{"version": "1.1", "host": "foo", "short_message": "bar"}`), a))
+	require.Equal(t, &gelf.Message{
+		Version: "1.1", Host: "foo", ShortMessage: "bar", Level: gelf.LevelAlert}, a)
+}
+
+func TestTime(t *testing.T) {
+	require.Equal(t, 1385053862.307, gelf.Time(time.Unix(1385053862, 307999999)))
+}
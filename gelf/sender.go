@@ -0,0 +1,295 @@
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Compression is the compression type of the UDP and HTTP senders.
+type Compression int
+
+const (
+	// CompressionNone doesn't compress messages.
+	CompressionNone Compression = iota
+	// CompressionGzip compresses messages with gzip.
+	CompressionGzip
+	// CompressionZlib compresses messages with zlib.
+	CompressionZlib
+)
+
+const (
+	// DefaultChunkSize is the default max size of an UDP chunk which is suitable for WAN.
+	DefaultChunkSize = 1420
+	// MaxChunkCount is the max number of the chunks of a message.
+	MaxChunkCount = 128
+
+	chunkHeaderSize = 12
+
+	// DefaultHTTPTimeout is the timeout of the HTTP sender's default HTTP client.
+	DefaultHTTPTimeout = 10 * time.Second
+)
+
+var (
+	// ChunkMagic is the magic bytes of a chunked GELF message.
+	ChunkMagic = []byte{0x1e, 0x0f}
+
+	defaultHTTPClient = &http.Client{Timeout: DefaultHTTPTimeout}
+)
+
+type (
+	// Sender sends a GELF message.
+	Sender interface {
+		Send(m *Message) error
+		Close() error
+	}
+
+	// UDPSender sends messages over UDP.
+	// Messages which are larger than ChunkSize are chunked.
+	UDPSender struct {
+		// Compression is CompressionGzip by default.
+		Compression Compression
+		ChunkSize   int
+		conn        net.Conn
+	}
+
+	// TCPSender sends messages over TCP. Each message is delimited by a null byte.
+	// If the connection is broken, the sender reconnects once per message.
+	TCPSender struct {
+		addr      string
+		tlsConfig *tls.Config
+		mutex     sync.Mutex
+		conn      net.Conn
+	}
+
+	// HTTPSender sends messages to the GELF HTTP input.
+	HTTPSender struct {
+		// URL is the endpoint such as "http://graylog.example.com:12201/gelf".
+		URL         string
+		Compression Compression
+		// HTTPClient is the HTTP client to send messages.
+		// If HTTPClient is nil, the client whose timeout is DefaultHTTPTimeout is used.
+		HTTPClient *http.Client
+	}
+)
+
+// NewUDPSender returns a new UDP sender which compresses messages with gzip.
+func NewUDPSender(addr string) (*UDPSender, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &UDPSender{
+		Compression: CompressionGzip,
+		ChunkSize:   DefaultChunkSize,
+		conn:        conn,
+	}, nil
+}
+
+// NewTCPSender returns a new TCP sender.
+// If tlsConfig isn't nil, the connection uses TLS.
+func NewTCPSender(addr string, tlsConfig *tls.Config) (*TCPSender, error) {
+	s := &TCPSender{addr: addr, tlsConfig: tlsConfig}
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewHTTPSender returns a new HTTP sender.
+func NewHTTPSender(u string) *HTTPSender {
+	return &HTTPSender{URL: u}
+}
+
+func compress(b []byte, c Compression) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+	switch c {
+	case CompressionNone:
+		return b, nil
+	case CompressionGzip:
+		w = gzip.NewWriter(&buf)
+	case CompressionZlib:
+		w = zlib.NewWriter(&buf)
+	default:
+		return nil, fmt.Errorf("unknown compression: %d", c)
+	}
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Chunk splits a message into GELF chunks whose size is at most chunkSize.
+// If the message is smaller than chunkSize, the message is returned as is.
+func Chunk(b []byte, chunkSize int) ([][]byte, error) {
+	if len(b) <= chunkSize {
+		return [][]byte{b}, nil
+	}
+	size := chunkSize - chunkHeaderSize
+	if size <= 0 {
+		return nil, fmt.Errorf("chunk size must be larger than %d", chunkHeaderSize)
+	}
+	count := (len(b) + size - 1) / size
+	if count > MaxChunkCount {
+		return nil, fmt.Errorf("message is too large: %d chunks are required but the max is %d", count, MaxChunkCount)
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * size
+		if end > len(b) {
+			end = len(b)
+		}
+		chunk := make([]byte, 0, chunkHeaderSize+end-i*size)
+		chunk = append(chunk, ChunkMagic...)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, b[i*size:end]...)
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// Send sends a message.
+func (s *UDPSender) Send(m *Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	b, err = compress(b, s.Compression)
+	if err != nil {
+		return err
+	}
+	chunkSize := s.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	chunks, err := Chunk(b, chunkSize)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if _, err := s.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (s *UDPSender) Close() error {
+	return s.conn.Close()
+}
+
+func (s *TCPSender) connect() error {
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.tlsConfig != nil {
+		conn, err = tls.Dial("tcp", s.addr, s.tlsConfig)
+	} else {
+		conn, err = net.Dial("tcp", s.addr)
+	}
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+// Send sends a message.
+func (s *TCPSender) Send(m *Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	b = append(b, 0)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conn != nil {
+		if _, err := s.conn.Write(b); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	if err := s.connect(); err != nil {
+		return err
+	}
+	_, err = s.conn.Write(b)
+	return err
+}
+
+// Close closes the connection.
+func (s *TCPSender) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// Send sends a message.
+func (s *HTTPSender) Send(m *Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	b, err = compress(b, s.Compression)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch s.Compression {
+	case CompressionGzip:
+		req.Header.Set("Content-Encoding", "gzip")
+	case CompressionZlib:
+		req.Header.Set("Content-Encoding", "deflate")
+	}
+	hc := s.HTTPClient
+	if hc == nil {
+		hc = defaultHTTPClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send a GELF message: %s %d", s.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// Close does nothing.
+func (s *HTTPSender) Close() error {
+	return nil
+}
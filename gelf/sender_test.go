@@ -0,0 +1,165 @@
+package gelf_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/gelf"
+)
+
+func TestChunk(t *testing.T) {
+	chunks, err := gelf.Chunk([]byte("foo"), 20)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("foo")}, chunks)
+
+	b := []byte(strings.Repeat("a", 25))
+	chunks, err = gelf.Chunk(b, 22)
+	require.Nil(t, err)
+	require.Len(t, chunks, 3)
+	joined := []byte{}
+	for i, chunk := range chunks {
+		require.Equal(t, gelf.ChunkMagic, chunk[:2])
+		require.Equal(t, chunks[0][2:10], chunk[2:10])
+		require.Equal(t, byte(i), chunk[10])
+		require.Equal(t, byte(3), chunk[11])
+		joined = append(joined, chunk[12:]...)
+	}
+	require.Equal(t, b, joined)
+
+	_, err = gelf.Chunk(b, 12)
+	require.NotNil(t, err)
+	_, err = gelf.Chunk(make([]byte, 129*10), 22)
+	require.NotNil(t, err)
+}
+
+func TestUDPSender(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer conn.Close()
+
+	s, err := gelf.NewUDPSender(conn.LocalAddr().String())
+	require.Nil(t, err)
+	defer s.Close()
+	w := gelf.NewWriter(s)
+	w.Host = "example.org"
+
+	// gzip
+	_, err = w.Write([]byte("hello\n"))
+	require.Nil(t, err)
+	buf := make([]byte, 65536)
+	n, _, err := conn.ReadFrom(buf)
+	require.Nil(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(buf[:n]))
+	require.Nil(t, err)
+	b, err := ioutil.ReadAll(r)
+	require.Nil(t, err)
+	m := &gelf.Message{}
+	require.Nil(t, json.Unmarshal(b, m))
+	require.Equal(t, "hello", m.ShortMessage)
+	require.Equal(t, "example.org", m.Host)
+	require.Equal(t, gelf.LevelInfo, m.Level)
+
+	// zlib and chunked
+	s.Compression = gelf.CompressionZlib
+	s.ChunkSize = 100
+	long := strings.Repeat("abcdefghijklmnopqrstuvwxyz0123456789", 100)
+	require.Nil(t, s.Send(&gelf.Message{Version: gelf.Version, Host: "example.org", ShortMessage: "long", FullMessage: long}))
+	payload := []byte{}
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		require.Nil(t, err)
+		require.Equal(t, gelf.ChunkMagic, buf[:2])
+		payload = append(payload, buf[12:n]...)
+		if buf[10] == buf[11]-1 {
+			break
+		}
+	}
+	r2, err := zlib.NewReader(bytes.NewReader(payload))
+	require.Nil(t, err)
+	b, err = ioutil.ReadAll(r2)
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(b, m))
+	require.Equal(t, long, m.FullMessage)
+}
+
+func TestTCPSender(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer ln.Close()
+	msgs := make(chan []byte, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			b, err := r.ReadBytes(0)
+			if err != nil {
+				close(msgs)
+				return
+			}
+			msgs <- b
+		}
+	}()
+
+	s, err := gelf.NewTCPSender(ln.Addr().String(), nil)
+	require.Nil(t, err)
+	w := gelf.NewWriter(s)
+	w.Extra = map[string]interface{}{"env": "test"}
+	require.Nil(t, w.WriteMessage(&gelf.Message{ShortMessage: "foo"}))
+	require.Nil(t, w.WriteMessage(&gelf.Message{ShortMessage: "bar", Extra: map[string]interface{}{"env": "dev"}}))
+	require.Nil(t, w.Close())
+
+	for _, exp := range []struct{ msg, env string }{{"foo", "test"}, {"bar", "dev"}} {
+		b := <-msgs
+		require.Equal(t, byte(0), b[len(b)-1])
+		m := &gelf.Message{}
+		require.Nil(t, json.Unmarshal(b[:len(b)-1], m))
+		require.Equal(t, exp.msg, m.ShortMessage)
+		require.Equal(t, exp.env, m.Extra["env"])
+		require.Equal(t, gelf.Version, m.Version)
+		require.NotZero(t, m.Timestamp)
+	}
+}
+
+func TestHTTPSender(t *testing.T) {
+	var m gelf.Message
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/gelf", req.URL.Path)
+		require.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+		r, err := gzip.NewReader(req.Body)
+		require.Nil(t, err)
+		require.Nil(t, json.NewDecoder(r).Decode(&m))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	s := gelf.NewHTTPSender(ts.URL + "/gelf")
+	s.Compression = gelf.CompressionGzip
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	logger.AddHook(gelf.NewHook(gelf.NewWriter(s)))
+	logger.WithField("user_id", 10).Warn("hello")
+	require.Equal(t, "hello", m.ShortMessage)
+	require.Equal(t, gelf.LevelWarning, m.Level)
+	require.Equal(t, float64(10), m.Extra["user_id"])
+
+	s.URL = ts.URL + "/foo"
+	s.Compression = gelf.CompressionNone
+	ts.Config.Handler = http.NotFoundHandler()
+	require.NotNil(t, s.Send(&gelf.Message{ShortMessage: "foo"}))
+}
@@ -0,0 +1,76 @@
+package gelf
+
+import (
+	"bytes"
+	"os"
+	"time"
+)
+
+// Writer sends messages with the default fields.
+// Writer implements io.Writer, so it can be used as the output of a logger.
+type Writer struct {
+	Sender Sender
+	// Host is set to messages whose host is empty. The default is the hostname.
+	Host string
+	// Level is the level of the messages written by Write. The default is LevelInfo.
+	Level int
+	// Extra is the additional fields which are added to all messages.
+	Extra map[string]interface{}
+}
+
+// NewWriter returns a new writer.
+func NewWriter(s Sender) *Writer {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+	return &Writer{Sender: s, Host: host, Level: LevelInfo}
+}
+
+// Write sends p as a message.
+// The first line is the short message and p is the full message if p has multiple lines.
+func (w *Writer) Write(p []byte) (int, error) {
+	b := bytes.TrimRight(p, "\n")
+	m := &Message{Level: w.Level}
+	if i := bytes.IndexByte(b, '\n'); i >= 0 {
+		m.ShortMessage = string(b[:i])
+		m.FullMessage = string(b)
+	} else {
+		m.ShortMessage = string(b)
+	}
+	if err := w.WriteMessage(m); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteMessage sends a message.
+// The version, host, timestamp and the additional fields of the writer are set if they are empty.
+func (w *Writer) WriteMessage(m *Message) error {
+	msg := *m
+	if msg.Version == "" {
+		msg.Version = Version
+	}
+	if msg.Host == "" {
+		msg.Host = w.Host
+	}
+	if msg.Timestamp == 0 {
+		msg.Timestamp = Time(time.Now())
+	}
+	if len(w.Extra) != 0 {
+		extra := make(map[string]interface{}, len(w.Extra)+len(m.Extra))
+		for k, v := range w.Extra {
+			extra[k] = v
+		}
+		for k, v := range m.Extra {
+			extra[k] = v
+		}
+		msg.Extra = extra
+	}
+	return w.Sender.Send(&msg)
+}
+
+// Close closes the sender.
+func (w *Writer) Close() error {
+	return w.Sender.Close()
+}
@@ -4,8 +4,11 @@ go 1.13
 
 require (
 	github.com/AlecAivazis/survey/v2 v2.0.4
+	github.com/go-playground/locales v0.12.1 // indirect
+	github.com/go-playground/universal-translator v0.16.0 // indirect
 	github.com/gofrs/uuid v3.2.0+incompatible
 	github.com/hashicorp/terraform v0.12.8
+	github.com/leodido/go-urn v1.1.0 // indirect
 	github.com/mitchellh/mapstructure v1.1.2
 	github.com/pkg/errors v0.8.1
 	github.com/sanity-io/litter v1.1.0
@@ -15,8 +18,8 @@ require (
 	github.com/suzuki-shunsuke/go-jsoneq v0.1.1
 	github.com/suzuki-shunsuke/go-ptr v1.0.0
 	github.com/suzuki-shunsuke/go-set v6.0.0+incompatible
-	github.com/suzuki-shunsuke/graylog-mock-server v1.0.0
 	gopkg.in/go-playground/validator.v9 v9.29.1
 	gopkg.in/h2non/gock.v1 v1.0.15
 	gopkg.in/mgo.v2 v2.0.0-20190816093944-a6b53ec6cb22
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -84,6 +84,12 @@ func (input *Input) ToData() (*InputData, error) {
 	if input.Attrs == nil {
 		return d, nil
 	}
+	if attrs, ok := input.Attrs.(*InputUnknownAttrs); ok {
+		for k, v := range attrs.Data {
+			d.Attrs[k] = v
+		}
+		return d, nil
+	}
 	return d, util.MSDecode(input.Attrs, &d.Attrs)
 }
 
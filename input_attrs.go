@@ -34,6 +34,8 @@ var (
 		NewInputNetFlowUDPAttrs,
 		NewInputRawAMQPAttrs,
 		NewInputRawKafkaAttrs,
+		NewInputRawTCPAttrs,
+		NewInputRawUDPAttrs,
 		NewInputSyslogAMQPAttrs,
 		NewInputSyslogKafkaAttrs,
 		NewInputSyslogTCPAttrs,
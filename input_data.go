@@ -71,7 +71,7 @@ func (d *InputData) ToInput(input *Input) error {
 	input.StaticFields = d.StaticFields
 	attrs := NewInputAttrsByType(d.Type)
 	if _, ok := attrs.(*InputUnknownAttrs); ok {
-		input.Attrs = &InputUnknownAttrs{inputType: input.Type(), Data: d.Attrs}
+		input.Attrs = &InputUnknownAttrs{inputType: d.Type, Data: d.Attrs}
 		return nil
 	}
 	if err := util.MSDecode(d.Attrs, attrs); err != nil {
@@ -0,0 +1,25 @@
+package graylog
+
+const (
+	// InputTypeRawUDP is one of input types.
+	InputTypeRawUDP string = "org.graylog2.inputs.raw.udp.RawUDPInput"
+)
+
+// NewInputRawUDPAttrs is the constructor of InputRawUDPAttrs.
+func NewInputRawUDPAttrs() InputAttrs {
+	return &InputRawUDPAttrs{}
+}
+
+// InputType is the implementation of the InputAttrs interface.
+func (attrs InputRawUDPAttrs) InputType() string {
+	return InputTypeRawUDP
+}
+
+// InputRawUDPAttrs represents raw/plaintext UDP Input's attributes.
+type InputRawUDPAttrs struct {
+	BindAddress         string `json:"bind_address,omitempty" v-create:"required" v-update:"required"`
+	Port                int    `json:"port,omitempty" v-create:"required" v-update:"required"`
+	RecvBufferSize      int    `json:"recv_buffer_size,omitempty" v-create:"required" v-update:"required"`
+	NumberWorkerThreads int    `json:"number_worker_threads,omitempty"`
+	OverrideSource      string `json:"override_source,omitempty"`
+}
@@ -17,7 +17,21 @@ func (attrs InputSyslogTCPAttrs) InputType() string {
 
 // InputSyslogTCPAttrs represents SyslogTCP Input's attributes.
 type InputSyslogTCPAttrs struct {
-	Port           int    `json:"port,omitempty" v-create:"required" v-update:"required"`
-	BindAddress    string `json:"bind_address,omitempty" v-create:"required" v-update:"required"`
-	RecvBufferSize int    `json:"recv_buffer_size,omitempty" v-create:"required" v-update:"required"`
+	Port                  int    `json:"port,omitempty" v-create:"required" v-update:"required"`
+	BindAddress           string `json:"bind_address,omitempty" v-create:"required" v-update:"required"`
+	RecvBufferSize        int    `json:"recv_buffer_size,omitempty" v-create:"required" v-update:"required"`
+	MaxMessageSize        int    `json:"max_message_size,omitempty"`
+	OverrideSource        string `json:"override_source,omitempty"`
+	TLSCertFile           string `json:"tls_cert_file,omitempty"`
+	TLSKeyFile            string `json:"tls_key_file,omitempty"`
+	TLSKeyPassword        string `json:"tls_key_password,omitempty"`
+	TLSClientAuth         string `json:"tls_client_auth,omitempty"`
+	TLSClientAuthCertFile string `json:"tls_client_auth_cert_file,omitempty"`
+	TLSEnable             bool   `json:"tls_enable"`
+	TCPKeepAlive          bool   `json:"tcp_keepalive"`
+	UseNullDelimiter      bool   `json:"use_null_delimiter"`
+	AllowOverrideDate     bool   `json:"allow_override_date"`
+	ExpandStructuredData  bool   `json:"expand_structured_data"`
+	ForceRDNS             bool   `json:"force_rdns"`
+	StoreFullMessage      bool   `json:"store_full_message"`
 }
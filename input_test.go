@@ -30,3 +30,40 @@ func TestInputNewUpdateParams(t *testing.T) {
 		t.Fatalf(`prms.ID = "%s", wanted "%s"`, prms.ID, input.ID)
 	}
 }
+
+func TestInputMarshalJSONUnknownAttrs(t *testing.T) {
+	input := &graylog.Input{}
+	d := &graylog.InputData{
+		Title: "foo",
+		Type:  "com.example.UnknownInput",
+		Attrs: map[string]interface{}{"port": float64(514)},
+	}
+	if err := d.ToInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if input.Type() != d.Type {
+		t.Fatalf("input.Type() = %s, wanted %s", input.Type(), d.Type)
+	}
+	b, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &graylog.Input{}
+	if err := json.Unmarshal(b, a); err != nil {
+		t.Fatal(err)
+	}
+	attrs, ok := a.Attrs.(*graylog.InputUnknownAttrs)
+	if !ok {
+		t.Fatalf("a.Attrs is %T, wanted *graylog.InputUnknownAttrs", a.Attrs)
+	}
+	if attrs.Data["port"] != float64(514) {
+		t.Fatalf(`attrs.Data["port"] = %v, wanted 514`, attrs.Data["port"])
+	}
+	b, err = json.Marshal(attrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"port":514}` {
+		t.Fatalf(`json.Marshal(attrs) = %s, wanted {"port":514}`, string(b))
+	}
+}
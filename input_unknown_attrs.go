@@ -1,5 +1,9 @@
 package graylog
 
+import (
+	"encoding/json"
+)
+
 // InputUnknownAttrs represents unknown type's Input Attrs.
 type InputUnknownAttrs struct {
 	inputType string
@@ -10,3 +14,12 @@ type InputUnknownAttrs struct {
 func (attrs InputUnknownAttrs) InputType() string {
 	return attrs.inputType
 }
+
+// MarshalJSON is the implementation of the json.Marshaler interface.
+// Only Data is encoded because Data is the attributes.
+func (attrs InputUnknownAttrs) MarshalJSON() ([]byte, error) {
+	if attrs.Data == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(attrs.Data)
+}
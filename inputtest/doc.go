@@ -0,0 +1,18 @@
+/*
+Package inputtest provides an in-process listener which stands in for a Graylog input.
+
+The listener receives GELF, syslog and raw messages in the same way as the input described by the input attributes,
+so tests can assert on exactly what would have reached Graylog.
+
+	l, err := inputtest.Listen(&graylog.InputGELFUDPAttrs{BindAddress: "127.0.0.1"})
+	defer l.Close()
+	// the attributes have the actual port
+	s, err := gelf.NewSenderFromInput(&graylog.Input{Attrs: l.Attrs()}, nil)
+	// send messages with the agent under test
+	msgs, err := l.Wait(ctx, 1)
+	// msgs[0].GELF.ShortMessage
+
+GELF UDP, TCP and HTTP inputs, syslog UDP and TCP inputs and raw UDP and TCP inputs are supported.
+TLS isn't supported.
+*/
+package inputtest
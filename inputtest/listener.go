@@ -0,0 +1,399 @@
+package inputtest
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/gelf"
+	"github.com/suzuki-shunsuke/go-graylog/syslog"
+)
+
+const (
+	protocolGELF   = "gelf"
+	protocolSyslog = "syslog"
+	protocolRaw    = "raw"
+)
+
+type (
+	// Message is a received message.
+	// One of GELF and Syslog is set according to the input type.
+	// Raw is the payload, which is decompressed and reassembled in case of GELF.
+	Message struct {
+		GELF       *gelf.Message
+		Syslog     *syslog.Message
+		Raw        []byte
+		RemoteAddr string
+	}
+
+	// Listener receives messages as a Graylog input.
+	Listener struct {
+		attrs    graylog.InputAttrs
+		protocol string
+		addr     net.Addr
+
+		maxMessageSize int
+		reassembler    *gelf.Reassembler
+
+		packetConn net.PacketConn
+		listener   net.Listener
+		server     *http.Server
+
+		mutex  sync.Mutex
+		msgs   []Message
+		errs   []error
+		notify chan struct{}
+		conns  map[net.Conn]struct{}
+		closed bool
+		wg     sync.WaitGroup
+	}
+
+	listenConfig struct {
+		network        string
+		protocol       string
+		bindAddress    string
+		port           int
+		recvBufferSize int
+		maxMessageSize int
+		tlsEnable      bool
+	}
+)
+
+// Listen starts a listener for the input attributes.
+// If the port is zero, a random port is used.
+// If the bind address is empty, "127.0.0.1" is used.
+func Listen(attrs graylog.InputAttrs) (*Listener, error) {
+	cfg, err := newListenConfig(attrs)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.tlsEnable {
+		return nil, fmt.Errorf("TLS isn't supported")
+	}
+	if cfg.bindAddress == "" {
+		cfg.bindAddress = "127.0.0.1"
+	}
+	l := &Listener{
+		attrs:          attrs,
+		protocol:       cfg.protocol,
+		maxMessageSize: cfg.maxMessageSize,
+		reassembler:    gelf.NewReassembler(),
+		notify:         make(chan struct{}),
+		conns:          map[net.Conn]struct{}{},
+	}
+	addr := net.JoinHostPort(cfg.bindAddress, strconv.Itoa(cfg.port))
+	switch cfg.network {
+	case "udp":
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.recvBufferSize > 0 {
+			if err := conn.(*net.UDPConn).SetReadBuffer(cfg.recvBufferSize); err != nil {
+				conn.Close()
+				return nil, err
+			}
+		}
+		l.packetConn = conn
+		l.addr = conn.LocalAddr()
+		l.wg.Add(1)
+		go l.serveUDP()
+	case "tcp", "http":
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		l.listener = ln
+		l.addr = ln.Addr()
+		if cfg.network == "http" {
+			l.server = &http.Server{Handler: http.HandlerFunc(l.serveHTTP)}
+			l.wg.Add(1)
+			go func() {
+				defer l.wg.Done()
+				_ = l.server.Serve(ln)
+			}()
+			break
+		}
+		l.wg.Add(1)
+		go l.serveTCP()
+	}
+	return l, nil
+}
+
+func newListenConfig(attrs graylog.InputAttrs) (*listenConfig, error) {
+	switch a := attrs.(type) {
+	case *graylog.InputGELFUDPAttrs:
+		return &listenConfig{
+			network: "udp", protocol: protocolGELF,
+			bindAddress: a.BindAddress, port: a.Port, recvBufferSize: a.RecvBufferSize,
+		}, nil
+	case *graylog.InputGELFTCPAttrs:
+		return &listenConfig{
+			network: "tcp", protocol: protocolGELF,
+			bindAddress: a.BindAddress, port: a.Port, recvBufferSize: a.RecvBufferSize,
+			maxMessageSize: a.MaxMessageSize, tlsEnable: a.TLSEnable,
+		}, nil
+	case *graylog.InputGELFHTTPAttrs:
+		return &listenConfig{
+			network: "http", protocol: protocolGELF,
+			bindAddress: a.BindAddress, port: a.Port, recvBufferSize: a.RecvBufferSize,
+			tlsEnable: a.TLSEnable,
+		}, nil
+	case *graylog.InputSyslogUDPAttrs:
+		return &listenConfig{
+			network: "udp", protocol: protocolSyslog,
+			bindAddress: a.BindAddress, port: a.Port, recvBufferSize: a.RecvBufferSize,
+		}, nil
+	case *graylog.InputSyslogTCPAttrs:
+		return &listenConfig{
+			network: "tcp", protocol: protocolSyslog,
+			bindAddress: a.BindAddress, port: a.Port, recvBufferSize: a.RecvBufferSize,
+			maxMessageSize: a.MaxMessageSize, tlsEnable: a.TLSEnable,
+		}, nil
+	case *graylog.InputRawUDPAttrs:
+		return &listenConfig{
+			network: "udp", protocol: protocolRaw,
+			bindAddress: a.BindAddress, port: a.Port, recvBufferSize: a.RecvBufferSize,
+		}, nil
+	case *graylog.InputRawTCPAttrs:
+		return &listenConfig{
+			network: "tcp", protocol: protocolRaw,
+			bindAddress: a.BindAddress, port: a.Port, recvBufferSize: a.RecvBufferSize,
+			maxMessageSize: a.MaxMessageSize, tlsEnable: a.TLSEnable,
+		}, nil
+	}
+	if attrs == nil {
+		return nil, errors.New("input attributes are nil")
+	}
+	return nil, fmt.Errorf("input type %s isn't supported", attrs.InputType())
+}
+
+// Addr returns the listener's address.
+func (l *Listener) Addr() net.Addr {
+	return l.addr
+}
+
+// Attrs returns a copy of the input attributes whose bind address and port are the listener's.
+func (l *Listener) Attrs() graylog.InputAttrs {
+	host, p, _ := net.SplitHostPort(l.addr.String())
+	port, _ := strconv.Atoi(p)
+	switch a := l.attrs.(type) {
+	case *graylog.InputGELFUDPAttrs:
+		cp := *a
+		cp.BindAddress, cp.Port = host, port
+		return &cp
+	case *graylog.InputGELFTCPAttrs:
+		cp := *a
+		cp.BindAddress, cp.Port = host, port
+		return &cp
+	case *graylog.InputGELFHTTPAttrs:
+		cp := *a
+		cp.BindAddress, cp.Port = host, port
+		return &cp
+	case *graylog.InputSyslogUDPAttrs:
+		cp := *a
+		cp.BindAddress, cp.Port = host, port
+		return &cp
+	case *graylog.InputSyslogTCPAttrs:
+		cp := *a
+		cp.BindAddress, cp.Port = host, port
+		return &cp
+	case *graylog.InputRawUDPAttrs:
+		cp := *a
+		cp.BindAddress, cp.Port = host, port
+		return &cp
+	case *graylog.InputRawTCPAttrs:
+		cp := *a
+		cp.BindAddress, cp.Port = host, port
+		return &cp
+	}
+	return l.attrs
+}
+
+// Messages returns the received messages.
+func (l *Listener) Messages() []Message {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([]Message{}, l.msgs...)
+}
+
+// Errors returns the errors which occurred when messages were decoded.
+func (l *Listener) Errors() []error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([]error{}, l.errs...)
+}
+
+// Wait waits until n messages are received and returns the received messages.
+func (l *Listener) Wait(ctx context.Context, n int) ([]Message, error) {
+	for {
+		l.mutex.Lock()
+		if len(l.msgs) >= n {
+			msgs := append([]Message{}, l.msgs...)
+			l.mutex.Unlock()
+			return msgs, nil
+		}
+		notify := l.notify
+		l.mutex.Unlock()
+		select {
+		case <-ctx.Done():
+			return l.Messages(), ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// Close stops the listener and waits for the goroutines.
+func (l *Listener) Close() error {
+	l.mutex.Lock()
+	if l.closed {
+		l.mutex.Unlock()
+		return nil
+	}
+	l.closed = true
+	for conn := range l.conns {
+		conn.Close()
+	}
+	l.mutex.Unlock()
+	var err error
+	switch {
+	case l.packetConn != nil:
+		err = l.packetConn.Close()
+	case l.server != nil:
+		err = l.server.Close()
+	case l.listener != nil:
+		err = l.listener.Close()
+	}
+	l.wg.Wait()
+	return err
+}
+
+func (l *Listener) addError(err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.errs = append(l.errs, err)
+}
+
+// receive decodes a payload and records the message.
+func (l *Listener) receive(b []byte, remoteAddr string) {
+	msg := Message{Raw: append([]byte{}, b...), RemoteAddr: remoteAddr}
+	switch l.protocol {
+	case protocolGELF:
+		raw, err := gelf.Decompress(b)
+		if err != nil {
+			l.addError(fmt.Errorf("failed to decompress a GELF message: %v", err))
+			return
+		}
+		m, err := gelf.Decode(raw)
+		if err != nil {
+			l.addError(err)
+			return
+		}
+		msg.GELF = m
+		msg.Raw = raw
+	case protocolSyslog:
+		m, _, err := syslog.Parse(b)
+		if err != nil {
+			l.addError(fmt.Errorf("failed to parse a syslog message: %v", err))
+			return
+		}
+		msg.Syslog = m
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.msgs = append(l.msgs, msg)
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+func (l *Listener) serveUDP() {
+	defer l.wg.Done()
+	buf := make([]byte, 65536)
+	for {
+		n, addr, err := l.packetConn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		b := buf[:n]
+		if l.protocol == protocolGELF {
+			b, err = l.reassembler.Add(b)
+			if err != nil {
+				l.addError(err)
+				continue
+			}
+			if b == nil {
+				continue
+			}
+		}
+		l.receive(b, addr.String())
+	}
+}
+
+func (l *Listener) serveTCP() {
+	defer l.wg.Done()
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			return
+		}
+		l.mutex.Lock()
+		if l.closed {
+			l.mutex.Unlock()
+			conn.Close()
+			return
+		}
+		l.conns[conn] = struct{}{}
+		l.mutex.Unlock()
+		l.wg.Add(1)
+		go l.serveConn(conn)
+	}
+}
+
+func (l *Listener) serveConn(conn net.Conn) {
+	defer l.wg.Done()
+	defer func() {
+		l.mutex.Lock()
+		delete(l.conns, conn)
+		l.mutex.Unlock()
+		conn.Close()
+	}()
+	var scanner *bufio.Scanner
+	if l.protocol == protocolGELF {
+		scanner = gelf.NewTCPScanner(conn, l.maxMessageSize)
+	} else {
+		scanner = syslog.NewScanner(conn, l.maxMessageSize)
+	}
+	for scanner.Scan() {
+		l.receive(scanner.Bytes(), conn.RemoteAddr().String())
+	}
+	if err := scanner.Err(); err != nil {
+		l.mutex.Lock()
+		closed := l.closed
+		l.mutex.Unlock()
+		if !closed {
+			l.addError(err)
+		}
+	}
+}
+
+func (l *Listener) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost || req.URL.Path != "/gelf" {
+		http.NotFound(w, req)
+		return
+	}
+	b, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		l.addError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	l.receive(b, req.RemoteAddr)
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,126 @@
+package inputtest_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/gelf"
+	"github.com/suzuki-shunsuke/go-graylog/inputtest"
+	"github.com/suzuki-shunsuke/go-graylog/syslog"
+)
+
+func waitMessages(t *testing.T, l *inputtest.Listener, n int) []inputtest.Message {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	msgs, err := l.Wait(ctx, n)
+	require.Nil(t, err, l.Errors())
+	return msgs
+}
+
+func TestListen_GELF(t *testing.T) {
+	compression := gelf.CompressionZlib
+	for _, attrs := range []graylog.InputAttrs{
+		&graylog.InputGELFUDPAttrs{RecvBufferSize: 262144},
+		&graylog.InputGELFTCPAttrs{},
+		&graylog.InputGELFHTTPAttrs{},
+	} {
+		l, err := inputtest.Listen(attrs)
+		require.Nil(t, err)
+		s, err := gelf.NewSenderFromInput(&graylog.Input{Attrs: l.Attrs()}, &gelf.InputOptions{Compression: &compression})
+		require.Nil(t, err)
+		w := gelf.NewWriter(s)
+		require.Nil(t, w.WriteMessage(&gelf.Message{ShortMessage: "hello", Extra: map[string]interface{}{"env": "test"}}))
+		// a large message which is chunked over UDP
+		long := strings.Repeat("0123456789", 1000)
+		require.Nil(t, w.WriteMessage(&gelf.Message{ShortMessage: "long", FullMessage: long}))
+		msgs := waitMessages(t, l, 2)
+		require.Equal(t, "hello", msgs[0].GELF.ShortMessage, attrs.InputType())
+		require.Equal(t, "test", msgs[0].GELF.Extra["env"])
+		require.Equal(t, long, msgs[1].GELF.FullMessage)
+		require.Nil(t, w.Close())
+		require.Nil(t, l.Close())
+	}
+}
+
+func TestListen_Syslog(t *testing.T) {
+	for _, attrs := range []graylog.InputAttrs{
+		&graylog.InputSyslogUDPAttrs{},
+		&graylog.InputSyslogTCPAttrs{},
+		&graylog.InputSyslogTCPAttrs{UseNullDelimiter: true},
+	} {
+		l, err := inputtest.Listen(attrs)
+		require.Nil(t, err)
+		for _, opts := range []*syslog.InputOptions{
+			{Format: syslog.RFC5424, OctetCounting: true},
+			{Format: syslog.RFC3164},
+		} {
+			s, err := syslog.NewSenderFromInput(&graylog.Input{Attrs: l.Attrs()}, opts)
+			require.Nil(t, err)
+			require.Nil(t, s.Send(&syslog.Message{
+				Facility: syslog.FacilityLocal0, Severity: syslog.SeverityWarning,
+				AppName: "app", Message: fmt.Sprintf("format %d", opts.Format),
+			}))
+			require.Nil(t, s.Close())
+		}
+		// messages sent over different connections may arrive in any order
+		msgs := waitMessages(t, l, 2)
+		bodies := make([]string, len(msgs))
+		for i, msg := range msgs {
+			require.Equal(t, "app", msg.Syslog.AppName)
+			require.Equal(t, syslog.SeverityWarning, msg.Syslog.Severity)
+			bodies[i] = msg.Syslog.Message
+		}
+		require.ElementsMatch(t, []string{"format 0", "format 1"}, bodies)
+		require.Nil(t, l.Close())
+	}
+}
+
+func TestListen_Raw(t *testing.T) {
+	for _, attrs := range []graylog.InputAttrs{
+		&graylog.InputRawUDPAttrs{},
+		&graylog.InputRawTCPAttrs{MaxMessageSize: 10},
+	} {
+		l, err := inputtest.Listen(attrs)
+		require.Nil(t, err)
+		s, err := syslog.NewRawSenderFromInput(&graylog.Input{Attrs: l.Attrs()}, nil)
+		require.Nil(t, err)
+		_, err = s.Write([]byte("foo\n"))
+		require.Nil(t, err)
+		_, err = s.Write([]byte("bar"))
+		require.Nil(t, err)
+		msgs := waitMessages(t, l, 2)
+		require.Equal(t, "foo", string(msgs[0].Raw))
+		require.Equal(t, "bar", string(msgs[1].Raw))
+		require.Nil(t, s.Close())
+		require.Nil(t, l.Close())
+	}
+}
+
+func TestListen_invalid(t *testing.T) {
+	_, err := inputtest.Listen(&graylog.InputBeatsAttrs{})
+	require.NotNil(t, err)
+	_, err = inputtest.Listen(&graylog.InputGELFTCPAttrs{TLSEnable: true})
+	require.NotNil(t, err)
+
+	l, err := inputtest.Listen(&graylog.InputSyslogUDPAttrs{})
+	require.Nil(t, err)
+	defer l.Close()
+	a := l.Attrs().(*graylog.InputSyslogUDPAttrs)
+	s, err := syslog.NewRawSenderFromInput(&graylog.Input{Attrs: &graylog.InputRawUDPAttrs{
+		BindAddress: a.BindAddress, Port: a.Port,
+	}}, nil)
+	require.Nil(t, err)
+	defer s.Close()
+	_, err = s.Write([]byte("not syslog"))
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		return len(l.Errors()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, l.Messages())
+}
@@ -0,0 +1,310 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+const (
+	// RulePipelineUnknownRule flags pipelines whose stages refer non-existent pipeline rules.
+	RulePipelineUnknownRule = "pipeline-unknown-rule"
+	// RuleConnectionUnknownStream flags pipeline connections of non-existent streams.
+	RuleConnectionUnknownStream = "pipeline-connection-unknown-stream"
+	// RuleConnectionUnknownPipeline flags pipeline connections which refer non-existent pipelines.
+	RuleConnectionUnknownPipeline = "pipeline-connection-unknown-pipeline"
+	// RuleStreamNoRules flags streams which have no stream rule.
+	// The default stream is ignored.
+	RuleStreamNoRules = "stream-no-rules"
+	// RuleStreamUnknownIndexSet flags streams whose index set doesn't exist.
+	RuleStreamUnknownIndexSet = "stream-unknown-index-set"
+	// RuleGrokPatternUnused flags grok patterns which are referred by nothing.
+	// Only the grok patterns whose names start with Options.GrokPatternPrefix are checked.
+	RuleGrokPatternUnused = "grok-pattern-unused"
+	// RuleExtractorUnknownGrokPattern flags grok extractors which use non-existent grok patterns.
+	RuleExtractorUnknownGrokPattern = "extractor-unknown-grok-pattern"
+	// RuleAlarmCallbackNoAlertCondition flags alarm callbacks of streams which have no alert condition.
+	RuleAlarmCallbackNoAlertCondition = "alarm-callback-no-alert-condition"
+	// RuleRoleUnknownTarget flags roles which grant permissions on non-existent streams or dashboards.
+	RuleRoleUnknownTarget = "role-unknown-permission-target"
+)
+
+type (
+	// Options configures the linter.
+	Options struct {
+		// GrokPatternPrefix is the prefix of the names of the grok patterns checked by RuleGrokPatternUnused.
+		// Graylog has about 100 built-in grok patterns and most of them are unused,
+		// so RuleGrokPatternUnused is skipped if GrokPatternPrefix is empty.
+		GrokPatternPrefix string
+	}
+
+	// Issue is a problem found by the linter.
+	Issue struct {
+		Rule string `json:"rule"`
+		// Kind is the kind of the resource such as "stream".
+		Kind    string `json:"kind"`
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Message string `json:"message"`
+	}
+)
+
+// String returns an issue's string expression.
+func (issue Issue) String() string {
+	return fmt.Sprintf("%s: %s %q (%s): %s", issue.Rule, issue.Kind, issue.Name, issue.ID, issue.Message)
+}
+
+// grokRefPattern matches references of grok patterns such as %{IP:client}.
+var grokRefPattern = regexp.MustCompile(`%\{([A-Za-z0-9_]+)(?::[^}]*)?\}`)
+
+func grokRefs(s string) []string {
+	matches := grokRefPattern.FindAllStringSubmatch(s, -1)
+	refs := make([]string, len(matches))
+	for i, m := range matches {
+		refs[i] = m[1]
+	}
+	return refs
+}
+
+// extractorGrokPattern returns the grok pattern of a grok extractor.
+// The extractor config may be a map (ex. decoded from JSON) or a struct.
+func extractorGrokPattern(extractor graylog.Extractor) string {
+	if extractor.Type != "grok" || extractor.ExtractorConfig == nil {
+		return ""
+	}
+	b, err := json.Marshal(extractor.ExtractorConfig)
+	if err != nil {
+		return ""
+	}
+	cfg := graylog.ExtractorTypeGrokConfig{}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return ""
+	}
+	return cfg.GrokPattern
+}
+
+// Lint checks a snapshot and returns found issues.
+// opts can be nil.
+func Lint(s *Snapshot, opts *Options) []Issue {
+	if opts == nil {
+		opts = &Options{}
+	}
+	issues := []Issue{}
+	for _, f := range []func(*Snapshot) []Issue{
+		lintPipelines,
+		lintPipelineConnections,
+		lintStreams,
+		func(s *Snapshot) []Issue {
+			return lintGrokPatterns(s, opts.GrokPatternPrefix)
+		},
+		lintAlarmCallbacks,
+		lintRoles,
+	} {
+		issues = append(issues, f(s)...)
+	}
+	return issues
+}
+
+func lintPipelines(s *Snapshot) []Issue {
+	rules := make(map[string]bool, len(s.PipelineRules))
+	for _, rule := range s.PipelineRules {
+		rules[rule.Title] = true
+	}
+	var issues []Issue
+	for _, pipeline := range s.Pipelines {
+		for _, stage := range pipeline.Stages {
+			for _, rule := range stage.Rules {
+				if rules[rule] {
+					continue
+				}
+				issues = append(issues, Issue{
+					Rule: RulePipelineUnknownRule, Kind: "pipeline",
+					ID: pipeline.ID, Name: pipeline.Title,
+					Message: fmt.Sprintf("the stage %d refers an unknown rule %q", stage.Stage, rule),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func lintPipelineConnections(s *Snapshot) []Issue {
+	streams := make(map[string]bool, len(s.Streams))
+	for _, stream := range s.Streams {
+		streams[stream.ID] = true
+	}
+	pipelines := make(map[string]bool, len(s.Pipelines))
+	for _, pipeline := range s.Pipelines {
+		pipelines[pipeline.ID] = true
+	}
+	var issues []Issue
+	for _, conn := range s.PipelineConnections {
+		if !streams[conn.StreamID] {
+			issues = append(issues, Issue{
+				Rule: RuleConnectionUnknownStream, Kind: "pipeline_connection",
+				ID: conn.ID, Name: conn.StreamID,
+				Message: fmt.Sprintf("the stream %s doesn't exist", conn.StreamID),
+			})
+		}
+		for _, id := range conn.PipelineIDs {
+			if pipelines[id] {
+				continue
+			}
+			issues = append(issues, Issue{
+				Rule: RuleConnectionUnknownPipeline, Kind: "pipeline_connection",
+				ID: conn.ID, Name: conn.StreamID,
+				Message: fmt.Sprintf("the pipeline %s doesn't exist", id),
+			})
+		}
+	}
+	return issues
+}
+
+func lintStreams(s *Snapshot) []Issue {
+	indexSets := make(map[string]bool, len(s.IndexSets))
+	for _, is := range s.IndexSets {
+		indexSets[is.ID] = true
+	}
+	var issues []Issue
+	for _, stream := range s.Streams {
+		if stream.IsDefault {
+			continue
+		}
+		if len(stream.Rules) == 0 {
+			issues = append(issues, Issue{
+				Rule: RuleStreamNoRules, Kind: "stream", ID: stream.ID, Name: stream.Title,
+				Message: "the stream has no stream rule, so no message is routed to the stream",
+			})
+		}
+		if !indexSets[stream.IndexSetID] {
+			issues = append(issues, Issue{
+				Rule: RuleStreamUnknownIndexSet, Kind: "stream", ID: stream.ID, Name: stream.Title,
+				Message: fmt.Sprintf("the index set %s doesn't exist", stream.IndexSetID),
+			})
+		}
+	}
+	return issues
+}
+
+func lintGrokPatterns(s *Snapshot, prefix string) []Issue {
+	patterns := make(map[string]bool, len(s.GrokPatterns))
+	for _, p := range s.GrokPatterns {
+		patterns[p.Name] = true
+	}
+	used := map[string]bool{}
+	for _, p := range s.GrokPatterns {
+		for _, ref := range grokRefs(p.Pattern) {
+			if ref != p.Name {
+				used[ref] = true
+			}
+		}
+	}
+	for _, rule := range s.PipelineRules {
+		for _, ref := range grokRefs(rule.Source) {
+			used[ref] = true
+		}
+	}
+
+	var issues []Issue
+	inputIDs := make([]string, 0, len(s.Extractors))
+	for id := range s.Extractors {
+		inputIDs = append(inputIDs, id)
+	}
+	sort.Strings(inputIDs)
+	for _, inputID := range inputIDs {
+		for _, extractor := range s.Extractors[inputID] {
+			for _, ref := range grokRefs(extractorGrokPattern(extractor)) {
+				used[ref] = true
+				if patterns[ref] {
+					continue
+				}
+				issues = append(issues, Issue{
+					Rule: RuleExtractorUnknownGrokPattern, Kind: "extractor",
+					ID: extractor.ID, Name: extractor.Title,
+					Message: fmt.Sprintf(
+						"the extractor of the input %s uses an unknown grok pattern %s", inputID, ref),
+				})
+			}
+		}
+	}
+
+	if prefix == "" {
+		return issues
+	}
+	for _, p := range s.GrokPatterns {
+		if used[p.Name] || !strings.HasPrefix(p.Name, prefix) {
+			continue
+		}
+		issues = append(issues, Issue{
+			Rule: RuleGrokPatternUnused, Kind: "grok_pattern", ID: p.ID, Name: p.Name,
+			Message: "the grok pattern is referred by no extractor, grok pattern and pipeline rule",
+		})
+	}
+	return issues
+}
+
+func lintAlarmCallbacks(s *Snapshot) []Issue {
+	streams := make(map[string]*graylog.Stream, len(s.Streams))
+	for i, stream := range s.Streams {
+		streams[stream.ID] = &s.Streams[i]
+	}
+	var issues []Issue
+	for _, ac := range s.AlarmCallbacks {
+		stream, ok := streams[ac.StreamID]
+		if ok && len(stream.AlertConditions) != 0 {
+			continue
+		}
+		msg := fmt.Sprintf("the stream %s doesn't exist", ac.StreamID)
+		if ok {
+			msg = fmt.Sprintf("the stream %s has no alert condition, so the alarm callback is never called", stream.Title)
+		}
+		issues = append(issues, Issue{
+			Rule: RuleAlarmCallbackNoAlertCondition, Kind: "alarm_callback",
+			ID: ac.ID, Name: ac.Title, Message: msg,
+		})
+	}
+	return issues
+}
+
+func lintRoles(s *Snapshot) []Issue {
+	ids := map[string]map[string]bool{
+		"streams":    make(map[string]bool, len(s.Streams)),
+		"dashboards": make(map[string]bool, len(s.Dashboards)),
+	}
+	for _, stream := range s.Streams {
+		ids["streams"][stream.ID] = true
+	}
+	for _, dashboard := range s.Dashboards {
+		ids["dashboards"][dashboard.ID] = true
+	}
+	var issues []Issue
+	for _, role := range s.Roles {
+		perms := role.Permissions.ToList()
+		sort.Strings(perms)
+		for _, perm := range perms {
+			// ex. streams:read:<stream id>,<stream id>
+			a := strings.Split(perm, ":")
+			if len(a) != 3 || a[2] == "*" {
+				continue
+			}
+			targets, ok := ids[a[0]]
+			if !ok {
+				continue
+			}
+			for _, id := range strings.Split(a[2], ",") {
+				if targets[id] {
+					continue
+				}
+				issues = append(issues, Issue{
+					Rule: RuleRoleUnknownTarget, Kind: "role", ID: role.Name, Name: role.Name,
+					Message: fmt.Sprintf("the permission %s refers a non-existent %s %s", perm, a[0], id),
+				})
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,107 @@
+package lint_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/go-set"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/lint"
+)
+
+func testSnapshot() *lint.Snapshot {
+	return &lint.Snapshot{
+		IndexSets: []graylog.IndexSet{{ID: "is"}},
+		Streams: []graylog.Stream{
+			{ID: "default", Title: "All messages", IndexSetID: "is", IsDefault: true},
+			{
+				ID: "s1", Title: "app", IndexSetID: "is",
+				Rules:           []graylog.StreamRule{{ID: "r1", Field: "tag", Value: "app"}},
+				AlertConditions: []graylog.AlertCondition{{ID: "ac1", Title: "errors"}},
+			},
+			{ID: "s2", Title: "empty", IndexSetID: "deleted"},
+		},
+		AlarmCallbacks: []graylog.AlarmCallback{
+			{ID: "cb1", StreamID: "s1", Title: "ok"},
+			{ID: "cb2", StreamID: "s2", Title: "never"},
+		},
+		Extractors: map[string][]graylog.Extractor{
+			"input": {
+				{
+					ID: "e1", Title: "grok", Type: "grok",
+					ExtractorConfig: map[string]interface{}{"grok_pattern": "%{IP:client} %{UNKNOWN}"},
+				},
+			},
+		},
+		GrokPatterns: []graylog.GrokPattern{
+			{ID: "g1", Name: "IP", Pattern: "%{IPV4}"},
+			{ID: "g2", Name: "IPV4", Pattern: "[0-9.]+"},
+			{ID: "g3", Name: "WORD", Pattern: `\b\w+\b`},
+			{ID: "g4", Name: "UNUSED", Pattern: `.*`},
+		},
+		Pipelines: []graylog.Pipeline{
+			{
+				ID: "p1", Title: "main",
+				Stages: []graylog.PipelineStage{{Stage: 0, Rules: []string{"parse", "deleted"}}},
+			},
+		},
+		PipelineRules: []graylog.PipelineRule{
+			{ID: "pr1", Title: "parse", Source: `rule "parse" when true then set_fields(grok("%{WORD:w}", to_string($message.message))); end`},
+		},
+		PipelineConnections: []graylog.PipelineConnection{
+			{ID: "c1", StreamID: "s1", PipelineIDs: []string{"p1"}},
+			{ID: "c2", StreamID: "deleted", PipelineIDs: []string{"p2"}},
+		},
+		Dashboards: []graylog.Dashboard{{ID: "d1", Title: "dashboard"}},
+		Roles: []graylog.Role{
+			{
+				Name: "reader",
+				Permissions: set.NewStrSet(
+					"streams:read:s1,deleted", "streams:read:*", "dashboards:read:d1", "dashboards:read:d2"),
+			},
+		},
+	}
+}
+
+func TestLint(t *testing.T) {
+	issues := lint.Lint(testSnapshot(), &lint.Options{GrokPatternPrefix: "UN"})
+	type key struct {
+		rule string
+		id   string
+	}
+	keys := make([]key, len(issues))
+	for i, issue := range issues {
+		keys[i] = key{issue.Rule, issue.ID}
+	}
+	require.Equal(t, []key{
+		{lint.RulePipelineUnknownRule, "p1"},
+		{lint.RuleConnectionUnknownStream, "c2"},
+		{lint.RuleConnectionUnknownPipeline, "c2"},
+		{lint.RuleStreamNoRules, "s2"},
+		{lint.RuleStreamUnknownIndexSet, "s2"},
+		{lint.RuleExtractorUnknownGrokPattern, "e1"},
+		{lint.RuleGrokPatternUnused, "g4"},
+		{lint.RuleAlarmCallbackNoAlertCondition, "cb2"},
+		{lint.RuleRoleUnknownTarget, "reader"},
+		{lint.RuleRoleUnknownTarget, "reader"},
+	}, keys)
+}
+
+func TestLint_GrokPatternPrefix(t *testing.T) {
+	for _, issue := range lint.Lint(testSnapshot(), nil) {
+		require.NotEqual(t, lint.RuleGrokPatternUnused, issue.Rule)
+	}
+	for _, issue := range lint.Lint(testSnapshot(), &lint.Options{GrokPatternPrefix: "APP_"}) {
+		require.NotEqual(t, lint.RuleGrokPatternUnused, issue.Rule)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.Nil(t, testSnapshot().Save(buf))
+	s, err := lint.LoadSnapshot(buf)
+	require.Nil(t, err)
+	require.Equal(t, lint.Lint(testSnapshot(), nil), lint.Lint(s, nil))
+}
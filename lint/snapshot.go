@@ -0,0 +1,99 @@
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+// Snapshot is a set of resources fetched from a Graylog cluster.
+// Snapshot can be saved as JSON and linted offline.
+type Snapshot struct {
+	IndexSets           []graylog.IndexSet             `json:"index_sets"`
+	Streams             []graylog.Stream               `json:"streams"`
+	AlarmCallbacks      []graylog.AlarmCallback        `json:"alarm_callbacks"`
+	Inputs              []graylog.Input                `json:"inputs"`
+	Extractors          map[string][]graylog.Extractor `json:"extractors"`
+	GrokPatterns        []graylog.GrokPattern          `json:"grok_patterns"`
+	Pipelines           []graylog.Pipeline             `json:"pipelines"`
+	PipelineRules       []graylog.PipelineRule         `json:"pipeline_rules"`
+	PipelineConnections []graylog.PipelineConnection   `json:"pipeline_connections"`
+	Dashboards          []graylog.Dashboard            `json:"dashboards"`
+	Roles               []graylog.Role                 `json:"roles"`
+}
+
+// Fetch reads resources from a Graylog cluster and returns the snapshot.
+// Extractors are keyed by the input ID.
+func Fetch(ctx context.Context, cl *client.Client) (*Snapshot, error) {
+	s := &Snapshot{Extractors: map[string][]graylog.Extractor{}}
+	var err error
+	s.IndexSets, _, _, _, err = cl.GetIndexSets(ctx, 0, 0, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get index sets")
+	}
+	s.Streams, _, _, err = cl.GetStreams(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get streams")
+	}
+	s.AlarmCallbacks, _, _, err = cl.GetAlarmCallbacks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get alarm callbacks")
+	}
+	s.Inputs, _, _, err = cl.GetInputs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get inputs")
+	}
+	for _, input := range s.Inputs {
+		extractors, _, _, err := cl.GetExtractors(ctx, input.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get extractors of the input %s", input.Title)
+		}
+		s.Extractors[input.ID] = extractors
+	}
+	s.GrokPatterns, _, err = cl.GetGrokPatterns(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get grok patterns")
+	}
+	s.Pipelines, _, err = cl.GetPipelines(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pipelines")
+	}
+	s.PipelineRules, _, err = cl.GetPipelineRules(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pipeline rules")
+	}
+	s.PipelineConnections, _, err = cl.GetPipelineConnections(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pipeline connections")
+	}
+	s.Dashboards, _, _, err = cl.GetDashboards(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get dashboards")
+	}
+	s.Roles, _, _, err = cl.GetRoles(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get roles")
+	}
+	return s, nil
+}
+
+// LoadSnapshot reads a snapshot saved by Save.
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	s := &Snapshot{}
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, errors.Wrap(err, "failed to decode a snapshot")
+	}
+	return s, nil
+}
+
+// Save writes a snapshot as JSON.
+func (s *Snapshot) Save(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
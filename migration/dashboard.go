@@ -0,0 +1,138 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func findDashboard(dashboards []graylog.Dashboard, title string) (*graylog.Dashboard, int) {
+	var found *graylog.Dashboard
+	n := 0
+	for i, d := range dashboards {
+		if d.Title == title {
+			found = &dashboards[i]
+			n++
+		}
+	}
+	return found, n
+}
+
+// migrateDashboardsOfStream migrates dashboards which have widgets referring a given stream.
+func (m *Migrator) migrateDashboardsOfStream(ctx context.Context, streamID string) error {
+	dashboards, _, _, err := m.src.GetDashboards(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get dashboards from the source cluster")
+	}
+	for _, dashboard := range dashboards {
+		for i := range dashboard.Widgets {
+			if id, _ := widgetStreamID(&dashboard.Widgets[i]); id != streamID {
+				continue
+			}
+			if _, err := m.MigrateDashboard(ctx, dashboard.ID); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// MigrateDashboard copies a dashboard and its widgets to the destination cluster
+// and returns the destination dashboard's ID.
+// Dashboards are matched by the title.
+// Streams which widgets refer are migrated too.
+// When the dashboard is overwritten, the existing widgets are recreated.
+func (m *Migrator) MigrateDashboard(ctx context.Context, id string) (string, error) {
+	if dstID, ok := m.ids.Get(KindDashboard, id); ok {
+		return dstID, nil
+	}
+	src, _, err := m.src.GetDashboard(ctx, id)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get a dashboard %s from the source cluster", id)
+	}
+	dstDashboards, _, _, err := m.dst.GetDashboards(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get dashboards from the destination cluster")
+	}
+	existing, n := findDashboard(dstDashboards, src.Title)
+	if n > 1 {
+		return "", ambiguousError(KindDashboard, src.Title, n)
+	}
+	action, dstTitle, err := m.resolve(KindDashboard, src.Title, existing != nil)
+	if err != nil {
+		return "", err
+	}
+	if action == ActionSkip {
+		m.record(KindDashboard, src.Title, dstTitle, action, id, existing.ID)
+		return existing.ID, nil
+	}
+	dashboard := &graylog.Dashboard{
+		Title:       dstTitle,
+		Description: src.Description,
+	}
+	if action == ActionUpdate {
+		dashboard.ID = existing.ID
+		if !m.cfg.DryRun {
+			if _, err := m.dst.UpdateDashboard(ctx, dashboard); err != nil {
+				return "", errors.Wrapf(err, "failed to update a dashboard %s", src.Title)
+			}
+		}
+	} else {
+		if m.cfg.DryRun {
+			dashboard.ID = dryRunID(id)
+		} else if _, err := m.dst.CreateDashboard(ctx, dashboard); err != nil {
+			return "", errors.Wrapf(err, "failed to create a dashboard %s", dstTitle)
+		}
+	}
+	// record the dashboard before the widgets are migrated,
+	// because streams which widgets refer may refer this dashboard again.
+	m.record(KindDashboard, src.Title, dstTitle, action, id, dashboard.ID)
+
+	if action == ActionUpdate {
+		for _, widget := range existing.Widgets {
+			if !m.cfg.DryRun {
+				if _, err := m.dst.DeleteDashboardWidget(ctx, dashboard.ID, widget.ID); err != nil {
+					return "", errors.Wrapf(err, "failed to delete a widget %s", widget.Description)
+				}
+			}
+			m.addOperation(KindWidget, widget.Description, widget.Description, ActionDelete, "", widget.ID)
+		}
+	}
+	for _, srcWidget := range src.Widgets {
+		widget := srcWidget
+		if streamID, _ := widgetStreamID(&widget); streamID != "" {
+			if _, err := m.MigrateStream(ctx, streamID); err != nil {
+				return "", err
+			}
+		}
+		m.warnMissing(KindWidget, widget.Description, m.ids.RemapWidget(&widget))
+		widget.CreatorUserID = ""
+		if m.cfg.DryRun {
+			widget.ID = dryRunID(srcWidget.ID)
+		} else {
+			widget.ID = ""
+			widget, _, err = m.dst.CreateDashboardWidget(ctx, dashboard.ID, widget)
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to create a widget %s", widget.Description)
+			}
+		}
+		m.record(KindWidget, widget.Description, widget.Description, ActionCreate, srcWidget.ID, widget.ID)
+	}
+
+	positions := []graylog.DashboardWidgetPosition{}
+	for _, pos := range src.Positions {
+		if widgetID, ok := m.ids.Get(KindWidget, pos.WidgetID); ok {
+			pos.WidgetID = widgetID
+			positions = append(positions, pos)
+		}
+	}
+	if len(positions) != 0 && !m.cfg.DryRun {
+		if _, err := m.dst.UpdateDashboardWidgetPositions(ctx, dashboard.ID, positions); err != nil {
+			return "", errors.Wrapf(err, "failed to update widget positions of a dashboard %s", dstTitle)
+		}
+	}
+	return dashboard.ID, nil
+}
@@ -0,0 +1,18 @@
+/*
+Package migration copies Graylog resources from a cluster to another cluster.
+
+Resources are matched by their natural keys (stream title, index set prefix, role name, grok pattern name and so on),
+and every ID reference is remapped to the destination cluster's ID.
+When a resource already exists in the destination cluster, it is skipped, overwritten or created with a new name
+according to the ConflictPolicy.
+
+	m := migration.NewMigrator(src, dst, &migration.Config{
+		Policy: migration.ConflictOverwrite,
+		DryRun: true,
+	})
+	if _, err := m.MigrateStream(ctx, streamID); err != nil {
+		return err
+	}
+	fmt.Println(m.Report())
+*/
+package migration
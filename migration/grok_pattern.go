@@ -0,0 +1,73 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func findGrokPattern(patterns []graylog.GrokPattern, name string) (*graylog.GrokPattern, int) {
+	var found *graylog.GrokPattern
+	n := 0
+	for i, p := range patterns {
+		if p.Name == name {
+			found = &patterns[i]
+			n++
+		}
+	}
+	return found, n
+}
+
+// MigrateGrokPattern copies a grok pattern to the destination cluster and returns the destination grok pattern's ID.
+// Grok patterns are matched by the name.
+func (m *Migrator) MigrateGrokPattern(ctx context.Context, name string) (string, error) {
+	srcPatterns, _, err := m.src.GetGrokPatterns(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get grok patterns from the source cluster")
+	}
+	src, n := findGrokPattern(srcPatterns, name)
+	if n == 0 {
+		return "", fmt.Errorf("grok pattern %q isn't found in the source cluster", name)
+	}
+	if dstID, ok := m.ids.Get(KindGrokPattern, src.ID); ok {
+		return dstID, nil
+	}
+	dstPatterns, _, err := m.dst.GetGrokPatterns(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get grok patterns from the destination cluster")
+	}
+	existing, n := findGrokPattern(dstPatterns, name)
+	if n > 1 {
+		return "", ambiguousError(KindGrokPattern, name, n)
+	}
+	action, dstName, err := m.resolve(KindGrokPattern, name, existing != nil)
+	if err != nil {
+		return "", err
+	}
+	pattern := *src
+	pattern.Name = dstName
+	switch action {
+	case ActionSkip:
+		m.record(KindGrokPattern, name, dstName, action, src.ID, existing.ID)
+		return existing.ID, nil
+	case ActionUpdate:
+		pattern.ID = existing.ID
+		if !m.cfg.DryRun {
+			if _, err := m.dst.UpdateGrokPattern(ctx, &pattern); err != nil {
+				return "", errors.Wrapf(err, "failed to update a grok pattern %s", name)
+			}
+		}
+	default:
+		pattern.ID = ""
+		if m.cfg.DryRun {
+			pattern.ID = dryRunID(src.ID)
+		} else if _, err := m.dst.CreateGrokPattern(ctx, &pattern); err != nil {
+			return "", errors.Wrapf(err, "failed to create a grok pattern %s", dstName)
+		}
+	}
+	m.record(KindGrokPattern, name, dstName, action, src.ID, pattern.ID)
+	return pattern.ID, nil
+}
@@ -0,0 +1,169 @@
+package migration
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+const (
+	// KindIndexSet is the kind of index sets.
+	KindIndexSet = "index_set"
+	// KindStream is the kind of streams.
+	KindStream = "stream"
+	// KindStreamRule is the kind of stream rules.
+	KindStreamRule = "stream_rule"
+	// KindAlertCondition is the kind of alert conditions.
+	KindAlertCondition = "alert_condition"
+	// KindAlarmCallback is the kind of alarm callbacks.
+	KindAlarmCallback = "alarm_callback"
+	// KindPipeline is the kind of pipelines.
+	KindPipeline = "pipeline"
+	// KindPipelineRule is the kind of pipeline rules.
+	KindPipelineRule = "pipeline_rule"
+	// KindPipelineConnection is the kind of pipeline connections.
+	KindPipelineConnection = "pipeline_connection"
+	// KindDashboard is the kind of dashboards.
+	KindDashboard = "dashboard"
+	// KindWidget is the kind of dashboard widgets.
+	KindWidget = "widget"
+	// KindRole is the kind of roles.
+	KindRole = "role"
+	// KindGrokPattern is the kind of grok patterns.
+	KindGrokPattern = "grok_pattern"
+)
+
+type (
+	// IDMap maps the source cluster's IDs to the destination cluster's IDs per kind.
+	IDMap map[string]map[string]string
+
+	// Reference is a reference to a resource.
+	Reference struct {
+		Kind string
+		ID   string
+	}
+)
+
+// String returns a reference's string expression "<kind>:<id>".
+func (ref Reference) String() string {
+	return ref.Kind + ":" + ref.ID
+}
+
+// Set sets a destination ID of a given kind's source ID.
+func (m IDMap) Set(kind, srcID, dstID string) {
+	ids, ok := m[kind]
+	if !ok {
+		ids = map[string]string{}
+		m[kind] = ids
+	}
+	ids[srcID] = dstID
+}
+
+// Get returns a destination ID of a given kind's source ID.
+func (m IDMap) Get(kind, srcID string) (string, bool) {
+	ids, ok := m[kind]
+	if !ok {
+		return "", false
+	}
+	id, ok := ids[srcID]
+	return id, ok
+}
+
+func (m IDMap) remap(kind string, id *string, missing []Reference) []Reference {
+	if *id == "" {
+		return missing
+	}
+	if dstID, ok := m.Get(kind, *id); ok {
+		*id = dstID
+		return missing
+	}
+	return append(missing, Reference{Kind: kind, ID: *id})
+}
+
+// RemapStream replaces the IDs which a stream refers with the destination cluster's IDs.
+// The stream's own ID isn't changed.
+// References which aren't found in the map are kept and returned.
+func (m IDMap) RemapStream(stream *graylog.Stream) []Reference {
+	missing := m.remap(KindIndexSet, &stream.IndexSetID, nil)
+	for i := range stream.Rules {
+		missing = m.remap(KindStream, &stream.Rules[i].StreamID, missing)
+	}
+	return missing
+}
+
+// widgetStreamID returns the stream id of a widget's config and the function to update it.
+// If the config doesn't have the stream id, the function is nil.
+func widgetStreamID(widget *graylog.Widget) (string, func(string)) {
+	if widget.Config == nil {
+		return "", nil
+	}
+	if cfg, ok := widget.Config.(*graylog.WidgetConfigUnknownType); ok {
+		id, ok := cfg.Fields["stream_id"].(string)
+		if !ok {
+			return "", nil
+		}
+		return id, func(id string) {
+			cfg.Fields["stream_id"] = id
+		}
+	}
+	v := reflect.ValueOf(widget.Config)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return "", nil
+	}
+	f := v.Elem().FieldByName("StreamID")
+	if !f.IsValid() || f.Kind() != reflect.String || !f.CanSet() {
+		return "", nil
+	}
+	return f.String(), f.SetString
+}
+
+// RemapWidget replaces the stream id of a widget's config with the destination cluster's ID.
+// References which aren't found in the map are kept and returned.
+func (m IDMap) RemapWidget(widget *graylog.Widget) []Reference {
+	id, set := widgetStreamID(widget)
+	if set == nil {
+		return nil
+	}
+	missing := m.remap(KindStream, &id, nil)
+	set(id)
+	return missing
+}
+
+// RemapPipelineConnection replaces the stream id and pipeline ids of a pipeline connection
+// with the destination cluster's IDs.
+// References which aren't found in the map are kept and returned.
+func (m IDMap) RemapPipelineConnection(conn *graylog.PipelineConnection) []Reference {
+	missing := m.remap(KindStream, &conn.StreamID, nil)
+	for i := range conn.PipelineIDs {
+		missing = m.remap(KindPipeline, &conn.PipelineIDs[i], missing)
+	}
+	return missing
+}
+
+// RemapPermissions replaces stream and dashboard IDs in role or user permissions
+// (ex. "streams:read:<stream id>") with the destination cluster's IDs.
+// References which aren't found in the map are kept and returned.
+func (m IDMap) RemapPermissions(permissions []string) ([]string, []Reference) {
+	kinds := map[string]string{
+		"streams":    KindStream,
+		"dashboards": KindDashboard,
+	}
+	var missing []Reference
+	ret := make([]string, len(permissions))
+	for i, perm := range permissions {
+		a := strings.Split(perm, ":")
+		kind, ok := kinds[a[0]]
+		if len(a) != 3 || !ok || a[2] == "*" {
+			ret[i] = perm
+			continue
+		}
+		ids := strings.Split(a[2], ",")
+		for j := range ids {
+			missing = m.remap(kind, &ids[j], missing)
+		}
+		a[2] = strings.Join(ids, ",")
+		ret[i] = strings.Join(a, ":")
+	}
+	return ret, missing
+}
@@ -0,0 +1,129 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func TestIDMap_RemapStream(t *testing.T) {
+	ids := IDMap{}
+	ids.Set(KindIndexSet, "src-is", "dst-is")
+	stream := &graylog.Stream{
+		ID:         "src-stream",
+		IndexSetID: "src-is",
+		Rules: []graylog.StreamRule{
+			{StreamID: "unknown-stream"},
+		},
+	}
+	missing := ids.RemapStream(stream)
+	require.Equal(t, "src-stream", stream.ID)
+	require.Equal(t, "dst-is", stream.IndexSetID)
+	require.Equal(t, []Reference{{Kind: KindStream, ID: "unknown-stream"}}, missing)
+}
+
+func TestIDMap_RemapWidget(t *testing.T) {
+	ids := IDMap{}
+	ids.Set(KindStream, "src-stream", "dst-stream")
+	data := []struct {
+		title   string
+		widget  *graylog.Widget
+		exp     string
+		missing []Reference
+	}{
+		{
+			title: "typed config",
+			widget: &graylog.Widget{
+				Config: &graylog.WidgetConfigStreamSearchResultCount{StreamID: "src-stream"},
+			},
+			exp: "dst-stream",
+		},
+		{
+			title: "unknown type config",
+			widget: &graylog.Widget{
+				Config: &graylog.WidgetConfigUnknownType{
+					T:      "CUSTOM",
+					Fields: map[string]interface{}{"stream_id": "src-stream"},
+				},
+			},
+			exp: "dst-stream",
+		},
+		{
+			title: "all messages",
+			widget: &graylog.Widget{
+				Config: &graylog.WidgetConfigFieldChart{},
+			},
+			exp: "",
+		},
+		{
+			title: "not migrated stream",
+			widget: &graylog.Widget{
+				Config: &graylog.WidgetConfigStatsCount{StreamID: "foo"},
+			},
+			exp:     "foo",
+			missing: []Reference{{Kind: KindStream, ID: "foo"}},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			missing := ids.RemapWidget(d.widget)
+			require.Equal(t, d.missing, missing)
+			id, _ := widgetStreamID(d.widget)
+			require.Equal(t, d.exp, id)
+		})
+	}
+}
+
+func TestIDMap_RemapPipelineConnection(t *testing.T) {
+	ids := IDMap{}
+	ids.Set(KindStream, "src-stream", "dst-stream")
+	ids.Set(KindPipeline, "src-pipe", "dst-pipe")
+	conn := &graylog.PipelineConnection{
+		StreamID:    "src-stream",
+		PipelineIDs: []string{"src-pipe", "foo"},
+	}
+	missing := ids.RemapPipelineConnection(conn)
+	require.Equal(t, "dst-stream", conn.StreamID)
+	require.Equal(t, []string{"dst-pipe", "foo"}, conn.PipelineIDs)
+	require.Equal(t, []Reference{{Kind: KindPipeline, ID: "foo"}}, missing)
+}
+
+func TestIDMap_RemapPermissions(t *testing.T) {
+	ids := IDMap{}
+	ids.Set(KindStream, "src-stream", "dst-stream")
+	ids.Set(KindDashboard, "src-dashboard", "dst-dashboard")
+	perms, missing := ids.RemapPermissions([]string{
+		"streams:read:src-stream",
+		"streams:edit:src-stream,foo",
+		"dashboards:read:src-dashboard",
+		"streams:*",
+		"streams:read:*",
+		"users:edit:admin",
+	})
+	require.Equal(t, []string{
+		"streams:read:dst-stream",
+		"streams:edit:dst-stream,foo",
+		"dashboards:read:dst-dashboard",
+		"streams:*",
+		"streams:read:*",
+		"users:edit:admin",
+	}, perms)
+	require.Equal(t, []Reference{{Kind: KindStream, ID: "foo"}}, missing)
+}
+
+func TestRenameInSource(t *testing.T) {
+	src := `pipeline "foo"
+stage 0 match either
+rule "has $ sign";
+rule "bar";
+end`
+	src = renameInSource(src, "pipeline", "foo", "foo (migrated)")
+	src = renameInSource(src, "rule", "has $ sign", "has $ sign (migrated)")
+	require.Equal(t, `pipeline "foo (migrated)"
+stage 0 match either
+rule "has $ sign (migrated)";
+rule "bar";
+end`, src)
+}
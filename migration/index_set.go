@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// MigrateIndexSet copies an index set to the destination cluster and returns the destination index set's ID.
+// Index sets are matched by the index prefix.
+func (m *Migrator) MigrateIndexSet(ctx context.Context, id string) (string, error) {
+	if dstID, ok := m.ids.Get(KindIndexSet, id); ok {
+		return dstID, nil
+	}
+	src, _, err := m.src.GetIndexSet(ctx, id)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get an index set %s from the source cluster", id)
+	}
+	iss, _, _, _, err := m.dst.GetIndexSets(ctx, 0, 0, false)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get index sets from the destination cluster")
+	}
+	var existing *graylog.IndexSet
+	n := 0
+	for i, is := range iss {
+		if is.IndexPrefix == src.IndexPrefix {
+			existing = &iss[i]
+			n++
+		}
+	}
+	if n > 1 {
+		return "", ambiguousError(KindIndexSet, src.IndexPrefix, n)
+	}
+	action, prefix, err := m.resolve(KindIndexSet, src.IndexPrefix, existing != nil)
+	if err != nil {
+		return "", err
+	}
+	is := *src
+	is.IndexPrefix = prefix
+	is.Default = false
+	is.Stats = nil
+	switch action {
+	case ActionSkip:
+		m.record(KindIndexSet, src.IndexPrefix, prefix, action, id, existing.ID)
+		return existing.ID, nil
+	case ActionUpdate:
+		is.ID = existing.ID
+		if !m.cfg.DryRun {
+			if _, _, err := m.dst.UpdateIndexSet(ctx, is.NewUpdateParams()); err != nil {
+				return "", errors.Wrapf(err, "failed to update an index set %s", is.IndexPrefix)
+			}
+		}
+	default:
+		is.ID = ""
+		is.CreationDate = ""
+		if m.cfg.DryRun {
+			is.ID = dryRunID(id)
+		} else if _, err := m.dst.CreateIndexSet(ctx, &is); err != nil {
+			return "", errors.Wrapf(err, "failed to create an index set %s", is.IndexPrefix)
+		}
+	}
+	m.record(KindIndexSet, src.IndexPrefix, prefix, action, id, is.ID)
+	return is.ID, nil
+}
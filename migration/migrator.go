@@ -0,0 +1,161 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+const (
+	// ConflictSkip uses the existing resource of the destination cluster as it is.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictOverwrite overwrites the existing resource of the destination cluster.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	// ConflictRename creates a new resource with a new name.
+	ConflictRename ConflictPolicy = "rename"
+
+	dryRunIDPrefix = "dry-run:"
+)
+
+type (
+	// ConflictPolicy decides how to handle a resource which already exists in the destination cluster.
+	ConflictPolicy string
+
+	// Config is Migrator's configuration.
+	Config struct {
+		// the default policy is ConflictSkip
+		Policy ConflictPolicy
+		// KindPolicies overwrites Policy per kind (ex. KindStream).
+		KindPolicies map[string]ConflictPolicy
+		// If DryRun is true, no change is applied to the destination cluster.
+		DryRun bool
+		// If Prune is true, the alert conditions and alarm callbacks of an overwritten stream
+		// which don't exist in the source cluster are deleted.
+		// By default they are kept.
+		Prune bool
+		// Rename returns a new name of a resource when the policy is ConflictRename.
+		// The default is DefaultRename.
+		Rename func(kind, name string) string
+	}
+
+	// Migrator copies resources from the source cluster to the destination cluster.
+	Migrator struct {
+		src    *client.Client
+		dst    *client.Client
+		cfg    Config
+		ids    IDMap
+		report *Report
+	}
+)
+
+// DefaultRename is the default Config.Rename.
+// The suffix "_migrated" is added to index set prefixes and grok pattern names,
+// because they don't allow spaces.
+// The suffix " (migrated)" is added to the other names.
+func DefaultRename(kind, name string) string {
+	switch kind {
+	case KindIndexSet, KindGrokPattern:
+		return name + "_migrated"
+	}
+	return name + " (migrated)"
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(src, dst *client.Client, cfg *Config) *Migrator {
+	m := &Migrator{
+		src: src, dst: dst,
+		ids: IDMap{},
+	}
+	if cfg != nil {
+		m.cfg = *cfg
+	}
+	if m.cfg.Policy == "" {
+		m.cfg.Policy = ConflictSkip
+	}
+	if m.cfg.Rename == nil {
+		m.cfg.Rename = DefaultRename
+	}
+	m.report = &Report{DryRun: m.cfg.DryRun}
+	return m
+}
+
+// IDMap returns the map of IDs of migrated resources.
+// When some resources have been migrated in advance, you can set their IDs to the map.
+func (m *Migrator) IDMap() IDMap {
+	return m.ids
+}
+
+// Report returns the migration report.
+func (m *Migrator) Report() *Report {
+	return m.report
+}
+
+func (m *Migrator) policy(kind string) ConflictPolicy {
+	if p, ok := m.cfg.KindPolicies[kind]; ok {
+		return p
+	}
+	return m.cfg.Policy
+}
+
+// resolve decides an action according to the conflict policy and returns a name of a resource in the destination cluster.
+func (m *Migrator) resolve(kind, name string, exists bool) (Action, string, error) {
+	if !exists {
+		return ActionCreate, name, nil
+	}
+	switch p := m.policy(kind); p {
+	case ConflictSkip:
+		return ActionSkip, name, nil
+	case ConflictOverwrite:
+		return ActionUpdate, name, nil
+	case ConflictRename:
+		return ActionRename, m.cfg.Rename(kind, name), nil
+	default:
+		return "", "", fmt.Errorf("invalid conflict policy: %s", p)
+	}
+}
+
+// record adds an operation to the report and the ID map.
+// dstName is the resource's name in the destination cluster.
+func (m *Migrator) record(kind, name, dstName string, action Action, srcID, dstID string) {
+	if srcID != "" && dstID != "" {
+		m.ids.Set(kind, srcID, dstID)
+	}
+	m.addOperation(kind, name, dstName, action, srcID, dstID)
+}
+
+// addOperation adds an operation to the report.
+func (m *Migrator) addOperation(kind, name, dstName string, action Action, srcID, dstID string) {
+	op := Operation{
+		Kind: kind, Name: name, Action: action,
+		SrcID: srcID, DstID: dstID,
+	}
+	if dstName != name {
+		op.NewName = dstName
+	}
+	m.report.add(op)
+}
+
+func (m *Migrator) warnMissing(kind, name string, refs []Reference) {
+	for _, ref := range refs {
+		m.report.warn("%s %q refers %s which isn't migrated", kind, name, ref)
+	}
+}
+
+func dryRunID(srcID string) string {
+	return dryRunIDPrefix + srcID
+}
+
+// isPlaceholder returns true if a given id is a placeholder of a resource which isn't created by dry-run.
+func isPlaceholder(id string) bool {
+	return strings.HasPrefix(id, dryRunIDPrefix)
+}
+
+func isNotFound(ei *client.ErrorInfo) bool {
+	return ei != nil && ei.Response != nil && ei.Response.StatusCode == 404
+}
+
+func ambiguousError(kind, name string, n int) error {
+	return fmt.Errorf(
+		"%d %ss are found by the natural key %q in the destination cluster", n, kind, name)
+}
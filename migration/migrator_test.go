@@ -0,0 +1,332 @@
+package migration_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/migration"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+)
+
+const (
+	srcIndexSetID = "5d84c1a92ab79c000d35d5e0"
+	srcStreamID   = "5d84c1a92ab79c000d35d5e1"
+	srcPipeID     = "5d84c1a92ab79c000d35d5e2"
+	srcDashID     = "5d84c1a92ab79c000d35d5e3"
+)
+
+func newServerAndClient(t *testing.T, seed *mockserver.Seed) (*mockserver.Server, *client.Client) {
+	server, err := mockserver.NewServer("", seed)
+	require.Nil(t, err)
+	server.Start()
+	cl, err := client.NewClient(server.Endpoint(), "admin", mockserver.DefaultPassword)
+	if err != nil {
+		server.Close()
+		require.Nil(t, err)
+	}
+	return server, cl
+}
+
+// srcSeed returns the source cluster's data.
+// The stream "app" has a rule, an alert condition, an alarm callback, a connected pipeline
+// and a dashboard whose widget refers the stream.
+func srcSeed() *mockserver.Seed {
+	seed := mockserver.DefaultSeed()
+	is := seed.IndexSets[0]
+	is.ID = srcIndexSetID
+	is.Title = "app"
+	is.IndexPrefix = "app"
+	is.Default = false
+	seed.IndexSets = append(seed.IndexSets, is)
+	seed.Streams = append(seed.Streams, graylog.Stream{
+		ID:           srcStreamID,
+		Title:        "app",
+		Description:  "source",
+		IndexSetID:   srcIndexSetID,
+		MatchingType: "AND",
+		Rules: []graylog.StreamRule{{
+			Type: 1, Field: "tag", Value: "app",
+		}},
+		AlertConditions: []graylog.AlertCondition{{
+			Title: "error count",
+			Parameters: &graylog.MessageCountAlertConditionParameters{
+				Time: 5, Threshold: 10, ThresholdType: "MORE",
+			},
+		}},
+	})
+	seed.AlarmCallbacks = []graylog.AlarmCallback{{
+		StreamID:      srcStreamID,
+		Title:         "notify",
+		Configuration: &graylog.HTTPAlarmCallbackConfiguration{URL: "http://example.com"},
+	}}
+	seed.PipelineRules = []graylog.PipelineRule{{
+		Title:  "has app",
+		Source: "rule \"has app\"\nwhen\n  has_field(\"app\")\nthen\nend",
+	}}
+	seed.Pipelines = []graylog.Pipeline{{
+		ID:     srcPipeID,
+		Title:  "app",
+		Source: "pipeline \"app\"\nstage 0 match either\n  rule \"has app\";\nend",
+		Stages: []graylog.PipelineStage{{Stage: 0, Rules: []string{"has app"}}},
+	}}
+	seed.PipelineConnections = []graylog.PipelineConnection{{
+		StreamID: srcStreamID, PipelineIDs: []string{srcPipeID},
+	}}
+	seed.Dashboards = []graylog.Dashboard{{
+		ID:    srcDashID,
+		Title: "app",
+		Widgets: []graylog.Widget{{
+			Description: "app count",
+			Config: &graylog.WidgetConfigStatsCount{
+				Timerange:     &graylog.Timerange{Type: "relative", Range: 300},
+				StreamID:      srcStreamID,
+				Field:         "status",
+				StatsFunction: "count",
+			},
+		}},
+	}}
+	return seed
+}
+
+func findStream(t *testing.T, cl *client.Client, title string) *graylog.Stream {
+	streams, _, _, err := cl.GetStreams(context.Background())
+	require.Nil(t, err)
+	for i, stream := range streams {
+		if stream.Title == title {
+			return &streams[i]
+		}
+	}
+	return nil
+}
+
+func getActions(report *migration.Report, kind string) []migration.Action {
+	actions := []migration.Action{}
+	for _, op := range report.Operations {
+		if op.Kind == kind {
+			actions = append(actions, op.Action)
+		}
+	}
+	return actions
+}
+
+func TestMigrator_MigrateStream(t *testing.T) {
+	ctx := context.Background()
+	srcServer, src := newServerAndClient(t, srcSeed())
+	defer srcServer.Close()
+	dstServer, dst := newServerAndClient(t, nil)
+	defer dstServer.Close()
+
+	m := migration.NewMigrator(src, dst, nil)
+	dstStreamID, err := m.MigrateStream(ctx, srcStreamID)
+	require.Nil(t, err)
+
+	stream, _, err := dst.GetStream(ctx, dstStreamID)
+	require.Nil(t, err)
+	require.Equal(t, "app", stream.Title)
+	require.Len(t, stream.Rules, 1)
+	require.Equal(t, "tag", stream.Rules[0].Field)
+	require.Len(t, stream.AlertConditions, 1)
+	require.Equal(t, "error count", stream.AlertConditions[0].Title)
+
+	is, _, err := dst.GetIndexSet(ctx, stream.IndexSetID)
+	require.Nil(t, err)
+	require.Equal(t, "app", is.IndexPrefix)
+	dstIndexSetID, ok := m.IDMap().Get(migration.KindIndexSet, srcIndexSetID)
+	require.True(t, ok)
+	require.Equal(t, is.ID, dstIndexSetID)
+
+	acs, _, _, err := dst.GetStreamAlarmCallbacks(ctx, dstStreamID)
+	require.Nil(t, err)
+	require.Len(t, acs, 1)
+	require.Equal(t, dstStreamID, acs[0].StreamID)
+
+	dstPipeID, ok := m.IDMap().Get(migration.KindPipeline, srcPipeID)
+	require.True(t, ok)
+	conn, _, err := dst.GetPipelineConnectionsOfStream(ctx, dstStreamID)
+	require.Nil(t, err)
+	require.Equal(t, []string{dstPipeID}, conn.PipelineIDs)
+
+	dstDashID, ok := m.IDMap().Get(migration.KindDashboard, srcDashID)
+	require.True(t, ok)
+	db, _, err := dst.GetDashboard(ctx, dstDashID)
+	require.Nil(t, err)
+	require.Len(t, db.Widgets, 1)
+	cfg, ok := db.Widgets[0].Config.(*graylog.WidgetConfigStatsCount)
+	require.True(t, ok)
+	require.Equal(t, dstStreamID, cfg.StreamID)
+
+	require.Empty(t, m.Report().Warnings)
+}
+
+func TestMigrator_DryRun(t *testing.T) {
+	ctx := context.Background()
+	srcServer, src := newServerAndClient(t, srcSeed())
+	defer srcServer.Close()
+	dstServer, dst := newServerAndClient(t, nil)
+	defer dstServer.Close()
+
+	m := migration.NewMigrator(src, dst, &migration.Config{DryRun: true})
+	_, err := m.MigrateStream(ctx, srcStreamID)
+	require.Nil(t, err)
+
+	require.Nil(t, findStream(t, dst, "app"))
+	_, total, _, err := dst.GetDashboards(ctx)
+	require.Nil(t, err)
+	require.Equal(t, 0, total)
+
+	report := m.Report()
+	require.True(t, report.DryRun)
+	for _, kind := range []string{
+		migration.KindIndexSet, migration.KindStream, migration.KindStreamRule,
+		migration.KindAlertCondition, migration.KindAlarmCallback,
+		migration.KindPipelineRule, migration.KindPipeline, migration.KindDashboard, migration.KindWidget,
+	} {
+		require.Equal(t, []migration.Action{migration.ActionCreate}, getActions(report, kind), kind)
+	}
+	require.True(t, strings.HasPrefix(report.String(), "[dry-run]"))
+}
+
+func TestMigrator_ConflictPolicy(t *testing.T) {
+	ctx := context.Background()
+	data := []struct {
+		title   string
+		cfg     migration.Config
+		action  migration.Action
+		streams int
+		desc    string
+		conds   []string
+	}{
+		{
+			title: "skip", cfg: migration.Config{Policy: migration.ConflictSkip},
+			action: migration.ActionSkip, streams: 2, desc: "destination", conds: []string{"stale"},
+		},
+		{
+			title: "overwrite", cfg: migration.Config{Policy: migration.ConflictOverwrite},
+			action: migration.ActionUpdate, streams: 2, desc: "source", conds: []string{"error count", "stale"},
+		},
+		{
+			title: "overwrite and prune", cfg: migration.Config{Policy: migration.ConflictOverwrite, Prune: true},
+			action: migration.ActionUpdate, streams: 2, desc: "source", conds: []string{"error count"},
+		},
+		{
+			title: "rename", cfg: migration.Config{Policy: migration.ConflictRename},
+			action: migration.ActionRename, streams: 3, desc: "destination", conds: []string{"stale"},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			srcServer, src := newServerAndClient(t, srcSeed())
+			defer srcServer.Close()
+			dstSeed := mockserver.DefaultSeed()
+			dstSeed.Streams = append(dstSeed.Streams, graylog.Stream{
+				Title:        "app",
+				Description:  "destination",
+				IndexSetID:   mockserver.DefaultIndexSetID,
+				MatchingType: "AND",
+				AlertConditions: []graylog.AlertCondition{{
+					Title: "stale",
+					Parameters: &graylog.MessageCountAlertConditionParameters{
+						Time: 1, ThresholdType: "MORE",
+					},
+				}},
+			})
+			dstServer, dst := newServerAndClient(t, dstSeed)
+			defer dstServer.Close()
+
+			m := migration.NewMigrator(src, dst, &d.cfg)
+			_, err := m.MigrateStream(ctx, srcStreamID)
+			require.Nil(t, err)
+			require.Equal(t, []migration.Action{d.action}, getActions(m.Report(), migration.KindStream))
+
+			streams, _, _, err := dst.GetStreams(ctx)
+			require.Nil(t, err)
+			require.Len(t, streams, d.streams)
+			stream := findStream(t, dst, "app")
+			require.NotNil(t, stream)
+			require.Equal(t, d.desc, stream.Description)
+			conds := make([]string, len(stream.AlertConditions))
+			for i, cond := range stream.AlertConditions {
+				conds[i] = cond.Title
+			}
+			require.ElementsMatch(t, d.conds, conds)
+			if d.action == migration.ActionRename {
+				require.NotNil(t, findStream(t, dst, "app (migrated)"))
+			}
+		})
+	}
+}
+
+func TestMigrator_MigrateDashboard(t *testing.T) {
+	ctx := context.Background()
+	srcServer, src := newServerAndClient(t, srcSeed())
+	defer srcServer.Close()
+	dstSeed := mockserver.DefaultSeed()
+	dstSeed.Dashboards = []graylog.Dashboard{{
+		Title: "app",
+		Widgets: []graylog.Widget{{
+			Description: "old widget",
+			Config: &graylog.WidgetConfigStatsCount{
+				Timerange: &graylog.Timerange{Type: "relative", Range: 60},
+			},
+		}},
+	}}
+	dstServer, dst := newServerAndClient(t, dstSeed)
+	defer dstServer.Close()
+
+	m := migration.NewMigrator(src, dst, &migration.Config{
+		KindPolicies: map[string]migration.ConflictPolicy{
+			migration.KindDashboard: migration.ConflictOverwrite,
+		},
+	})
+	dstDashID, err := m.MigrateDashboard(ctx, srcDashID)
+	require.Nil(t, err)
+	require.Equal(t, []migration.Action{migration.ActionUpdate}, getActions(m.Report(), migration.KindDashboard))
+	require.Equal(t,
+		[]migration.Action{migration.ActionDelete, migration.ActionCreate},
+		getActions(m.Report(), migration.KindWidget))
+
+	db, _, err := dst.GetDashboard(ctx, dstDashID)
+	require.Nil(t, err)
+	require.Len(t, db.Widgets, 1)
+	require.Equal(t, "app count", db.Widgets[0].Description)
+	// the stream which the widget refers is migrated together
+	dstStreamID, ok := m.IDMap().Get(migration.KindStream, srcStreamID)
+	require.True(t, ok)
+	cfg := db.Widgets[0].Config.(*graylog.WidgetConfigStatsCount)
+	require.Equal(t, dstStreamID, cfg.StreamID)
+}
+
+func TestMigrator_MigratePipeline(t *testing.T) {
+	ctx := context.Background()
+	srcServer, src := newServerAndClient(t, srcSeed())
+	defer srcServer.Close()
+	dstSeed := mockserver.DefaultSeed()
+	dstSeed.PipelineRules = []graylog.PipelineRule{{
+		Title:  "has app",
+		Source: "rule \"has app\"\nwhen\n  true\nthen\nend",
+	}}
+	dstServer, dst := newServerAndClient(t, dstSeed)
+	defer dstServer.Close()
+
+	m := migration.NewMigrator(src, dst, &migration.Config{Policy: migration.ConflictRename})
+	dstPipeID, err := m.MigratePipeline(ctx, srcPipeID)
+	require.Nil(t, err)
+
+	// the existing rule is kept and the renamed rule is referred by the pipeline
+	pipe, _, err := dst.GetPipeline(ctx, dstPipeID)
+	require.Nil(t, err)
+	require.Equal(t, "app", pipe.Title)
+	require.Contains(t, pipe.Source, `rule "has app (migrated)";`)
+	rules, _, err := dst.GetPipelineRules(ctx)
+	require.Nil(t, err)
+	titles := make([]string, len(rules))
+	for i, rule := range rules {
+		titles[i] = rule.Title
+	}
+	require.ElementsMatch(t, []string{"has app", "has app (migrated)"}, titles)
+}
@@ -0,0 +1,195 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// renameInSource renames a declaration or reference such as `rule "name"` in the pipeline or rule source.
+func renameInSource(source, keyword, oldName, newName string) string {
+	if oldName == newName {
+		return source
+	}
+	re := regexp.MustCompile(`(` + keyword + `\s+)` + regexp.QuoteMeta(strconv.Quote(oldName)))
+	return re.ReplaceAllString(source, "${1}"+strings.Replace(strconv.Quote(newName), "$", "$$", -1))
+}
+
+func findPipelineRule(rules []graylog.PipelineRule, title string) (*graylog.PipelineRule, int) {
+	var found *graylog.PipelineRule
+	n := 0
+	for i, r := range rules {
+		if r.Title == title {
+			found = &rules[i]
+			n++
+		}
+	}
+	return found, n
+}
+
+// MigratePipelineRule copies a pipeline rule to the destination cluster and returns the destination rule's title.
+// Pipeline rules are matched by the title, because pipelines refer rules by the title.
+func (m *Migrator) MigratePipelineRule(ctx context.Context, title string) (string, error) {
+	if dstTitle, ok := m.ids.Get(KindPipelineRule, title); ok {
+		return dstTitle, nil
+	}
+	srcRules, _, err := m.src.GetPipelineRules(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get pipeline rules from the source cluster")
+	}
+	src, n := findPipelineRule(srcRules, title)
+	if n == 0 {
+		return "", fmt.Errorf("pipeline rule %q isn't found in the source cluster", title)
+	}
+	dstRules, _, err := m.dst.GetPipelineRules(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get pipeline rules from the destination cluster")
+	}
+	existing, n := findPipelineRule(dstRules, title)
+	if n > 1 {
+		return "", ambiguousError(KindPipelineRule, title, n)
+	}
+	action, dstTitle, err := m.resolve(KindPipelineRule, title, existing != nil)
+	if err != nil {
+		return "", err
+	}
+	rule := *src
+	rule.Title = dstTitle
+	rule.Source = renameInSource(src.Source, "rule", title, dstTitle)
+	dstID := ""
+	switch action {
+	case ActionSkip:
+		dstID = existing.ID
+	case ActionUpdate:
+		rule.ID = existing.ID
+		dstID = existing.ID
+		if !m.cfg.DryRun {
+			if _, err := m.dst.UpdatePipelineRule(ctx, &rule); err != nil {
+				return "", errors.Wrapf(err, "failed to update a pipeline rule %s", title)
+			}
+		}
+	default:
+		rule.ID = ""
+		dstID = dryRunID(src.ID)
+		if !m.cfg.DryRun {
+			if _, err := m.dst.CreatePipelineRule(ctx, &rule); err != nil {
+				return "", errors.Wrapf(err, "failed to create a pipeline rule %s", dstTitle)
+			}
+			dstID = rule.ID
+		}
+	}
+	m.addOperation(KindPipelineRule, title, dstTitle, action, src.ID, dstID)
+	// pipelines refer rules by the title, so the ID map has titles instead of IDs.
+	m.ids.Set(KindPipelineRule, title, dstTitle)
+	return dstTitle, nil
+}
+
+func findPipeline(pipes []graylog.Pipeline, title string) (*graylog.Pipeline, int) {
+	var found *graylog.Pipeline
+	n := 0
+	for i, p := range pipes {
+		if p.Title == title {
+			found = &pipes[i]
+			n++
+		}
+	}
+	return found, n
+}
+
+// MigratePipeline copies a pipeline and its rules to the destination cluster and returns the destination pipeline's ID.
+// Pipelines are matched by the title.
+func (m *Migrator) MigratePipeline(ctx context.Context, id string) (string, error) {
+	if dstID, ok := m.ids.Get(KindPipeline, id); ok {
+		return dstID, nil
+	}
+	src, _, err := m.src.GetPipeline(ctx, id)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get a pipeline %s from the source cluster", id)
+	}
+	source := src.Source
+	for _, stage := range src.Stages {
+		for _, ruleTitle := range stage.Rules {
+			dstRuleTitle, err := m.MigratePipelineRule(ctx, ruleTitle)
+			if err != nil {
+				return "", err
+			}
+			source = renameInSource(source, "rule", ruleTitle, dstRuleTitle)
+		}
+	}
+	dstPipes, _, err := m.dst.GetPipelines(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get pipelines from the destination cluster")
+	}
+	existing, n := findPipeline(dstPipes, src.Title)
+	if n > 1 {
+		return "", ambiguousError(KindPipeline, src.Title, n)
+	}
+	action, dstTitle, err := m.resolve(KindPipeline, src.Title, existing != nil)
+	if err != nil {
+		return "", err
+	}
+	pipe := &graylog.Pipeline{
+		Title:       dstTitle,
+		Description: src.Description,
+		Source:      renameInSource(source, "pipeline", src.Title, dstTitle),
+	}
+	switch action {
+	case ActionSkip:
+		m.record(KindPipeline, src.Title, dstTitle, action, id, existing.ID)
+		return existing.ID, nil
+	case ActionUpdate:
+		pipe.ID = existing.ID
+		if !m.cfg.DryRun {
+			if _, err := m.dst.UpdatePipeline(ctx, pipe); err != nil {
+				return "", errors.Wrapf(err, "failed to update a pipeline %s", src.Title)
+			}
+		}
+	default:
+		if m.cfg.DryRun {
+			pipe.ID = dryRunID(id)
+		} else if _, err := m.dst.CreatePipeline(ctx, pipe); err != nil {
+			return "", errors.Wrapf(err, "failed to create a pipeline %s", dstTitle)
+		}
+	}
+	m.record(KindPipeline, src.Title, dstTitle, action, id, pipe.ID)
+	return pipe.ID, nil
+}
+
+// migratePipelineConnection connects the migrated pipelines to the migrated stream.
+// The stream must be migrated in advance.
+func (m *Migrator) migratePipelineConnection(ctx context.Context, src *graylog.Stream) error {
+	conn, ei, err := m.src.GetPipelineConnectionsOfStream(ctx, src.ID)
+	if err != nil {
+		if isNotFound(ei) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get pipeline connections of a stream %s from the source cluster", src.Title)
+	}
+	if len(conn.PipelineIDs) == 0 {
+		return nil
+	}
+	for _, pipeID := range conn.PipelineIDs {
+		if _, err := m.MigratePipeline(ctx, pipeID); err != nil {
+			return err
+		}
+	}
+	dstConn := &graylog.PipelineConnection{
+		StreamID:    src.ID,
+		PipelineIDs: make([]string, len(conn.PipelineIDs)),
+	}
+	copy(dstConn.PipelineIDs, conn.PipelineIDs)
+	m.warnMissing(KindPipelineConnection, src.Title, m.ids.RemapPipelineConnection(dstConn))
+	if !m.cfg.DryRun {
+		if _, err := m.dst.ConnectPipelinesToStream(ctx, dstConn); err != nil {
+			return errors.Wrapf(err, "failed to connect pipelines to a stream %s", src.Title)
+		}
+	}
+	m.record(KindPipelineConnection, src.Title, src.Title, ActionUpdate, conn.ID, dstConn.ID)
+	return nil
+}
@@ -0,0 +1,75 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ActionCreate means that a resource is created in the destination cluster.
+	ActionCreate Action = "create"
+	// ActionUpdate means that an existing resource of the destination cluster is overwritten.
+	ActionUpdate Action = "update"
+	// ActionSkip means that an existing resource of the destination cluster is used as it is.
+	ActionSkip Action = "skip"
+	// ActionRename means that a resource is created in the destination cluster with a new name.
+	ActionRename Action = "rename"
+	// ActionDelete means that a resource of the destination cluster is deleted.
+	// Only children of an overwritten resource are deleted:
+	// stream rules, and alert conditions and alarm callbacks when Config.Prune is true.
+	ActionDelete Action = "delete"
+)
+
+type (
+	// Action is an action against the destination cluster.
+	Action string
+
+	// Operation is an operation against the destination cluster.
+	Operation struct {
+		Kind   string `json:"kind"`
+		Name   string `json:"name"`
+		Action Action `json:"action"`
+		SrcID  string `json:"src_id,omitempty"`
+		DstID  string `json:"dst_id,omitempty"`
+		// the new name of the renamed resource
+		NewName string `json:"new_name,omitempty"`
+	}
+
+	// Report is the result of the migration.
+	// When the migration is run with dry-run mode, Report shows operations which would be run.
+	Report struct {
+		DryRun     bool        `json:"dry_run"`
+		Operations []Operation `json:"operations"`
+		Warnings   []string    `json:"warnings,omitempty"`
+	}
+)
+
+func (report *Report) add(op Operation) {
+	report.Operations = append(report.Operations, op)
+}
+
+func (report *Report) warn(format string, a ...interface{}) {
+	report.Warnings = append(report.Warnings, fmt.Sprintf(format, a...))
+}
+
+// String returns a human readable report.
+func (report *Report) String() string {
+	buf := &strings.Builder{}
+	if report.DryRun {
+		buf.WriteString("[dry-run] no change is applied to the destination cluster\n")
+	}
+	for _, op := range report.Operations {
+		fmt.Fprintf(buf, "%-6s %s %q", op.Action, op.Kind, op.Name)
+		if op.NewName != "" {
+			fmt.Fprintf(buf, " -> %q", op.NewName)
+		}
+		if op.SrcID != "" || op.DstID != "" {
+			fmt.Fprintf(buf, " (%s -> %s)", op.SrcID, op.DstID)
+		}
+		buf.WriteString("\n")
+	}
+	for _, w := range report.Warnings {
+		fmt.Fprintf(buf, "WARNING: %s\n", w)
+	}
+	return buf.String()
+}
@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// MigrateRole copies a role to the destination cluster and returns the destination role's name.
+// Roles are matched by the name.
+// Stream and dashboard IDs in the role's permissions are remapped,
+// so migrate streams and dashboards before their roles.
+// Read only roles such as "Admin" and "Reader" are built in, so they are always skipped.
+func (m *Migrator) MigrateRole(ctx context.Context, name string) (string, error) {
+	if dstName, ok := m.ids.Get(KindRole, name); ok {
+		return dstName, nil
+	}
+	src, _, err := m.src.GetRole(ctx, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get a role %s from the source cluster", name)
+	}
+	existing, ei, err := m.dst.GetRole(ctx, name)
+	if err != nil {
+		if !isNotFound(ei) {
+			return "", errors.Wrapf(err, "failed to get a role %s from the destination cluster", name)
+		}
+		existing = nil
+	}
+	if src.ReadOnly && existing != nil {
+		m.record(KindRole, name, name, ActionSkip, name, name)
+		return name, nil
+	}
+	action, dstName, err := m.resolve(KindRole, name, existing != nil)
+	if err != nil {
+		return "", err
+	}
+	if action == ActionSkip {
+		m.record(KindRole, name, dstName, action, name, name)
+		return name, nil
+	}
+	role := *src
+	role.Name = dstName
+	role.ReadOnly = false
+	perms, missing := m.ids.RemapPermissions(src.Permissions.ToList())
+	m.warnMissing(KindRole, name, missing)
+	role.Permissions = set.NewStrSet(perms...)
+	if !m.cfg.DryRun {
+		if action == ActionUpdate {
+			if _, _, err := m.dst.UpdateRole(ctx, name, role.NewUpdateParams()); err != nil {
+				return "", errors.Wrapf(err, "failed to update a role %s", name)
+			}
+		} else if _, err := m.dst.CreateRole(ctx, &role); err != nil {
+			return "", errors.Wrapf(err, "failed to create a role %s", dstName)
+		}
+	}
+	m.record(KindRole, name, dstName, action, name, dstName)
+	return dstName, nil
+}
@@ -0,0 +1,296 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func findStream(streams []graylog.Stream, title string) (*graylog.Stream, int) {
+	var found *graylog.Stream
+	n := 0
+	for i, s := range streams {
+		if s.Title == title {
+			found = &streams[i]
+			n++
+		}
+	}
+	return found, n
+}
+
+// streamRuleKey returns a natural key of a stream rule.
+// Stream rules don't have a name, so they are matched by the content.
+func streamRuleKey(rule graylog.StreamRule) string {
+	return fmt.Sprintf("%s %d %s inverted=%t", rule.Field, rule.Type, rule.Value, rule.Inverted)
+}
+
+// MigrateStream copies a stream to the destination cluster and returns the destination stream's ID.
+// Streams are matched by the title.
+// The stream's index set, rules, alert conditions, alarm callbacks, pipeline connections
+// and dashboards whose widgets refer the stream are migrated together.
+// The default stream is mapped to the destination cluster's default stream.
+func (m *Migrator) MigrateStream(ctx context.Context, id string) (string, error) {
+	if dstID, ok := m.ids.Get(KindStream, id); ok {
+		return dstID, nil
+	}
+	src, _, err := m.src.GetStream(ctx, id)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get a stream %s from the source cluster", id)
+	}
+	dstStreams, _, _, err := m.dst.GetStreams(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get streams from the destination cluster")
+	}
+	if src.IsDefault {
+		for _, stream := range dstStreams {
+			if stream.IsDefault {
+				m.record(KindStream, src.Title, stream.Title, ActionSkip, id, stream.ID)
+				return stream.ID, nil
+			}
+		}
+		return "", errors.New("the default stream isn't found in the destination cluster")
+	}
+	if _, err := m.MigrateIndexSet(ctx, src.IndexSetID); err != nil {
+		return "", err
+	}
+	existing, n := findStream(dstStreams, src.Title)
+	if n > 1 {
+		return "", ambiguousError(KindStream, src.Title, n)
+	}
+	action, dstTitle, err := m.resolve(KindStream, src.Title, existing != nil)
+	if err != nil {
+		return "", err
+	}
+	if action == ActionSkip {
+		m.record(KindStream, src.Title, dstTitle, action, id, existing.ID)
+		return existing.ID, nil
+	}
+	stream := &graylog.Stream{
+		Title:                          dstTitle,
+		IndexSetID:                     src.IndexSetID,
+		Description:                    src.Description,
+		MatchingType:                   src.MatchingType,
+		RemoveMatchesFromDefaultStream: src.RemoveMatchesFromDefaultStream,
+	}
+	m.warnMissing(KindStream, src.Title, m.ids.RemapStream(stream))
+	var dst *graylog.Stream
+	if action == ActionUpdate {
+		dst = existing
+		stream.ID = existing.ID
+		if !m.cfg.DryRun {
+			if _, err := m.dst.UpdateStream(ctx, stream); err != nil {
+				return "", errors.Wrapf(err, "failed to update a stream %s", src.Title)
+			}
+		}
+	} else {
+		if m.cfg.DryRun {
+			stream.ID = dryRunID(id)
+		} else if _, err := m.dst.CreateStream(ctx, stream); err != nil {
+			return "", errors.Wrapf(err, "failed to create a stream %s", dstTitle)
+		}
+	}
+	m.record(KindStream, src.Title, dstTitle, action, id, stream.ID)
+
+	if err := m.migrateStreamRules(ctx, src, stream.ID, dst); err != nil {
+		return "", err
+	}
+	if err := m.migrateAlertConditions(ctx, src, stream.ID, dst); err != nil {
+		return "", err
+	}
+	if err := m.migrateAlarmCallbacks(ctx, src, stream.ID, dst); err != nil {
+		return "", err
+	}
+	if err := m.migratePipelineConnection(ctx, src); err != nil {
+		return "", err
+	}
+	if !m.cfg.DryRun {
+		// a created stream is paused, so resume it after its rules are created
+		if src.Disabled {
+			_, err = m.dst.PauseStream(ctx, stream.ID)
+		} else {
+			_, err = m.dst.ResumeStream(ctx, stream.ID)
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to change the state of a stream %s", dstTitle)
+		}
+	}
+	if err := m.migrateDashboardsOfStream(ctx, id); err != nil {
+		return "", err
+	}
+	return stream.ID, nil
+}
+
+// migrateStreamRules migrates stream rules.
+// dst is the overwritten stream of the destination cluster.
+// If dst is nil, the stream is created newly.
+func (m *Migrator) migrateStreamRules(
+	ctx context.Context, src *graylog.Stream, dstStreamID string, dst *graylog.Stream,
+) error {
+	used := map[string]bool{}
+	for _, srcRule := range src.Rules {
+		key := streamRuleKey(srcRule)
+		if dst != nil {
+			found := false
+			for _, r := range dst.Rules {
+				if !used[r.ID] && streamRuleKey(r) == key {
+					used[r.ID] = true
+					found = true
+					m.record(KindStreamRule, key, key, ActionSkip, srcRule.ID, r.ID)
+					break
+				}
+			}
+			if found {
+				continue
+			}
+		}
+		rule := srcRule
+		rule.StreamID = dstStreamID
+		if m.cfg.DryRun {
+			rule.ID = dryRunID(srcRule.ID)
+		} else {
+			rule.ID = ""
+			if _, err := m.dst.CreateStreamRule(ctx, &rule); err != nil {
+				return errors.Wrapf(err, "failed to create a stream rule of a stream %s", src.Title)
+			}
+		}
+		m.record(KindStreamRule, key, key, ActionCreate, srcRule.ID, rule.ID)
+	}
+	if dst == nil {
+		return nil
+	}
+	for _, r := range dst.Rules {
+		if used[r.ID] {
+			continue
+		}
+		key := streamRuleKey(r)
+		if !m.cfg.DryRun {
+			if _, err := m.dst.DeleteStreamRule(ctx, dstStreamID, r.ID); err != nil {
+				return errors.Wrapf(err, "failed to delete a stream rule of a stream %s", src.Title)
+			}
+		}
+		m.addOperation(KindStreamRule, key, key, ActionDelete, "", r.ID)
+	}
+	return nil
+}
+
+// migrateAlertConditions migrates alert conditions.
+// Alert conditions are matched by the title.
+// The unmatched alert conditions of the destination cluster are deleted only if Config.Prune is true.
+func (m *Migrator) migrateAlertConditions(
+	ctx context.Context, src *graylog.Stream, dstStreamID string, dst *graylog.Stream,
+) error {
+	existing := map[string]graylog.AlertCondition{}
+	if dst != nil {
+		for _, cond := range dst.AlertConditions {
+			existing[cond.Title] = cond
+		}
+	}
+	for _, srcCond := range src.AlertConditions {
+		cond := srcCond
+		cond.CreatedAt = ""
+		cond.CreatorUserID = ""
+		cond.InGrace = false
+		if e, ok := existing[srcCond.Title]; ok {
+			delete(existing, srcCond.Title)
+			cond.ID = e.ID
+			if !m.cfg.DryRun {
+				if _, err := m.dst.UpdateStreamAlertCondition(ctx, dstStreamID, &cond); err != nil {
+					return errors.Wrapf(err, "failed to update an alert condition %s", cond.Title)
+				}
+			}
+			m.record(KindAlertCondition, cond.Title, cond.Title, ActionUpdate, srcCond.ID, cond.ID)
+			continue
+		}
+		if m.cfg.DryRun {
+			cond.ID = dryRunID(srcCond.ID)
+		} else {
+			cond.ID = ""
+			if _, err := m.dst.CreateStreamAlertCondition(ctx, dstStreamID, &cond); err != nil {
+				return errors.Wrapf(err, "failed to create an alert condition %s", cond.Title)
+			}
+		}
+		m.record(KindAlertCondition, cond.Title, cond.Title, ActionCreate, srcCond.ID, cond.ID)
+	}
+	if dst == nil || !m.cfg.Prune {
+		return nil
+	}
+	for _, cond := range dst.AlertConditions {
+		if _, ok := existing[cond.Title]; !ok {
+			continue
+		}
+		if !m.cfg.DryRun {
+			if _, err := m.dst.DeleteStreamAlertCondition(ctx, dstStreamID, cond.ID); err != nil {
+				return errors.Wrapf(err, "failed to delete an alert condition %s", cond.Title)
+			}
+		}
+		m.addOperation(KindAlertCondition, cond.Title, cond.Title, ActionDelete, "", cond.ID)
+	}
+	return nil
+}
+
+// migrateAlarmCallbacks migrates alarm callbacks.
+// Alarm callbacks are matched by the title.
+// The unmatched alarm callbacks of the destination cluster are deleted only if Config.Prune is true.
+func (m *Migrator) migrateAlarmCallbacks(
+	ctx context.Context, src *graylog.Stream, dstStreamID string, dst *graylog.Stream,
+) error {
+	srcACs, _, _, err := m.src.GetStreamAlarmCallbacks(ctx, src.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get alarm callbacks of a stream %s from the source cluster", src.Title)
+	}
+	var dstACs []graylog.AlarmCallback
+	if dst != nil {
+		dstACs, _, _, err = m.dst.GetStreamAlarmCallbacks(ctx, dstStreamID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get alarm callbacks of a stream %s from the destination cluster", dst.Title)
+		}
+	}
+	existing := map[string]graylog.AlarmCallback{}
+	for _, ac := range dstACs {
+		existing[ac.Title] = ac
+	}
+	for _, srcAC := range srcACs {
+		ac := srcAC
+		ac.StreamID = dstStreamID
+		ac.CreatedAt = ""
+		ac.CreatorUserID = ""
+		if e, ok := existing[srcAC.Title]; ok {
+			delete(existing, srcAC.Title)
+			ac.ID = e.ID
+			if !m.cfg.DryRun {
+				if _, err := m.dst.UpdateStreamAlarmCallback(ctx, &ac); err != nil {
+					return errors.Wrapf(err, "failed to update an alarm callback %s", ac.Title)
+				}
+			}
+			m.record(KindAlarmCallback, ac.Title, ac.Title, ActionUpdate, srcAC.ID, ac.ID)
+			continue
+		}
+		if m.cfg.DryRun {
+			ac.ID = dryRunID(srcAC.ID)
+		} else {
+			ac.ID = ""
+			if _, err := m.dst.CreateStreamAlarmCallback(ctx, &ac); err != nil {
+				return errors.Wrapf(err, "failed to create an alarm callback %s", ac.Title)
+			}
+		}
+		m.record(KindAlarmCallback, ac.Title, ac.Title, ActionCreate, srcAC.ID, ac.ID)
+	}
+	if !m.cfg.Prune {
+		return nil
+	}
+	for _, ac := range dstACs {
+		if _, ok := existing[ac.Title]; !ok {
+			continue
+		}
+		if !m.cfg.DryRun {
+			if _, err := m.dst.DeleteStreamAlarmCallback(ctx, dstStreamID, ac.ID); err != nil {
+				return errors.Wrapf(err, "failed to delete an alarm callback %s", ac.Title)
+			}
+		}
+		m.addOperation(KindAlarmCallback, ac.Title, ac.Title, ActionDelete, "", ac.ID)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// alarmCallbackTypes are the alarm callback types which Graylog supports.
+var alarmCallbackTypes = map[string]graylog.AlarmCallbackTypeInfo{
+	graylog.EmailAlarmCallbackType: {
+		Name: "Email Alert Callback", RequestedConfiguration: map[string]graylog.ConfigurationField{},
+	},
+	graylog.HTTPAlarmCallbackType: {
+		Name: "HTTP Alarm Callback", RequestedConfiguration: map[string]graylog.ConfigurationField{},
+	},
+}
+
+func (srv *Server) getStreamAlarmCallback(streamID, id string) (*graylog.AlarmCallback, int, error) {
+	if _, sc, err := srv.getStream(streamID); err != nil {
+		return nil, sc, err
+	}
+	ac, ok := srv.alarmCallbacks[id]
+	if !ok || ac.StreamID != streamID {
+		return nil, http.StatusNotFound, fmt.Errorf("alarm callback <%s> not found", id)
+	}
+	return &ac, http.StatusOK, nil
+}
+
+func (srv *Server) decodeAlarmCallback(r *http.Request, streamID string) (*graylog.AlarmCallback, int, error) {
+	ac := &graylog.AlarmCallback{}
+	if sc, err := decodeBody(r, ac); err != nil {
+		return nil, sc, err
+	}
+	ac.StreamID = streamID
+	if err := validator.CreateValidator.Struct(ac); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err := validator.CreateValidator.Struct(ac.Configuration); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return ac, http.StatusOK, nil
+}
+
+func (srv *Server) getAlarmCallbacks(streamID string) *graylog.AlarmCallbacksBody {
+	acs := []graylog.AlarmCallback{}
+	for _, ac := range srv.alarmCallbacks {
+		if streamID == "" || ac.StreamID == streamID {
+			acs = append(acs, ac)
+		}
+	}
+	sort.Slice(acs, func(i, j int) bool {
+		return acs[i].ID < acs[j].ID
+	})
+	return &graylog.AlarmCallbacksBody{AlarmCallbacks: acs, Total: len(acs)}
+}
+
+// GET /alerts/callbacks Get a list of all alarm callbacks
+func (srv *Server) handleGetAlarmCallbacks(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.getAlarmCallbacks(""), http.StatusOK, nil
+}
+
+// GET /alerts/callbacks/types Available alarm callback types
+func (srv *Server) handleGetAlarmCallbackTypes(r *http.Request, ps params) (interface{}, int, error) {
+	return &graylog.AlarmCallbackTypesBody{Types: alarmCallbackTypes}, http.StatusOK, nil
+}
+
+// POST /alerts/callbacks/{alarmCallbackId}/test Send a test alert for a given alarm callback
+func (srv *Server) handleTestAlarmCallback(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["alarmCallbackID"]
+	if _, ok := srv.alarmCallbacks[id]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("alarm callback <%s> not found", id)
+	}
+	return nil, http.StatusOK, nil
+}
+
+// GET /streams/{streamid}/alarmcallbacks Get a list of all alarm callbacks for this stream
+func (srv *Server) handleGetStreamAlarmCallbacks(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	return srv.getAlarmCallbacks(stream.ID), http.StatusOK, nil
+}
+
+// GET /streams/{streamid}/alarmcallbacks/{alarmCallbackId} Get a single specified alarm callback for this stream
+func (srv *Server) handleGetStreamAlarmCallback(r *http.Request, ps params) (interface{}, int, error) {
+	ac, sc, err := srv.getStreamAlarmCallback(ps["streamID"], ps["alarmCallbackID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	return ac, http.StatusOK, nil
+}
+
+// POST /streams/{streamid}/alarmcallbacks Create an alarm callback
+func (srv *Server) handleCreateStreamAlarmCallback(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	ac, sc, err := srv.decodeAlarmCallback(r, stream.ID)
+	if err != nil {
+		return nil, sc, err
+	}
+	ac.ID = newObjectID()
+	ac.CreatedAt = now()
+	ac.CreatorUserID = srv.creatorUserID()
+	srv.alarmCallbacks[ac.ID] = *ac
+	return map[string]string{"alarmcallback_id": ac.ID}, http.StatusCreated, nil
+}
+
+// PUT /streams/{streamid}/alarmcallbacks/{alarmCallbackId} Update an alarm callback
+func (srv *Server) handleUpdateStreamAlarmCallback(r *http.Request, ps params) (interface{}, int, error) {
+	orig, sc, err := srv.getStreamAlarmCallback(ps["streamID"], ps["alarmCallbackID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	ac, sc, err := srv.decodeAlarmCallback(r, orig.StreamID)
+	if err != nil {
+		return nil, sc, err
+	}
+	if ac.Type() != orig.Type() {
+		return nil, http.StatusBadRequest, fmt.Errorf("the type of alarm callback cannot be changed")
+	}
+	ac.ID = orig.ID
+	ac.CreatedAt = orig.CreatedAt
+	ac.CreatorUserID = orig.CreatorUserID
+	srv.alarmCallbacks[ac.ID] = *ac
+	return nil, http.StatusNoContent, nil
+}
+
+// DELETE /streams/{streamid}/alarmcallbacks/{alarmCallbackId} Delete an alarm callback
+func (srv *Server) handleDeleteStreamAlarmCallback(r *http.Request, ps params) (interface{}, int, error) {
+	ac, sc, err := srv.getStreamAlarmCallback(ps["streamID"], ps["alarmCallbackID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	delete(srv.alarmCallbacks, ac.ID)
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,178 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// filterAlerts returns the stream's alerts in descending order of triggered_at.
+// If streamID is empty, all alerts are returned.
+func (srv *Server) filterAlerts(streamID, state string, since time.Time) ([]graylog.Alert, int, error) {
+	switch state {
+	case "", graylog.AlertStateAny, graylog.AlertStateResolved, graylog.AlertStateUnresolved:
+	default:
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid alert state: %s", state)
+	}
+	alerts := []graylog.Alert{}
+	for _, alert := range srv.alerts {
+		if streamID != "" && alert.StreamID != streamID {
+			continue
+		}
+		if state == graylog.AlertStateResolved && !alert.IsResolved() {
+			continue
+		}
+		if state == graylog.AlertStateUnresolved && alert.IsResolved() {
+			continue
+		}
+		if !since.IsZero() {
+			t, err := time.Parse(graylog.CreationDateFormat, alert.TriggeredAt)
+			if err == nil && t.Before(since) {
+				continue
+			}
+		}
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].TriggeredAt == alerts[j].TriggeredAt {
+			return alerts[i].ID < alerts[j].ID
+		}
+		return alerts[i].TriggeredAt > alerts[j].TriggeredAt
+	})
+	return alerts, http.StatusOK, nil
+}
+
+// pageAlerts returns the alerts in the page specified by the query parameters "skip" and "limit".
+func pageAlerts(r *http.Request, alerts []graylog.Alert) (*graylog.AlertsBody, int, error) {
+	skip, err := queryInt(r, "skip", 0)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	limit, err := queryInt(r, "limit", 0)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	start, end := paginate(len(alerts), skip, limit)
+	return &graylog.AlertsBody{Alerts: alerts[start:end], Total: len(alerts)}, http.StatusOK, nil
+}
+
+// GET /streams/alerts Get the most recent alarms of all streams
+func (srv *Server) handleGetAlerts(r *http.Request, ps params) (interface{}, int, error) {
+	alerts, sc, err := srv.filterAlerts("", "", time.Time{})
+	if err != nil {
+		return nil, sc, err
+	}
+	return pageAlerts(r, alerts)
+}
+
+// GET /streams/alerts/paginated Get alarms of all streams, filtered by specifying limit and offset parameters
+func (srv *Server) handleGetAlertsPaginated(r *http.Request, ps params) (interface{}, int, error) {
+	alerts, sc, err := srv.filterAlerts("", r.URL.Query().Get("state"), time.Time{})
+	if err != nil {
+		return nil, sc, err
+	}
+	return pageAlerts(r, alerts)
+}
+
+// GET /streams/alerts/{alertId} Get an alert by ID
+func (srv *Server) handleGetAlert(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["alertID"]
+	alert, ok := srv.alerts[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("alert <%s> not found", id)
+	}
+	return &alert, http.StatusOK, nil
+}
+
+// GET /streams/{streamId}/alerts Get the most recent alarms of this stream
+func (srv *Server) handleGetStreamAlerts(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	since, err := queryInt(r, "since", 0)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	var t time.Time
+	if since > 0 {
+		t = time.Unix(int64(since), 0)
+	}
+	alerts, sc, err := srv.filterAlerts(stream.ID, "", t)
+	if err != nil {
+		return nil, sc, err
+	}
+	limit, err := queryInt(r, "limit", 0)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	total := len(alerts)
+	if limit > 0 && limit < total {
+		alerts = alerts[:limit]
+	}
+	return &graylog.AlertsBody{Alerts: alerts, Total: total}, http.StatusOK, nil
+}
+
+// GET /streams/{streamId}/alerts/paginated Get the alarms of this stream, filtered by specifying limit and offset parameters
+func (srv *Server) handleGetStreamAlertsPaginated(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	alerts, sc, err := srv.filterAlerts(stream.ID, r.URL.Query().Get("state"), time.Time{})
+	if err != nil {
+		return nil, sc, err
+	}
+	return pageAlerts(r, alerts)
+}
+
+// POST /streams/{streamId}/alerts/receivers Add an alert receiver
+func (srv *Server) handleAddStreamAlertReceiver(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.updateStreamAlertReceivers(r, ps["streamID"], true)
+}
+
+// DELETE /streams/{streamId}/alerts/receivers Remove an alert receiver
+func (srv *Server) handleRemoveStreamAlertReceiver(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.updateStreamAlertReceivers(r, ps["streamID"], false)
+}
+
+func (srv *Server) updateStreamAlertReceivers(r *http.Request, streamID string, add bool) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(streamID)
+	if err != nil {
+		return nil, sc, err
+	}
+	q := r.URL.Query()
+	entity := q.Get("entity")
+	if entity == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("entity is required")
+	}
+	receivers := graylog.AlertReceivers{}
+	if stream.AlertReceivers != nil {
+		receivers = *stream.AlertReceivers
+	}
+	var list *[]string
+	switch q.Get("type") {
+	case graylog.AlertReceiverTypeUsers:
+		list = &receivers.Users
+	case graylog.AlertReceiverTypeEmails:
+		list = &receivers.Emails
+	default:
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid alert receiver type: %s", q.Get("type"))
+	}
+	entities := make([]string, 0, len(*list)+1)
+	for _, e := range *list {
+		if e != entity {
+			entities = append(entities, e)
+		}
+	}
+	if add {
+		entities = append(entities, entity)
+	}
+	*list = entities
+	stream.AlertReceivers = &receivers
+	srv.streams[stream.ID] = *stream
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,167 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// alertConditionTypes are the alert condition types which Graylog supports.
+var alertConditionTypes = map[string]graylog.AlertConditionTypeInfo{
+	"field_content_value": {
+		Name:                   "Field Content Alert Condition",
+		HumanName:              "Field Content Alert Condition",
+		RequestedConfiguration: map[string]graylog.ConfigurationField{},
+	},
+	"field_value": {
+		Name:                   "Field Aggregation Alert Condition",
+		HumanName:              "Field Aggregation Alert Condition",
+		RequestedConfiguration: map[string]graylog.ConfigurationField{},
+	},
+	"message_count": {
+		Name:                   "Message Count Alert Condition",
+		HumanName:              "Message Count Alert Condition",
+		RequestedConfiguration: map[string]graylog.ConfigurationField{},
+	},
+}
+
+func (srv *Server) getAlertCondition(streamID, id string) (*graylog.Stream, int, int, error) {
+	stream, sc, err := srv.getStream(streamID)
+	if err != nil {
+		return nil, -1, sc, err
+	}
+	for i, cond := range stream.AlertConditions {
+		if cond.ID == id {
+			return stream, i, http.StatusOK, nil
+		}
+	}
+	return nil, -1, http.StatusNotFound, fmt.Errorf("alert condition <%s> not found", id)
+}
+
+func (srv *Server) decodeAlertCondition(r *http.Request, isCreate bool) (*graylog.AlertCondition, int, error) {
+	cond := &graylog.AlertCondition{}
+	if sc, err := decodeBody(r, cond); err != nil {
+		return nil, sc, err
+	}
+	v := validator.UpdateValidator
+	if isCreate {
+		v = validator.CreateValidator
+	}
+	if err := v.Struct(cond); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err := v.Struct(cond.Parameters); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return cond, http.StatusOK, nil
+}
+
+// GET /alerts/conditions Get a list of all alert conditions
+func (srv *Server) handleGetAlertConditions(r *http.Request, ps params) (interface{}, int, error) {
+	conds := []graylog.AlertCondition{}
+	for _, stream := range srv.streams {
+		conds = append(conds, stream.AlertConditions...)
+	}
+	sort.Slice(conds, func(i, j int) bool {
+		return conds[i].ID < conds[j].ID
+	})
+	return &graylog.AlertConditionsBody{AlertConditions: conds, Total: len(conds)}, http.StatusOK, nil
+}
+
+// GET /alerts/conditions/types Get a list of all alert condition types
+func (srv *Server) handleGetAlertConditionTypes(r *http.Request, ps params) (interface{}, int, error) {
+	return alertConditionTypes, http.StatusOK, nil
+}
+
+// GET /streams/{streamId}/alerts/conditions Get all alert conditions of this stream
+func (srv *Server) handleGetStreamAlertConditions(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	conds := stream.AlertConditions
+	if conds == nil {
+		conds = []graylog.AlertCondition{}
+	}
+	return &graylog.AlertConditionsBody{AlertConditions: conds, Total: len(conds)}, http.StatusOK, nil
+}
+
+// GET /streams/{streamId}/alerts/conditions/{conditionId} Get an alert condition
+func (srv *Server) handleGetStreamAlertCondition(r *http.Request, ps params) (interface{}, int, error) {
+	stream, i, sc, err := srv.getAlertCondition(ps["streamID"], ps["alertConditionID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	return &stream.AlertConditions[i], http.StatusOK, nil
+}
+
+// POST /streams/{streamId}/alerts/conditions Create an alert condition
+func (srv *Server) handleCreateStreamAlertCondition(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	cond, sc, err := srv.decodeAlertCondition(r, true)
+	if err != nil {
+		return nil, sc, err
+	}
+	cond.ID = newUUID()
+	cond.CreatedAt = now()
+	cond.CreatorUserID = srv.creatorUserID()
+	conds := make([]graylog.AlertCondition, len(stream.AlertConditions), len(stream.AlertConditions)+1)
+	copy(conds, stream.AlertConditions)
+	stream.AlertConditions = append(conds, *cond)
+	srv.streams[stream.ID] = *stream
+	return map[string]string{"alert_condition_id": cond.ID}, http.StatusCreated, nil
+}
+
+// PUT /streams/{streamId}/alerts/conditions/{conditionId} Modify an alert condition
+func (srv *Server) handleUpdateStreamAlertCondition(r *http.Request, ps params) (interface{}, int, error) {
+	stream, i, sc, err := srv.getAlertCondition(ps["streamID"], ps["alertConditionID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	cond, sc, err := srv.decodeAlertCondition(r, false)
+	if err != nil {
+		return nil, sc, err
+	}
+	orig := stream.AlertConditions[i]
+	if cond.Type() != orig.Type() {
+		return nil, http.StatusBadRequest, fmt.Errorf("the type of alert condition cannot be changed")
+	}
+	cond.ID = orig.ID
+	cond.CreatedAt = orig.CreatedAt
+	cond.CreatorUserID = orig.CreatorUserID
+	conds := make([]graylog.AlertCondition, len(stream.AlertConditions))
+	copy(conds, stream.AlertConditions)
+	conds[i] = *cond
+	stream.AlertConditions = conds
+	srv.streams[stream.ID] = *stream
+	return nil, http.StatusNoContent, nil
+}
+
+// DELETE /streams/{streamId}/alerts/conditions/{conditionId} Delete an alert condition
+func (srv *Server) handleDeleteStreamAlertCondition(r *http.Request, ps params) (interface{}, int, error) {
+	stream, i, sc, err := srv.getAlertCondition(ps["streamID"], ps["alertConditionID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	conds := make([]graylog.AlertCondition, 0, len(stream.AlertConditions)-1)
+	conds = append(conds, stream.AlertConditions[:i]...)
+	stream.AlertConditions = append(conds, stream.AlertConditions[i+1:]...)
+	srv.streams[stream.ID] = *stream
+	return nil, http.StatusNoContent, nil
+}
+
+// POST /streams/{streamId}/alerts/conditions/{conditionId}/test Test an alert condition
+//
+// The mock server has no message, so the alert condition is never triggered.
+func (srv *Server) handleTestStreamAlertCondition(r *http.Request, ps params) (interface{}, int, error) {
+	if _, _, sc, err := srv.getAlertCondition(ps["streamID"], ps["alertConditionID"]); err != nil {
+		return nil, sc, err
+	}
+	return &graylog.AlertConditionTestResult{}, http.StatusOK, nil
+}
@@ -0,0 +1,322 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-set"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+func (srv *Server) getCollectorConfiguration(id string) (*graylog.CollectorConfiguration, int, error) {
+	cfg, ok := srv.collectorConfigurations[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("collector configuration <%s> not found", id)
+	}
+	return &cfg, http.StatusOK, nil
+}
+
+// collectorConfigurationResponse returns a collector configuration whose nil lists are empty.
+func collectorConfigurationResponse(cfg graylog.CollectorConfiguration) *graylog.CollectorConfiguration {
+	if cfg.Tags == nil {
+		cfg.Tags = set.StrSet{}
+	}
+	if cfg.Inputs == nil {
+		cfg.Inputs = []graylog.CollectorConfigurationInput{}
+	}
+	if cfg.Outputs == nil {
+		cfg.Outputs = []graylog.CollectorConfigurationOutput{}
+	}
+	if cfg.Snippets == nil {
+		cfg.Snippets = []graylog.CollectorConfigurationSnippet{}
+	}
+	return &cfg
+}
+
+// GET /plugins/org.graylog.plugins.collector/configurations List all collector configurations
+func (srv *Server) handleGetCollectorConfigurations(r *http.Request, ps params) (interface{}, int, error) {
+	cfgs := make([]graylog.CollectorConfiguration, 0, len(srv.collectorConfigurations))
+	for _, cfg := range srv.collectorConfigurations {
+		cfgs = append(cfgs, *collectorConfigurationResponse(cfg))
+	}
+	sort.Slice(cfgs, func(i, j int) bool {
+		return cfgs[i].ID < cfgs[j].ID
+	})
+	return &graylog.CollectorConfigurationsBody{Configurations: cfgs, Total: len(cfgs)}, http.StatusOK, nil
+}
+
+// GET /plugins/org.graylog.plugins.collector/configurations/{id} Show collector configuration details
+func (srv *Server) handleGetCollectorConfiguration(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	return collectorConfigurationResponse(*cfg), http.StatusOK, nil
+}
+
+// POST /plugins/org.graylog.plugins.collector/configurations Create new collector configuration
+func (srv *Server) handleCreateCollectorConfiguration(r *http.Request, ps params) (interface{}, int, error) {
+	cfg := &graylog.CollectorConfiguration{}
+	if sc, err := decodeBody(r, cfg); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.CreateValidator.Struct(cfg); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	cfg.ID = newObjectID()
+	for i := range cfg.Inputs {
+		if cfg.Inputs[i].InputID == "" {
+			cfg.Inputs[i].InputID = newObjectID()
+		}
+	}
+	for i := range cfg.Outputs {
+		if cfg.Outputs[i].OutputID == "" {
+			cfg.Outputs[i].OutputID = newObjectID()
+		}
+	}
+	for i := range cfg.Snippets {
+		if cfg.Snippets[i].SnippetID == "" {
+			cfg.Snippets[i].SnippetID = newObjectID()
+		}
+	}
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return collectorConfigurationResponse(*cfg), http.StatusOK, nil
+}
+
+// PUT /plugins/org.graylog.plugins.collector/configurations/{id}/name Updates a collector configuration name
+func (srv *Server) handleRenameCollectorConfiguration(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	body := &graylog.CollectorConfiguration{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	if body.Name == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("name is required")
+	}
+	cfg.Name = body.Name
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return collectorConfigurationResponse(*cfg), http.StatusAccepted, nil
+}
+
+// DELETE /plugins/org.graylog.plugins.collector/configurations/{id} Delete a collector configuration
+func (srv *Server) handleDeleteCollectorConfiguration(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	delete(srv.collectorConfigurations, cfg.ID)
+	return nil, http.StatusNoContent, nil
+}
+
+func findCollectorConfigurationInput(cfg *graylog.CollectorConfiguration, id string) (int, int, error) {
+	for i, input := range cfg.Inputs {
+		if input.InputID == id {
+			return i, http.StatusOK, nil
+		}
+	}
+	return -1, http.StatusNotFound, fmt.Errorf("input <%s> not found in collector configuration <%s>", id, cfg.ID)
+}
+
+func findCollectorConfigurationOutput(cfg *graylog.CollectorConfiguration, id string) (int, int, error) {
+	for i, output := range cfg.Outputs {
+		if output.OutputID == id {
+			return i, http.StatusOK, nil
+		}
+	}
+	return -1, http.StatusNotFound, fmt.Errorf("output <%s> not found in collector configuration <%s>", id, cfg.ID)
+}
+
+func findCollectorConfigurationSnippet(cfg *graylog.CollectorConfiguration, id string) (int, int, error) {
+	for i, snippet := range cfg.Snippets {
+		if snippet.SnippetID == id {
+			return i, http.StatusOK, nil
+		}
+	}
+	return -1, http.StatusNotFound, fmt.Errorf("snippet <%s> not found in collector configuration <%s>", id, cfg.ID)
+}
+
+// POST /plugins/org.graylog.plugins.collector/configurations/{id}/inputs Create a configuration input
+func (srv *Server) handleCreateCollectorConfigurationInput(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	input := graylog.CollectorConfigurationInput{}
+	if sc, err := decodeBody(r, &input); err != nil {
+		return nil, sc, err
+	}
+	if input.InputID == "" {
+		input.InputID = newObjectID()
+	}
+	inputs := make([]graylog.CollectorConfigurationInput, len(cfg.Inputs), len(cfg.Inputs)+1)
+	copy(inputs, cfg.Inputs)
+	cfg.Inputs = append(inputs, input)
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusAccepted, nil
+}
+
+// PUT /plugins/org.graylog.plugins.collector/configurations/{id}/inputs/{input_id} Update a configuration input
+func (srv *Server) handleUpdateCollectorConfigurationInput(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	i, sc, err := findCollectorConfigurationInput(cfg, ps["inputID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	input := graylog.CollectorConfigurationInput{}
+	if sc, err := decodeBody(r, &input); err != nil {
+		return nil, sc, err
+	}
+	input.InputID = cfg.Inputs[i].InputID
+	inputs := make([]graylog.CollectorConfigurationInput, len(cfg.Inputs))
+	copy(inputs, cfg.Inputs)
+	inputs[i] = input
+	cfg.Inputs = inputs
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusAccepted, nil
+}
+
+// DELETE /plugins/org.graylog.plugins.collector/configurations/{id}/inputs/{input_id} Delete input from configuration
+func (srv *Server) handleDeleteCollectorConfigurationInput(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	i, sc, err := findCollectorConfigurationInput(cfg, ps["inputID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	inputs := make([]graylog.CollectorConfigurationInput, 0, len(cfg.Inputs)-1)
+	inputs = append(inputs, cfg.Inputs[:i]...)
+	cfg.Inputs = append(inputs, cfg.Inputs[i+1:]...)
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusNoContent, nil
+}
+
+// POST /plugins/org.graylog.plugins.collector/configurations/{id}/outputs Create a configuration output
+func (srv *Server) handleCreateCollectorConfigurationOutput(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	output := graylog.CollectorConfigurationOutput{}
+	if sc, err := decodeBody(r, &output); err != nil {
+		return nil, sc, err
+	}
+	if output.OutputID == "" {
+		output.OutputID = newObjectID()
+	}
+	outputs := make([]graylog.CollectorConfigurationOutput, len(cfg.Outputs), len(cfg.Outputs)+1)
+	copy(outputs, cfg.Outputs)
+	cfg.Outputs = append(outputs, output)
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusAccepted, nil
+}
+
+// PUT /plugins/org.graylog.plugins.collector/configurations/{id}/outputs/{output_id} Update a configuration output
+func (srv *Server) handleUpdateCollectorConfigurationOutput(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	i, sc, err := findCollectorConfigurationOutput(cfg, ps["outputID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	output := graylog.CollectorConfigurationOutput{}
+	if sc, err := decodeBody(r, &output); err != nil {
+		return nil, sc, err
+	}
+	output.OutputID = cfg.Outputs[i].OutputID
+	outputs := make([]graylog.CollectorConfigurationOutput, len(cfg.Outputs))
+	copy(outputs, cfg.Outputs)
+	outputs[i] = output
+	cfg.Outputs = outputs
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusAccepted, nil
+}
+
+// DELETE /plugins/org.graylog.plugins.collector/configurations/{id}/outputs/{output_id} Delete output from configuration
+func (srv *Server) handleDeleteCollectorConfigurationOutput(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	i, sc, err := findCollectorConfigurationOutput(cfg, ps["outputID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	outputs := make([]graylog.CollectorConfigurationOutput, 0, len(cfg.Outputs)-1)
+	outputs = append(outputs, cfg.Outputs[:i]...)
+	cfg.Outputs = append(outputs, cfg.Outputs[i+1:]...)
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusNoContent, nil
+}
+
+// POST /plugins/org.graylog.plugins.collector/configurations/{id}/snippets Create a configuration snippet
+func (srv *Server) handleCreateCollectorConfigurationSnippet(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	snippet := graylog.CollectorConfigurationSnippet{}
+	if sc, err := decodeBody(r, &snippet); err != nil {
+		return nil, sc, err
+	}
+	if snippet.SnippetID == "" {
+		snippet.SnippetID = newObjectID()
+	}
+	snippets := make([]graylog.CollectorConfigurationSnippet, len(cfg.Snippets), len(cfg.Snippets)+1)
+	copy(snippets, cfg.Snippets)
+	cfg.Snippets = append(snippets, snippet)
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusAccepted, nil
+}
+
+// PUT /plugins/org.graylog.plugins.collector/configurations/{id}/snippets/{snippet_id} Update a configuration snippet
+func (srv *Server) handleUpdateCollectorConfigurationSnippet(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	i, sc, err := findCollectorConfigurationSnippet(cfg, ps["snippetID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	snippet := graylog.CollectorConfigurationSnippet{}
+	if sc, err := decodeBody(r, &snippet); err != nil {
+		return nil, sc, err
+	}
+	snippet.SnippetID = cfg.Snippets[i].SnippetID
+	snippets := make([]graylog.CollectorConfigurationSnippet, len(cfg.Snippets))
+	copy(snippets, cfg.Snippets)
+	snippets[i] = snippet
+	cfg.Snippets = snippets
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusAccepted, nil
+}
+
+// DELETE /plugins/org.graylog.plugins.collector/configurations/{id}/snippets/{snippet_id} Delete snippet from configuration
+func (srv *Server) handleDeleteCollectorConfigurationSnippet(r *http.Request, ps params) (interface{}, int, error) {
+	cfg, sc, err := srv.getCollectorConfiguration(ps["collectorConfigurationID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	i, sc, err := findCollectorConfigurationSnippet(cfg, ps["snippetID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	snippets := make([]graylog.CollectorConfigurationSnippet, 0, len(cfg.Snippets)-1)
+	snippets = append(snippets, cfg.Snippets[:i]...)
+	cfg.Snippets = append(snippets, cfg.Snippets[i+1:]...)
+	srv.collectorConfigurations[cfg.ID] = *cfg
+	return nil, http.StatusNoContent, nil
+}
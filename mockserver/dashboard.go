@@ -0,0 +1,163 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+type (
+	// dashboardResponse is a dashboard in the format of Graylog API.
+	// Graylog API returns widget positions as a map whose key is the widget id.
+	dashboardResponse struct {
+		ID            string                                     `json:"id"`
+		Title         string                                     `json:"title"`
+		Description   string                                     `json:"description"`
+		CreatedAt     string                                     `json:"created_at"`
+		CreatorUserID string                                     `json:"creator_user_id"`
+		Widgets       []graylog.Widget                           `json:"widgets"`
+		Positions     map[string]graylog.DashboardWidgetPosition `json:"positions"`
+	}
+
+	dashboardsResponse struct {
+		Dashboards []dashboardResponse `json:"dashboards"`
+		Total      int                 `json:"total"`
+	}
+
+	dashboardRequestBody struct {
+		Title       string `json:"title" v-create:"required"`
+		Description string `json:"description"`
+	}
+
+	dashboardPositionsRequestBody struct {
+		Positions []graylog.DashboardWidgetPosition `json:"positions"`
+	}
+)
+
+func newDashboardResponse(dashboard graylog.Dashboard) dashboardResponse {
+	widgets := dashboard.Widgets
+	if widgets == nil {
+		widgets = []graylog.Widget{}
+	}
+	positions := make(map[string]graylog.DashboardWidgetPosition, len(dashboard.Positions))
+	for _, p := range dashboard.Positions {
+		positions[p.WidgetID] = p
+	}
+	return dashboardResponse{
+		ID:            dashboard.ID,
+		Title:         dashboard.Title,
+		Description:   dashboard.Description,
+		CreatedAt:     dashboard.CreatedAt,
+		CreatorUserID: dashboard.CreatorUserID,
+		Widgets:       widgets,
+		Positions:     positions,
+	}
+}
+
+func (srv *Server) getDashboard(id string) (*graylog.Dashboard, int, error) {
+	dashboard, ok := srv.dashboards[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("dashboard <%s> not found", id)
+	}
+	return &dashboard, http.StatusOK, nil
+}
+
+func decodeDashboardRequestBody(r *http.Request) (*dashboardRequestBody, int, error) {
+	body := &dashboardRequestBody{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.CreateValidator.Struct(body); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return body, http.StatusOK, nil
+}
+
+// GET /dashboards Get a list of all dashboards
+func (srv *Server) handleGetDashboards(r *http.Request, ps params) (interface{}, int, error) {
+	dashboards := make([]dashboardResponse, 0, len(srv.dashboards))
+	for _, dashboard := range srv.dashboards {
+		dashboards = append(dashboards, newDashboardResponse(dashboard))
+	}
+	sort.Slice(dashboards, func(i, j int) bool {
+		return dashboards[i].ID < dashboards[j].ID
+	})
+	return &dashboardsResponse{Dashboards: dashboards, Total: len(dashboards)}, http.StatusOK, nil
+}
+
+// GET /dashboards/{dashboardId} Get a single dashboards and all configurations of its widgets
+func (srv *Server) handleGetDashboard(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, sc, err := srv.getDashboard(ps["dashboardID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	return newDashboardResponse(*dashboard), http.StatusOK, nil
+}
+
+// POST /dashboards Create a dashboard
+func (srv *Server) handleCreateDashboard(r *http.Request, ps params) (interface{}, int, error) {
+	body, sc, err := decodeDashboardRequestBody(r)
+	if err != nil {
+		return nil, sc, err
+	}
+	dashboard := graylog.Dashboard{
+		ID:            newObjectID(),
+		Title:         body.Title,
+		Description:   body.Description,
+		CreatedAt:     now(),
+		CreatorUserID: srv.creatorUserID(),
+	}
+	srv.dashboards[dashboard.ID] = dashboard
+	return map[string]string{"dashboard_id": dashboard.ID}, http.StatusCreated, nil
+}
+
+// PUT /dashboards/{dashboardId} Update the settings of a dashboard
+func (srv *Server) handleUpdateDashboard(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, sc, err := srv.getDashboard(ps["dashboardID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	body, sc, err := decodeDashboardRequestBody(r)
+	if err != nil {
+		return nil, sc, err
+	}
+	dashboard.Title = body.Title
+	dashboard.Description = body.Description
+	srv.dashboards[dashboard.ID] = *dashboard
+	return nil, http.StatusNoContent, nil
+}
+
+// DELETE /dashboards/{dashboardId} Delete a dashboard and all its widgets
+func (srv *Server) handleDeleteDashboard(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, sc, err := srv.getDashboard(ps["dashboardID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	delete(srv.dashboards, dashboard.ID)
+	return nil, http.StatusNoContent, nil
+}
+
+// PUT /dashboards/{dashboardId}/positions Update/set the positions of dashboard widgets
+func (srv *Server) handleUpdateDashboardWidgetPositions(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, sc, err := srv.getDashboard(ps["dashboardID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	body := &dashboardPositionsRequestBody{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	for _, p := range body.Positions {
+		if _, sc, err := getDashboardWidget(dashboard, p.WidgetID); err != nil {
+			return nil, sc, err
+		}
+	}
+	positions := make([]graylog.DashboardWidgetPosition, len(body.Positions))
+	copy(positions, body.Positions)
+	dashboard.Positions = positions
+	srv.dashboards[dashboard.ID] = *dashboard
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,62 @@
+package mockserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func TestDashboard(t *testing.T) {
+	server, cl := newServerAndClient(t, nil)
+	defer server.Close()
+	ctx := context.Background()
+
+	dashboard := &graylog.Dashboard{Title: "test", Description: "test"}
+	_, err := cl.CreateDashboard(ctx, dashboard)
+	require.Nil(t, err)
+	require.NotEmpty(t, dashboard.ID)
+
+	widget, _, err := cl.CreateDashboardWidget(ctx, dashboard.ID, graylog.Widget{
+		Description: "message count",
+		Config: &graylog.WidgetConfigStreamSearchResultCount{
+			Timerange: &graylog.Timerange{Type: "relative", Range: 300},
+		},
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, widget.ID)
+
+	positions := []graylog.DashboardWidgetPosition{{
+		WidgetID: widget.ID, Width: 1, Col: 2, Row: 3, Height: 4,
+	}}
+	_, err = cl.UpdateDashboardWidgetPositions(ctx, dashboard.ID, positions)
+	require.Nil(t, err)
+
+	d, _, err := cl.GetDashboard(ctx, dashboard.ID)
+	require.Nil(t, err)
+	require.Len(t, d.Widgets, 1)
+	require.Equal(t, "STREAM_SEARCH_RESULT_COUNT", d.Widgets[0].Type())
+	require.Equal(t, positions, d.Positions)
+
+	// a widget which doesn't exist can't be positioned
+	_, err = cl.UpdateDashboardWidgetPositions(ctx, dashboard.ID, []graylog.DashboardWidgetPosition{{WidgetID: "foo"}})
+	require.NotNil(t, err)
+
+	_, err = cl.UpdateDashboardWidgetDescription(ctx, dashboard.ID, widget.ID, "updated")
+	require.Nil(t, err)
+	w, _, err := cl.GetDashboardWidget(ctx, dashboard.ID, widget.ID)
+	require.Nil(t, err)
+	require.Equal(t, "updated", w.Description)
+
+	_, err = cl.DeleteDashboardWidget(ctx, dashboard.ID, widget.ID)
+	require.Nil(t, err)
+	d, _, err = cl.GetDashboard(ctx, dashboard.ID)
+	require.Nil(t, err)
+	require.Empty(t, d.Widgets)
+	require.Empty(t, d.Positions)
+
+	_, err = cl.DeleteDashboard(ctx, dashboard.ID)
+	require.Nil(t, err)
+}
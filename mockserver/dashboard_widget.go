@@ -0,0 +1,164 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// defaultWidgetCacheTime is the cache time of a widget which Graylog sets by default.
+const defaultWidgetCacheTime = 10
+
+func getDashboardWidget(dashboard *graylog.Dashboard, id string) (int, int, error) {
+	for i, widget := range dashboard.Widgets {
+		if widget.ID == id {
+			return i, http.StatusOK, nil
+		}
+	}
+	return -1, http.StatusNotFound, fmt.Errorf("widget <%s> not found in dashboard <%s>", id, dashboard.ID)
+}
+
+func (srv *Server) getWidget(dashboardID, widgetID string) (*graylog.Dashboard, int, int, error) {
+	dashboard, sc, err := srv.getDashboard(dashboardID)
+	if err != nil {
+		return nil, -1, sc, err
+	}
+	i, sc, err := getDashboardWidget(dashboard, widgetID)
+	if err != nil {
+		return nil, -1, sc, err
+	}
+	return dashboard, i, http.StatusOK, nil
+}
+
+// setWidget replaces the i-th widget of the dashboard.
+func (srv *Server) setWidget(dashboard *graylog.Dashboard, i int, widget graylog.Widget) {
+	widgets := make([]graylog.Widget, len(dashboard.Widgets))
+	copy(widgets, dashboard.Widgets)
+	widgets[i] = widget
+	dashboard.Widgets = widgets
+	srv.dashboards[dashboard.ID] = *dashboard
+}
+
+func decodeWidget(r *http.Request) (*graylog.Widget, int, error) {
+	widget := &graylog.Widget{}
+	if sc, err := decodeBody(r, widget); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.CreateValidator.Struct(widget); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return widget, http.StatusOK, nil
+}
+
+// GET /dashboards/{dashboardId}/widgets/{widgetId} Get a single widget
+func (srv *Server) handleGetDashboardWidget(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, i, sc, err := srv.getWidget(ps["dashboardID"], ps["widgetID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	return &dashboard.Widgets[i], http.StatusOK, nil
+}
+
+// POST /dashboards/{dashboardId}/widgets Add a widget to a dashboard
+func (srv *Server) handleCreateDashboardWidget(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, sc, err := srv.getDashboard(ps["dashboardID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	widget, sc, err := decodeWidget(r)
+	if err != nil {
+		return nil, sc, err
+	}
+	widget.ID = newUUID()
+	widget.CreatorUserID = srv.creatorUserID()
+	cacheTime := defaultWidgetCacheTime
+	widget.CacheTime = &cacheTime
+	widgets := make([]graylog.Widget, len(dashboard.Widgets), len(dashboard.Widgets)+1)
+	copy(widgets, dashboard.Widgets)
+	dashboard.Widgets = append(widgets, *widget)
+	srv.dashboards[dashboard.ID] = *dashboard
+	return map[string]string{"widget_id": widget.ID}, http.StatusCreated, nil
+}
+
+// PUT /dashboards/{dashboardId}/widgets/{widgetId} Update a widget
+func (srv *Server) handleUpdateDashboardWidget(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, i, sc, err := srv.getWidget(ps["dashboardID"], ps["widgetID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	widget, sc, err := decodeWidget(r)
+	if err != nil {
+		return nil, sc, err
+	}
+	orig := dashboard.Widgets[i]
+	widget.ID = orig.ID
+	widget.CreatorUserID = orig.CreatorUserID
+	widget.CacheTime = orig.CacheTime
+	srv.setWidget(dashboard, i, *widget)
+	return nil, http.StatusNoContent, nil
+}
+
+// DELETE /dashboards/{dashboardId}/widgets/{widgetId} Delete a widget
+func (srv *Server) handleDeleteDashboardWidget(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, i, sc, err := srv.getWidget(ps["dashboardID"], ps["widgetID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	id := dashboard.Widgets[i].ID
+	widgets := make([]graylog.Widget, 0, len(dashboard.Widgets)-1)
+	widgets = append(widgets, dashboard.Widgets[:i]...)
+	dashboard.Widgets = append(widgets, dashboard.Widgets[i+1:]...)
+	positions := make([]graylog.DashboardWidgetPosition, 0, len(dashboard.Positions))
+	for _, p := range dashboard.Positions {
+		if p.WidgetID != id {
+			positions = append(positions, p)
+		}
+	}
+	dashboard.Positions = positions
+	srv.dashboards[dashboard.ID] = *dashboard
+	return nil, http.StatusNoContent, nil
+}
+
+// PUT /dashboards/{dashboardId}/widgets/{widgetId}/cachetime Update cache time of a widget
+func (srv *Server) handleUpdateDashboardWidgetCacheTime(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, i, sc, err := srv.getWidget(ps["dashboardID"], ps["widgetID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	body := &struct {
+		CacheTime *int `json:"cache_time"`
+	}{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	if body.CacheTime == nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("cache_time is required")
+	}
+	widget := dashboard.Widgets[i]
+	widget.CacheTime = body.CacheTime
+	srv.setWidget(dashboard, i, widget)
+	return nil, http.StatusNoContent, nil
+}
+
+// PUT /dashboards/{dashboardId}/widgets/{widgetId}/description Update description of a widget
+func (srv *Server) handleUpdateDashboardWidgetDescription(r *http.Request, ps params) (interface{}, int, error) {
+	dashboard, i, sc, err := srv.getWidget(ps["dashboardID"], ps["widgetID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	body := &struct {
+		Description string `json:"description"`
+	}{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	if body.Description == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("description is required")
+	}
+	widget := dashboard.Widgets[i]
+	widget.Description = body.Description
+	srv.setWidget(dashboard, i, widget)
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,23 @@
+/*
+Package mockserver provides an in-memory mock server of the Graylog API.
+
+The mock server implements the APIs which client.Client calls.
+Request bodies are validated with the validator package and
+errors are returned with the same response body as Graylog's,
+so client.ErrorInfo is set as it is with the real Graylog API.
+
+	server, err := mockserver.NewServer("", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Start()
+	defer server.Close()
+	cl, err := client.NewClient(server.Endpoint(), "admin", "admin")
+
+The server is seeded with DefaultSeed, which has the user "admin" whose password is "admin",
+the roles "Admin" and "Reader", the default index set and the default stream.
+To seed the server with the fixtures of the testdata package, use TestdataSeed.
+
+	server, err := mockserver.NewServer("", mockserver.TestdataSeed())
+*/
+package mockserver
@@ -0,0 +1,36 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// APIError represents a Graylog API's error response body.
+type APIError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// NewAPIError returns a new APIError.
+func NewAPIError(msg string) *APIError {
+	return &APIError{Type: "ApiError", Message: msg}
+}
+
+func writeJSON(w http.ResponseWriter, sc int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(sc)
+		return
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		sc = http.StatusInternalServerError
+		b, _ = json.Marshal(NewAPIError("failed to encode the response body: " + err.Error()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(sc)
+	w.Write(b) // nolint: errcheck
+}
+
+func writeError(w http.ResponseWriter, sc int, msg string) {
+	writeJSON(w, sc, NewAPIError(msg))
+}
@@ -0,0 +1,180 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// extractorRequestBody is the request body of the create and update extractor APIs.
+type extractorRequestBody struct {
+	Title           string                                       `json:"title"`
+	CutOrCopy       string                                       `json:"cut_or_copy"`
+	SourceField     string                                       `json:"source_field"`
+	TargetField     string                                       `json:"target_field"`
+	ExtractorType   string                                       `json:"extractor_type"`
+	ExtractorConfig json.RawMessage                              `json:"extractor_config"`
+	Converters      map[string]*graylog.ExtractorConverterConfig `json:"converters"`
+	ConditionType   string                                       `json:"condition_type"`
+	ConditionValue  string                                       `json:"condition_value"`
+	Order           int                                          `json:"order"`
+}
+
+// toExtractor converts the request body to an extractor and validates it.
+func (body *extractorRequestBody) toExtractor() (*graylog.Extractor, int, error) {
+	if body.Title == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("title is required")
+	}
+	if body.ExtractorType == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("extractor_type is required")
+	}
+	if body.SourceField == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("source_field is required")
+	}
+	cfg := body.ExtractorConfig
+	if len(cfg) == 0 {
+		cfg = json.RawMessage("{}")
+	}
+	// decode the extractor config into the struct of the extractor type
+	b, err := json.Marshal(map[string]interface{}{
+		"type":             body.ExtractorType,
+		"extractor_config": cfg,
+	})
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	extractor := &graylog.Extractor{}
+	if err := json.Unmarshal(b, extractor); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("extractor_config is invalid: %v", err)
+	}
+	extractor.Title = body.Title
+	extractor.CursorStrategy = body.CutOrCopy
+	if extractor.CursorStrategy == "" {
+		extractor.CursorStrategy = "copy"
+	}
+	extractor.SourceField = body.SourceField
+	extractor.TargetField = body.TargetField
+	extractor.ConditionType = body.ConditionType
+	if extractor.ConditionType == "" {
+		extractor.ConditionType = "none"
+	}
+	extractor.ConditionValue = body.ConditionValue
+	extractor.Order = body.Order
+	types := make([]string, 0, len(body.Converters))
+	for t := range body.Converters {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	extractor.Converters = make([]graylog.ExtractorConverter, len(types))
+	for i, t := range types {
+		cfg := body.Converters[t]
+		if cfg == nil {
+			cfg = &graylog.ExtractorConverterConfig{}
+		}
+		extractor.Converters[i] = graylog.ExtractorConverter{Type: t, Config: cfg}
+	}
+	return extractor, http.StatusOK, nil
+}
+
+func (srv *Server) getExtractor(inputID, extractorID string) (*graylog.Extractor, int, error) {
+	if _, ok := srv.inputs[inputID]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no input found with id %s", inputID)
+	}
+	extractor, ok := srv.extractors[inputID][extractorID]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't find extractor %s", extractorID)
+	}
+	return &extractor, http.StatusOK, nil
+}
+
+func (srv *Server) setExtractor(inputID string, extractor graylog.Extractor) {
+	m := make(map[string]graylog.Extractor, len(srv.extractors[inputID])+1)
+	for id, e := range srv.extractors[inputID] {
+		m[id] = e
+	}
+	m[extractor.ID] = extractor
+	srv.extractors[inputID] = m
+}
+
+// GET /system/inputs/{inputId}/extractors List all extractors of an input
+func (srv *Server) handleGetExtractors(r *http.Request, ps params) (interface{}, int, error) {
+	inputID := ps["inputID"]
+	if _, ok := srv.inputs[inputID]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no input found with id %s", inputID)
+	}
+	extractors := make([]graylog.Extractor, 0, len(srv.extractors[inputID]))
+	for _, extractor := range srv.extractors[inputID] {
+		extractors = append(extractors, extractor)
+	}
+	sort.Slice(extractors, func(i, j int) bool {
+		if extractors[i].Order != extractors[j].Order {
+			return extractors[i].Order < extractors[j].Order
+		}
+		return extractors[i].ID < extractors[j].ID
+	})
+	return &graylog.ExtractorsBody{Extractors: extractors, Total: len(extractors)}, http.StatusOK, nil
+}
+
+// GET /system/inputs/{inputId}/extractors/{extractorId} Get information of a single extractor of an input
+func (srv *Server) handleGetExtractor(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.getExtractor(ps["inputID"], ps["extractorID"])
+}
+
+// POST /system/inputs/{inputId}/extractors Add an extractor to an input
+func (srv *Server) handleCreateExtractor(r *http.Request, ps params) (interface{}, int, error) {
+	inputID := ps["inputID"]
+	if _, ok := srv.inputs[inputID]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no input found with id %s", inputID)
+	}
+	body := &extractorRequestBody{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	extractor, sc, err := body.toExtractor()
+	if err != nil {
+		return nil, sc, err
+	}
+	extractor.ID = newUUID()
+	extractor.CreatorUserID = srv.creatorUserID()
+	srv.setExtractor(inputID, *extractor)
+	return map[string]string{"extractor_id": extractor.ID}, http.StatusCreated, nil
+}
+
+// PUT /system/inputs/{inputId}/extractors/{extractorId} Update an extractor
+func (srv *Server) handleUpdateExtractor(r *http.Request, ps params) (interface{}, int, error) {
+	orig, sc, err := srv.getExtractor(ps["inputID"], ps["extractorID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	body := &extractorRequestBody{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	extractor, sc, err := body.toExtractor()
+	if err != nil {
+		return nil, sc, err
+	}
+	extractor.ID = orig.ID
+	extractor.CreatorUserID = orig.CreatorUserID
+	srv.setExtractor(ps["inputID"], *extractor)
+	return extractor, http.StatusOK, nil
+}
+
+// DELETE /system/inputs/{inputId}/extractors/{extractorId} Delete an extractor
+func (srv *Server) handleDeleteExtractor(r *http.Request, ps params) (interface{}, int, error) {
+	inputID := ps["inputID"]
+	if _, sc, err := srv.getExtractor(inputID, ps["extractorID"]); err != nil {
+		return nil, sc, err
+	}
+	m := make(map[string]graylog.Extractor, len(srv.extractors[inputID]))
+	for id, e := range srv.extractors[inputID] {
+		if id != ps["extractorID"] {
+			m[id] = e
+		}
+	}
+	srv.extractors[inputID] = m
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,93 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func (srv *Server) getGrokPattern(id string) (*graylog.GrokPattern, int, error) {
+	pattern, ok := srv.grokPatterns[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't find grok pattern with ID %s", id)
+	}
+	return &pattern, http.StatusOK, nil
+}
+
+// checkGrokPattern validates a grok pattern and returns an error if the name is duplicated.
+func (srv *Server) checkGrokPattern(pattern *graylog.GrokPattern) (int, error) {
+	if pattern.Name == "" {
+		return http.StatusBadRequest, fmt.Errorf("name is required")
+	}
+	if pattern.Pattern == "" {
+		return http.StatusBadRequest, fmt.Errorf("pattern is required")
+	}
+	for _, p := range srv.grokPatterns {
+		if p.Name == pattern.Name && p.ID != pattern.ID {
+			return http.StatusBadRequest, fmt.Errorf("grok pattern %s already exists", pattern.Name)
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// GET /system/grok Get all existing grok patterns
+func (srv *Server) handleGetGrokPatterns(r *http.Request, ps params) (interface{}, int, error) {
+	patterns := make([]graylog.GrokPattern, 0, len(srv.grokPatterns))
+	for _, p := range srv.grokPatterns {
+		patterns = append(patterns, p)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].Name < patterns[j].Name
+	})
+	return &graylog.GrokPatternsBody{Patterns: patterns}, http.StatusOK, nil
+}
+
+// GET /system/grok/{patternId} Get the existing grok pattern
+func (srv *Server) handleGetGrokPattern(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.getGrokPattern(ps["grokPatternID"])
+}
+
+// POST /system/grok Add a new named pattern
+func (srv *Server) handleCreateGrokPattern(r *http.Request, ps params) (interface{}, int, error) {
+	pattern := &graylog.GrokPattern{}
+	if sc, err := decodeBody(r, pattern); err != nil {
+		return nil, sc, err
+	}
+	pattern.ID = ""
+	if sc, err := srv.checkGrokPattern(pattern); err != nil {
+		return nil, sc, err
+	}
+	pattern.ID = newObjectID()
+	srv.grokPatterns[pattern.ID] = *pattern
+	return pattern, http.StatusCreated, nil
+}
+
+// PUT /system/grok/{patternId} Update an existing pattern
+func (srv *Server) handleUpdateGrokPattern(r *http.Request, ps params) (interface{}, int, error) {
+	orig, sc, err := srv.getGrokPattern(ps["grokPatternID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	pattern := &graylog.GrokPattern{}
+	if sc, err := decodeBody(r, pattern); err != nil {
+		return nil, sc, err
+	}
+	pattern.ID = orig.ID
+	if sc, err := srv.checkGrokPattern(pattern); err != nil {
+		return nil, sc, err
+	}
+	srv.grokPatterns[pattern.ID] = *pattern
+	return pattern, http.StatusOK, nil
+}
+
+// DELETE /system/grok/{patternId} Remove an existing pattern by id
+func (srv *Server) handleDeleteGrokPattern(r *http.Request, ps params) (interface{}, int, error) {
+	pattern, sc, err := srv.getGrokPattern(ps["grokPatternID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	delete(srv.grokPatterns, pattern.ID)
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,226 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// queryInt returns the integer query parameter.
+// If the parameter isn't set, the default value is returned.
+func queryInt(r *http.Request, key string, defaultValue int) (int, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("the query parameter %s must be an integer: %s", key, v)
+	}
+	return i, nil
+}
+
+// paginate returns the range of a list.
+// If limit isn't positive, all elements after skip are returned.
+func paginate(size, skip, limit int) (int, int) {
+	if skip < 0 {
+		skip = 0
+	}
+	if skip > size {
+		skip = size
+	}
+	end := size
+	if limit > 0 && skip+limit < size {
+		end = skip + limit
+	}
+	return skip, end
+}
+
+func (srv *Server) unsetDefaultIndexSet() {
+	for id, is := range srv.indexSets {
+		if is.Default {
+			is.Default = false
+			srv.indexSets[id] = is
+		}
+	}
+}
+
+func (srv *Server) getIndexSetStats(id string) graylog.IndexSetStats {
+	if stats, ok := srv.indexSetStats[id]; ok {
+		return stats
+	}
+	return graylog.IndexSetStats{}
+}
+
+// GET /system/indices/index_sets Get a list of all index sets
+func (srv *Server) handleGetIndexSets(r *http.Request, ps params) (interface{}, int, error) {
+	skip, err := queryInt(r, "skip", 0)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	limit, err := queryInt(r, "limit", 0)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	withStats := r.URL.Query().Get("stats") == "true"
+	indexSets := make([]graylog.IndexSet, 0, len(srv.indexSets))
+	for _, is := range srv.indexSets {
+		indexSets = append(indexSets, is)
+	}
+	sort.Slice(indexSets, func(i, j int) bool {
+		return indexSets[i].ID < indexSets[j].ID
+	})
+	total := len(indexSets)
+	start, end := paginate(total, skip, limit)
+	indexSets = indexSets[start:end]
+	stats := map[string]graylog.IndexSetStats{}
+	if withStats {
+		for _, is := range indexSets {
+			stats[is.ID] = srv.getIndexSetStats(is.ID)
+		}
+	}
+	return &graylog.IndexSetsBody{IndexSets: indexSets, Stats: stats, Total: total}, http.StatusOK, nil
+}
+
+// GET /system/indices/index_sets/{id} Get index set
+func (srv *Server) handleGetIndexSet(r *http.Request, ps params) (interface{}, int, error) {
+	is, ok := srv.indexSets[ps["indexSetID"]]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't load index set with ID <%s>", ps["indexSetID"])
+	}
+	return &is, http.StatusOK, nil
+}
+
+// checkIndexPrefix returns an error if the index prefix conflicts with another index set.
+func (srv *Server) checkIndexPrefix(id, prefix string) (int, error) {
+	for _, is := range srv.indexSets {
+		if is.ID != id && is.IndexPrefix == prefix {
+			return http.StatusBadRequest, fmt.Errorf(
+				"index prefix %s would conflict with an existing index set", prefix)
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// POST /system/indices/index_sets Create index set
+func (srv *Server) handleCreateIndexSet(r *http.Request, ps params) (interface{}, int, error) {
+	is := &graylog.IndexSet{}
+	if sc, err := decodeBody(r, is); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.CreateValidator.Struct(is); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if sc, err := srv.checkIndexPrefix("", is.IndexPrefix); err != nil {
+		return nil, sc, err
+	}
+	is.ID = newObjectID()
+	is.SetCreateDefaultValues()
+	// Graylog sets the default index set only by the API "PUT /system/indices/index_sets/{id}/default"
+	is.Default = false
+	srv.indexSets[is.ID] = *is
+	return is, http.StatusOK, nil
+}
+
+// PUT /system/indices/index_sets/{id} Update index set
+func (srv *Server) handleUpdateIndexSet(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["indexSetID"]
+	is, ok := srv.indexSets[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't load index set with ID <%s>", id)
+	}
+	prms := &graylog.IndexSetUpdateParams{}
+	if sc, err := decodeBody(r, prms); err != nil {
+		return nil, sc, err
+	}
+	prms.ID = id
+	if err := validator.UpdateValidator.Struct(prms); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if prms.IndexPrefix != is.IndexPrefix {
+		return nil, http.StatusBadRequest, fmt.Errorf("the index prefix of the index set can't be changed")
+	}
+	if is.Default && prms.Writable != nil && !*prms.Writable {
+		return nil, http.StatusConflict, fmt.Errorf("default index set must be writable")
+	}
+	is.Title = prms.Title
+	is.RotationStrategyClass = prms.RotationStrategyClass
+	is.RotationStrategy = prms.RotationStrategy
+	is.RetentionStrategyClass = prms.RetentionStrategyClass
+	is.RetentionStrategy = prms.RetentionStrategy
+	is.IndexAnalyzer = prms.IndexAnalyzer
+	is.Shards = prms.Shards
+	is.IndexOptimizationMaxNumSegments = prms.IndexOptimizationMaxNumSegments
+	is.FieldTypeRefreshInterval = prms.FieldTypeRefreshInterval
+	if prms.Description != nil {
+		is.Description = *prms.Description
+	}
+	if prms.Replicas != nil {
+		is.Replicas = *prms.Replicas
+	}
+	if prms.IndexOptimizationDisabled != nil {
+		is.IndexOptimizationDisabled = *prms.IndexOptimizationDisabled
+	}
+	if prms.Writable != nil {
+		is.Writable = *prms.Writable
+	}
+	srv.indexSets[id] = is
+	return &is, http.StatusOK, nil
+}
+
+// DELETE /system/indices/index_sets/{id} Delete index set
+func (srv *Server) handleDeleteIndexSet(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["indexSetID"]
+	is, ok := srv.indexSets[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("index set <%s> not found", id)
+	}
+	if is.Default {
+		return nil, http.StatusBadRequest, fmt.Errorf("default index set <%s> cannot be deleted", id)
+	}
+	delete(srv.indexSets, id)
+	delete(srv.indexSetStats, id)
+	return nil, http.StatusNoContent, nil
+}
+
+// PUT /system/indices/index_sets/{id}/default Set default index set
+func (srv *Server) handleSetDefaultIndexSet(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["indexSetID"]
+	is, ok := srv.indexSets[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("index set <%s> does not exist", id)
+	}
+	if !is.Writable {
+		return nil, http.StatusConflict, fmt.Errorf("default index set must be writable")
+	}
+	srv.unsetDefaultIndexSet()
+	is.Default = true
+	srv.indexSets[id] = is
+	return &is, http.StatusOK, nil
+}
+
+// GET /system/indices/index_sets/{id}/stats Get index set statistics
+func (srv *Server) handleGetIndexSetStats(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["indexSetID"]
+	if _, ok := srv.indexSets[id]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't load index set with ID <%s>", id)
+	}
+	stats := srv.getIndexSetStats(id)
+	return &stats, http.StatusOK, nil
+}
+
+// GET /system/indices/index_sets/stats Get stats of all index sets
+func (srv *Server) handleGetTotalIndexSetsStats(r *http.Request, ps params) (interface{}, int, error) {
+	total := &graylog.IndexSetStats{}
+	for id := range srv.indexSets {
+		stats := srv.getIndexSetStats(id)
+		total.Indices += stats.Indices
+		total.Documents += stats.Documents
+		total.Size += stats.Size
+	}
+	return total, http.StatusOK, nil
+}
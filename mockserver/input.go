@@ -0,0 +1,175 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+type (
+	// inputRequestBody is the request body of the create and update input APIs.
+	inputRequestBody struct {
+		Title         string                 `json:"title"`
+		Type          string                 `json:"type"`
+		Configuration map[string]interface{} `json:"configuration"`
+		Global        bool                   `json:"global"`
+		Node          string                 `json:"node,omitempty"`
+	}
+
+	inputStaticFieldRequestBody struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+)
+
+// toInput converts the request body to an input and validates it.
+func (body *inputRequestBody) toInput() (*graylog.Input, int, error) {
+	if body.Type == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("type is required")
+	}
+	if body.Configuration == nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("configuration is required")
+	}
+	d := &graylog.InputData{
+		Title:  body.Title,
+		Type:   body.Type,
+		Global: body.Global,
+		Node:   body.Node,
+		Attrs:  body.Configuration,
+	}
+	input := &graylog.Input{}
+	if err := d.ToInput(input); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err := validator.CreateValidator.Struct(input); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err := validator.CreateValidator.Struct(input.Attrs); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return input, http.StatusOK, nil
+}
+
+// GET /system/inputs Get all inputs
+func (srv *Server) handleGetInputs(r *http.Request, ps params) (interface{}, int, error) {
+	inputs := make([]graylog.Input, 0, len(srv.inputs))
+	for _, input := range srv.inputs {
+		inputs = append(inputs, input)
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		return inputs[i].ID < inputs[j].ID
+	})
+	return &graylog.InputsBody{Inputs: inputs, Total: len(inputs)}, http.StatusOK, nil
+}
+
+// GET /system/inputs/{inputId} Get information of a single input on this node
+func (srv *Server) handleGetInput(r *http.Request, ps params) (interface{}, int, error) {
+	input, ok := srv.inputs[ps["inputID"]]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no input found with id %s", ps["inputID"])
+	}
+	return &input, http.StatusOK, nil
+}
+
+// POST /system/inputs Launch input on this node
+func (srv *Server) handleCreateInput(r *http.Request, ps params) (interface{}, int, error) {
+	body := &inputRequestBody{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	input, sc, err := body.toInput()
+	if err != nil {
+		return nil, sc, err
+	}
+	input.ID = newObjectID()
+	input.CreatedAt = now()
+	input.CreatorUserID = srv.creatorUserID()
+	srv.inputs[input.ID] = *input
+	return map[string]string{"id": input.ID}, http.StatusCreated, nil
+}
+
+// PUT /system/inputs/{inputId} Update input on this node
+func (srv *Server) handleUpdateInput(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["inputID"]
+	orig, ok := srv.inputs[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no input found with id %s", id)
+	}
+	body := &inputRequestBody{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	input, sc, err := body.toInput()
+	if err != nil {
+		return nil, sc, err
+	}
+	input.ID = id
+	input.CreatedAt = orig.CreatedAt
+	input.CreatorUserID = orig.CreatorUserID
+	input.StaticFields = orig.StaticFields
+	srv.inputs[id] = *input
+	return input, http.StatusCreated, nil
+}
+
+// DELETE /system/inputs/{inputId} Terminate input on this node
+func (srv *Server) handleDeleteInput(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["inputID"]
+	if _, ok := srv.inputs[id]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no input found with id %s", id)
+	}
+	delete(srv.inputs, id)
+	delete(srv.extractors, id)
+	return nil, http.StatusNoContent, nil
+}
+
+// POST /system/inputs/{inputId}/staticfields Add a static field to an input
+func (srv *Server) handleCreateInputStaticField(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["inputID"]
+	input, ok := srv.inputs[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no input found with id %s", id)
+	}
+	body := &inputStaticFieldRequestBody{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	if body.Key == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("key is required")
+	}
+	if body.Value == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("value is required")
+	}
+	fields := make(map[string]string, len(input.StaticFields)+1)
+	for k, v := range input.StaticFields {
+		fields[k] = v
+	}
+	fields[body.Key] = body.Value
+	input.StaticFields = fields
+	srv.inputs[id] = input
+	return nil, http.StatusCreated, nil
+}
+
+// DELETE /system/inputs/{inputId}/staticfields/{key} Remove static field of an input
+func (srv *Server) handleDeleteInputStaticField(r *http.Request, ps params) (interface{}, int, error) {
+	id := ps["inputID"]
+	input, ok := srv.inputs[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no input found with id %s", id)
+	}
+	key := ps["key"]
+	if _, ok := input.StaticFields[key]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no such static field %s", key)
+	}
+	fields := make(map[string]string, len(input.StaticFields))
+	for k, v := range input.StaticFields {
+		if k != key {
+			fields[k] = v
+		}
+	}
+	input.StaticFields = fields
+	srv.inputs[id] = input
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,65 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// GET /system/ldap/settings Get the LDAP configuration if it is configured
+func (srv *Server) handleGetLDAPSetting(r *http.Request, ps params) (interface{}, int, error) {
+	ls := srv.ldapSetting
+	return &ls, http.StatusOK, nil
+}
+
+// PUT /system/ldap/settings Update the LDAP configuration
+func (srv *Server) handleUpdateLDAPSetting(r *http.Request, ps params) (interface{}, int, error) {
+	ls := &graylog.LDAPSetting{}
+	if sc, err := decodeBody(r, ls); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.UpdateValidator.Struct(ls); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	srv.ldapSetting = *ls
+	return nil, http.StatusNoContent, nil
+}
+
+// DELETE /system/ldap/settings Remove the LDAP configuration
+func (srv *Server) handleDeleteLDAPSetting(r *http.Request, ps params) (interface{}, int, error) {
+	srv.ldapSetting = graylog.LDAPSetting{}
+	return nil, http.StatusNoContent, nil
+}
+
+// GET /system/ldap/groups Get a list of available LDAP groups
+func (srv *Server) handleGetLDAPGroups(r *http.Request, ps params) (interface{}, int, error) {
+	groups := make([]string, len(srv.ldapGroups))
+	copy(groups, srv.ldapGroups)
+	return groups, http.StatusOK, nil
+}
+
+// GET /system/ldap/settings/groups Get the LDAP group to Graylog role mapping
+func (srv *Server) handleGetLDAPGroupRoleMapping(r *http.Request, ps params) (interface{}, int, error) {
+	m := make(map[string]string, len(srv.ldapGroupRoleMapping))
+	for k, v := range srv.ldapGroupRoleMapping {
+		m[k] = v
+	}
+	return m, http.StatusOK, nil
+}
+
+// PUT /system/ldap/settings/groups Update the LDAP group to Graylog role mapping
+func (srv *Server) handleUpdateLDAPGroupRoleMapping(r *http.Request, ps params) (interface{}, int, error) {
+	m := map[string]string{}
+	if sc, err := decodeBody(r, &m); err != nil {
+		return nil, sc, err
+	}
+	for _, role := range m {
+		if _, ok := srv.roles[role]; !ok {
+			return nil, http.StatusBadRequest, fmt.Errorf("no role found with name %s", role)
+		}
+	}
+	srv.ldapGroupRoleMapping = m
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,97 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+func (srv *Server) getPipeline(id string) (*graylog.Pipeline, int, error) {
+	pipeline, ok := srv.pipelines[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no pipeline with id %s", id)
+	}
+	return &pipeline, http.StatusOK, nil
+}
+
+// decodePipeline decodes the request body and sets the title and stages parsed from the source.
+func (srv *Server) decodePipeline(r *http.Request) (*graylog.Pipeline, int, error) {
+	body := &graylog.Pipeline{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	body.ID = ""
+	if err := validator.CreateValidator.Struct(body); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	title, stages, err := graylog.ParsePipelineSource(body.Source)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	body.Title = title
+	body.Stages = stages
+	return body, http.StatusOK, nil
+}
+
+// GET /system/pipelines/pipeline Get all processing pipelines
+func (srv *Server) handleGetPipelines(r *http.Request, ps params) (interface{}, int, error) {
+	pipelines := make([]graylog.Pipeline, 0, len(srv.pipelines))
+	for _, pipeline := range srv.pipelines {
+		pipelines = append(pipelines, pipeline)
+	}
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].ID < pipelines[j].ID
+	})
+	return pipelines, http.StatusOK, nil
+}
+
+// GET /system/pipelines/pipeline/{id} Get a processing pipeline
+func (srv *Server) handleGetPipeline(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.getPipeline(ps["pipelineID"])
+}
+
+// POST /system/pipelines/pipeline Create a processing pipeline from source
+func (srv *Server) handleCreatePipeline(r *http.Request, ps params) (interface{}, int, error) {
+	pipeline, sc, err := srv.decodePipeline(r)
+	if err != nil {
+		return nil, sc, err
+	}
+	pipeline.ID = newObjectID()
+	srv.pipelines[pipeline.ID] = *pipeline
+	return pipeline, http.StatusOK, nil
+}
+
+// PUT /system/pipelines/pipeline/{id} Modify a processing pipeline
+func (srv *Server) handleUpdatePipeline(r *http.Request, ps params) (interface{}, int, error) {
+	orig, sc, err := srv.getPipeline(ps["pipelineID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	pipeline, sc, err := srv.decodePipeline(r)
+	if err != nil {
+		return nil, sc, err
+	}
+	pipeline.ID = orig.ID
+	srv.pipelines[pipeline.ID] = *pipeline
+	return pipeline, http.StatusOK, nil
+}
+
+// DELETE /system/pipelines/pipeline/{id} Delete a processing pipeline
+func (srv *Server) handleDeletePipeline(r *http.Request, ps params) (interface{}, int, error) {
+	pipeline, sc, err := srv.getPipeline(ps["pipelineID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	delete(srv.pipelines, pipeline.ID)
+	for streamID, conn := range srv.pipelineConnections {
+		ids := removeString(conn.PipelineIDs, pipeline.ID)
+		if len(ids) != len(conn.PipelineIDs) {
+			conn.PipelineIDs = ids
+			srv.pipelineConnections[streamID] = conn
+		}
+	}
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,118 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// removeString returns a new list which doesn't have the given string.
+func removeString(list []string, s string) []string {
+	ret := make([]string, 0, len(list))
+	for _, a := range list {
+		if a != s {
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
+
+// setPipelineConnection sets the pipelines connected to the stream.
+func (srv *Server) setPipelineConnection(streamID string, pipelineIDs []string) graylog.PipelineConnection {
+	conn, ok := srv.pipelineConnections[streamID]
+	if !ok {
+		conn = graylog.PipelineConnection{ID: newObjectID(), StreamID: streamID}
+	}
+	ids := make([]string, len(pipelineIDs))
+	copy(ids, pipelineIDs)
+	conn.PipelineIDs = ids
+	srv.pipelineConnections[streamID] = conn
+	return conn
+}
+
+// GET /system/pipelines/connections Get all pipeline connections
+func (srv *Server) handleGetPipelineConnections(r *http.Request, ps params) (interface{}, int, error) {
+	conns := make([]graylog.PipelineConnection, 0, len(srv.pipelineConnections))
+	for _, conn := range srv.pipelineConnections {
+		conns = append(conns, conn)
+	}
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].StreamID < conns[j].StreamID
+	})
+	return conns, http.StatusOK, nil
+}
+
+// GET /system/pipelines/connections/{streamId} Get pipeline connections for the given stream
+func (srv *Server) handleGetPipelineConnectionsOfStream(r *http.Request, ps params) (interface{}, int, error) {
+	streamID := ps["streamID"]
+	if _, sc, err := srv.getStream(streamID); err != nil {
+		return nil, sc, err
+	}
+	conn, ok := srv.pipelineConnections[streamID]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no pipeline connections for stream %s", streamID)
+	}
+	return &conn, http.StatusOK, nil
+}
+
+// POST /system/pipelines/connections/to_stream Connect processing pipelines to a stream
+func (srv *Server) handleConnectPipelinesToStream(r *http.Request, ps params) (interface{}, int, error) {
+	body := &graylog.PipelineConnection{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	if _, sc, err := srv.getStream(body.StreamID); err != nil {
+		return nil, sc, err
+	}
+	for _, id := range body.PipelineIDs {
+		if _, sc, err := srv.getPipeline(id); err != nil {
+			return nil, sc, err
+		}
+	}
+	conn := srv.setPipelineConnection(body.StreamID, body.PipelineIDs)
+	return &conn, http.StatusOK, nil
+}
+
+// POST /system/pipelines/connections/to_pipeline Connect streams to a processing pipeline
+func (srv *Server) handleConnectStreamsToPipeline(r *http.Request, ps params) (interface{}, int, error) {
+	body := &struct {
+		PipelineID string   `json:"pipeline_id"`
+		StreamIDs  []string `json:"stream_ids"`
+	}{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	if _, sc, err := srv.getPipeline(body.PipelineID); err != nil {
+		return nil, sc, err
+	}
+	streamIDs := map[string]struct{}{}
+	for _, id := range body.StreamIDs {
+		if _, sc, err := srv.getStream(id); err != nil {
+			return nil, sc, err
+		}
+		streamIDs[id] = struct{}{}
+	}
+	for streamID, conn := range srv.pipelineConnections {
+		if _, ok := streamIDs[streamID]; ok {
+			continue
+		}
+		ids := removeString(conn.PipelineIDs, body.PipelineID)
+		if len(ids) != len(conn.PipelineIDs) {
+			srv.setPipelineConnection(streamID, ids)
+		}
+	}
+	conns := make([]graylog.PipelineConnection, 0, len(streamIDs))
+	for streamID := range streamIDs {
+		ids := []string{}
+		if conn, ok := srv.pipelineConnections[streamID]; ok {
+			ids = removeString(conn.PipelineIDs, body.PipelineID)
+		}
+		conns = append(conns, srv.setPipelineConnection(streamID, append(ids, body.PipelineID)))
+	}
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].StreamID < conns[j].StreamID
+	})
+	return conns, http.StatusOK, nil
+}
@@ -0,0 +1,89 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+func (srv *Server) getPipelineRule(id string) (*graylog.PipelineRule, int, error) {
+	rule, ok := srv.pipelineRules[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no rule with id %s", id)
+	}
+	return &rule, http.StatusOK, nil
+}
+
+// decodePipelineRule decodes the request body and sets the title parsed from the source.
+func (srv *Server) decodePipelineRule(r *http.Request) (*graylog.PipelineRule, int, error) {
+	body := &graylog.PipelineRule{}
+	if sc, err := decodeBody(r, body); err != nil {
+		return nil, sc, err
+	}
+	body.ID = ""
+	if err := validator.CreateValidator.Struct(body); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	title, err := graylog.ParsePipelineRuleSource(body.Source)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	body.Title = title
+	return body, http.StatusOK, nil
+}
+
+// GET /system/pipelines/rule Get all processing rules
+func (srv *Server) handleGetPipelineRules(r *http.Request, ps params) (interface{}, int, error) {
+	rules := make([]graylog.PipelineRule, 0, len(srv.pipelineRules))
+	for _, rule := range srv.pipelineRules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules, http.StatusOK, nil
+}
+
+// GET /system/pipelines/rule/{id} Get a processing rule
+func (srv *Server) handleGetPipelineRule(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.getPipelineRule(ps["pipelineRuleID"])
+}
+
+// POST /system/pipelines/rule Create a processing rule from source
+func (srv *Server) handleCreatePipelineRule(r *http.Request, ps params) (interface{}, int, error) {
+	rule, sc, err := srv.decodePipelineRule(r)
+	if err != nil {
+		return nil, sc, err
+	}
+	rule.ID = newObjectID()
+	srv.pipelineRules[rule.ID] = *rule
+	return rule, http.StatusOK, nil
+}
+
+// PUT /system/pipelines/rule/{id} Modify a processing rule
+func (srv *Server) handleUpdatePipelineRule(r *http.Request, ps params) (interface{}, int, error) {
+	orig, sc, err := srv.getPipelineRule(ps["pipelineRuleID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	rule, sc, err := srv.decodePipelineRule(r)
+	if err != nil {
+		return nil, sc, err
+	}
+	rule.ID = orig.ID
+	srv.pipelineRules[rule.ID] = *rule
+	return rule, http.StatusOK, nil
+}
+
+// DELETE /system/pipelines/rule/{id} Delete a processing rule
+func (srv *Server) handleDeletePipelineRule(r *http.Request, ps params) (interface{}, int, error) {
+	rule, sc, err := srv.getPipelineRule(ps["pipelineRuleID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	delete(srv.pipelineRules, rule.ID)
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,64 @@
+package mockserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+)
+
+func TestPipeline(t *testing.T) {
+	server, cl := newServerAndClient(t, nil)
+	defer server.Close()
+	ctx := context.Background()
+
+	rule := &graylog.PipelineRule{
+		Source: `rule "has foo"
+when
+  has_field("foo")
+then
+end`,
+	}
+	_, err := cl.CreatePipelineRule(ctx, rule)
+	require.Nil(t, err)
+	require.NotEmpty(t, rule.ID)
+	require.Equal(t, "has foo", rule.Title)
+
+	pipeline := &graylog.Pipeline{
+		Source: `pipeline "test"
+stage 0 match either
+  rule "has foo"
+stage 1 match all
+  rule "has foo"
+  rule "has bar"
+end`,
+	}
+	_, err = cl.CreatePipeline(ctx, pipeline)
+	require.Nil(t, err)
+	require.NotEmpty(t, pipeline.ID)
+	require.Equal(t, "test", pipeline.Title)
+	require.Equal(t, []graylog.PipelineStage{
+		{Stage: 0, MatchAll: false, Rules: []string{"has foo"}},
+		{Stage: 1, MatchAll: true, Rules: []string{"has foo", "has bar"}},
+	}, pipeline.Stages)
+
+	// the source must have the title
+	_, err = cl.CreatePipeline(ctx, &graylog.Pipeline{Source: "foo"})
+	require.NotNil(t, err)
+
+	conn := &graylog.PipelineConnection{
+		StreamID: mockserver.DefaultStreamID, PipelineIDs: []string{pipeline.ID},
+	}
+	_, err = cl.ConnectPipelinesToStream(ctx, conn)
+	require.Nil(t, err)
+	require.NotEmpty(t, conn.ID)
+
+	_, err = cl.DeletePipeline(ctx, pipeline.ID)
+	require.Nil(t, err)
+	c, _, err := cl.GetPipelineConnectionsOfStream(ctx, mockserver.DefaultStreamID)
+	require.Nil(t, err)
+	require.Empty(t, c.PipelineIDs)
+}
@@ -0,0 +1,161 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+type roleMembersBody struct {
+	Role  string         `json:"role"`
+	Users []graylog.User `json:"users"`
+}
+
+// GET /roles List all roles
+func (srv *Server) handleGetRoles(r *http.Request, ps params) (interface{}, int, error) {
+	roles := make([]graylog.Role, 0, len(srv.roles))
+	for _, role := range srv.roles {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+	return &graylog.RolesBody{Roles: roles, Total: len(roles)}, http.StatusOK, nil
+}
+
+// GET /roles/{rolename} Retrieve permissions for a single role
+func (srv *Server) handleGetRole(r *http.Request, ps params) (interface{}, int, error) {
+	role, ok := srv.roles[ps["rolename"]]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no role found with name %s", ps["rolename"])
+	}
+	return &role, http.StatusOK, nil
+}
+
+// POST /roles Create a new role
+func (srv *Server) handleCreateRole(r *http.Request, ps params) (interface{}, int, error) {
+	role := &graylog.Role{}
+	if sc, err := decodeBody(r, role); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.CreateValidator.Struct(role); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if _, ok := srv.roles[role.Name]; ok {
+		return nil, http.StatusBadRequest, fmt.Errorf("role %s already exists", role.Name)
+	}
+	role.ReadOnly = false
+	srv.roles[role.Name] = *role
+	return role, http.StatusCreated, nil
+}
+
+// PUT /roles/{rolename} Update an existing role
+func (srv *Server) handleUpdateRole(r *http.Request, ps params) (interface{}, int, error) {
+	name := ps["rolename"]
+	role, ok := srv.roles[name]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no role found with name %s", name)
+	}
+	if role.ReadOnly {
+		return nil, http.StatusBadRequest, fmt.Errorf("cannot update read only role %s", name)
+	}
+	prms := &graylog.RoleUpdateParams{}
+	if sc, err := decodeBody(r, prms); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.UpdateValidator.Struct(prms); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if prms.Name != name {
+		if _, ok := srv.roles[prms.Name]; ok {
+			return nil, http.StatusBadRequest, fmt.Errorf("role %s already exists", prms.Name)
+		}
+		delete(srv.roles, name)
+		srv.renameUsersRole(name, prms.Name)
+	}
+	role.Name = prms.Name
+	role.Permissions = prms.Permissions
+	if prms.Description != nil {
+		role.Description = *prms.Description
+	}
+	srv.roles[role.Name] = role
+	return &role, http.StatusOK, nil
+}
+
+// DELETE /roles/{rolename} Remove the named role and dissociate any users from it
+func (srv *Server) handleDeleteRole(r *http.Request, ps params) (interface{}, int, error) {
+	name := ps["rolename"]
+	role, ok := srv.roles[name]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no role found with name %s", name)
+	}
+	if role.ReadOnly {
+		return nil, http.StatusBadRequest, fmt.Errorf("cannot delete read only role %s", name)
+	}
+	delete(srv.roles, name)
+	srv.renameUsersRole(name, "")
+	return nil, http.StatusNoContent, nil
+}
+
+// renameUsersRole renames the role of users.
+// If newName is empty, the role is removed from users.
+func (srv *Server) renameUsersRole(oldName, newName string) {
+	for _, user := range srv.users {
+		if !user.Roles.Has(oldName) {
+			continue
+		}
+		roles := user.Roles.Clone()
+		roles.Remove(oldName)
+		if newName != "" {
+			roles[newName] = struct{}{}
+		}
+		user.Roles = roles
+		srv.users[user.Username] = user
+	}
+}
+
+// GET /roles/{rolename}/members Retrieve the role's members
+func (srv *Server) handleGetRoleMembers(r *http.Request, ps params) (interface{}, int, error) {
+	name := ps["rolename"]
+	if _, ok := srv.roles[name]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no role found with name %s", name)
+	}
+	users := []graylog.User{}
+	for _, user := range srv.getUsers() {
+		if user.Roles.Has(name) {
+			users = append(users, user)
+		}
+	}
+	return &roleMembersBody{Role: name, Users: users}, http.StatusOK, nil
+}
+
+// PUT /roles/{rolename}/members/{username} Add a user to a role
+func (srv *Server) handleAddUserToRole(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.updateRoleMember(ps["rolename"], ps["username"], true)
+}
+
+// DELETE /roles/{rolename}/members/{username} Remove a user from a role
+func (srv *Server) handleRemoveUserFromRole(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.updateRoleMember(ps["rolename"], ps["username"], false)
+}
+
+func (srv *Server) updateRoleMember(roleName, userName string, add bool) (interface{}, int, error) {
+	if _, ok := srv.roles[roleName]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no role found with name %s", roleName)
+	}
+	user, ok := srv.users[userName]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't find user %s", userName)
+	}
+	roles := user.Roles.Clone()
+	roles.Remove(roleName)
+	if add {
+		roles[roleName] = struct{}{}
+	}
+	user.Roles = roles
+	srv.users[userName] = user
+	return nil, http.StatusNoContent, nil
+}
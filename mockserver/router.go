@@ -0,0 +1,76 @@
+package mockserver
+
+import (
+	"net/http"
+	"strings"
+)
+
+type (
+	// handlerFunc handles a request and returns the response body and status code.
+	// If the error isn't nil, the error is returned as an APIError.
+	handlerFunc func(r *http.Request, ps params) (interface{}, int, error)
+
+	params map[string]string
+
+	route struct {
+		method   string
+		segments []string
+		handler  handlerFunc
+	}
+)
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func (srv *Server) handle(method, pattern string, handler handlerFunc) {
+	srv.routes = append(srv.routes, route{
+		method: method, segments: splitPath(pattern), handler: handler,
+	})
+}
+
+// match returns the number of literal segments and the path parameters.
+// If the route doesn't match, the number is -1.
+func (rt route) match(segments []string) (int, params) {
+	if len(rt.segments) != len(segments) {
+		return -1, nil
+	}
+	n := 0
+	ps := params{}
+	for i, s := range rt.segments {
+		if strings.HasPrefix(s, ":") {
+			ps[s[1:]] = segments[i]
+			continue
+		}
+		if s != segments[i] {
+			return -1, nil
+		}
+		n++
+	}
+	return n, ps
+}
+
+// lookup returns the route whose literal segments are the most.
+// If the path matches but the method doesn't, methodMatched is false.
+func (srv *Server) lookup(method, p string) (rt *route, ps params, pathMatched bool) {
+	segments := splitPath(p)
+	best := -1
+	for i := range srv.routes {
+		n, prms := srv.routes[i].match(segments)
+		if n < 0 {
+			continue
+		}
+		pathMatched = true
+		if srv.routes[i].method != method || n <= best {
+			continue
+		}
+		best = n
+		rt = &srv.routes[i]
+		ps = prms
+	}
+	return rt, ps, pathMatched
+}
@@ -0,0 +1,157 @@
+package mockserver
+
+import (
+	"net/http"
+)
+
+// pipelinePrefixes are the path prefixes of the pipeline processor API.
+// Graylog v2 provides the API as a plugin and Graylog v3 provides it as a core API.
+var pipelinePrefixes = []string{
+	"/plugins/org.graylog.plugins.pipelineprocessor/system/pipelines",
+	"/system/pipelines",
+}
+
+// collectorConfigurationsPath is the path of the collector configurations API.
+const collectorConfigurationsPath = "/plugins/org.graylog.plugins.collector/configurations"
+
+func (srv *Server) setRoutes() {
+	srv.handle(http.MethodGet, "/users", srv.handleGetUsers)
+	srv.handle(http.MethodGet, "/users/:username", srv.handleGetUser)
+	srv.handle(http.MethodPost, "/users", srv.handleCreateUser)
+	srv.handle(http.MethodPut, "/users/:username", srv.handleUpdateUser)
+	srv.handle(http.MethodDelete, "/users/:username", srv.handleDeleteUser)
+
+	srv.handle(http.MethodGet, "/roles", srv.handleGetRoles)
+	srv.handle(http.MethodGet, "/roles/:rolename", srv.handleGetRole)
+	srv.handle(http.MethodPost, "/roles", srv.handleCreateRole)
+	srv.handle(http.MethodPut, "/roles/:rolename", srv.handleUpdateRole)
+	srv.handle(http.MethodDelete, "/roles/:rolename", srv.handleDeleteRole)
+	srv.handle(http.MethodGet, "/roles/:rolename/members", srv.handleGetRoleMembers)
+	srv.handle(http.MethodPut, "/roles/:rolename/members/:username", srv.handleAddUserToRole)
+	srv.handle(http.MethodDelete, "/roles/:rolename/members/:username", srv.handleRemoveUserFromRole)
+
+	srv.handle(http.MethodGet, "/system/indices/index_sets", srv.handleGetIndexSets)
+	srv.handle(http.MethodGet, "/system/indices/index_sets/stats", srv.handleGetTotalIndexSetsStats)
+	srv.handle(http.MethodGet, "/system/indices/index_sets/:indexSetID", srv.handleGetIndexSet)
+	srv.handle(http.MethodPost, "/system/indices/index_sets", srv.handleCreateIndexSet)
+	srv.handle(http.MethodPut, "/system/indices/index_sets/:indexSetID", srv.handleUpdateIndexSet)
+	srv.handle(http.MethodDelete, "/system/indices/index_sets/:indexSetID", srv.handleDeleteIndexSet)
+	srv.handle(http.MethodPut, "/system/indices/index_sets/:indexSetID/default", srv.handleSetDefaultIndexSet)
+	srv.handle(http.MethodGet, "/system/indices/index_sets/:indexSetID/stats", srv.handleGetIndexSetStats)
+
+	srv.handle(http.MethodGet, "/system/inputs", srv.handleGetInputs)
+	srv.handle(http.MethodGet, "/system/inputs/:inputID", srv.handleGetInput)
+	srv.handle(http.MethodPost, "/system/inputs", srv.handleCreateInput)
+	srv.handle(http.MethodPut, "/system/inputs/:inputID", srv.handleUpdateInput)
+	srv.handle(http.MethodDelete, "/system/inputs/:inputID", srv.handleDeleteInput)
+	srv.handle(http.MethodPost, "/system/inputs/:inputID/staticfields", srv.handleCreateInputStaticField)
+	srv.handle(http.MethodDelete, "/system/inputs/:inputID/staticfields/:key", srv.handleDeleteInputStaticField)
+
+	srv.handle(http.MethodGet, "/system/inputs/:inputID/extractors", srv.handleGetExtractors)
+	srv.handle(http.MethodGet, "/system/inputs/:inputID/extractors/:extractorID", srv.handleGetExtractor)
+	srv.handle(http.MethodPost, "/system/inputs/:inputID/extractors", srv.handleCreateExtractor)
+	srv.handle(http.MethodPut, "/system/inputs/:inputID/extractors/:extractorID", srv.handleUpdateExtractor)
+	srv.handle(http.MethodDelete, "/system/inputs/:inputID/extractors/:extractorID", srv.handleDeleteExtractor)
+
+	srv.handle(http.MethodGet, "/streams", srv.handleGetStreams)
+	srv.handle(http.MethodGet, "/streams/enabled", srv.handleGetEnabledStreams)
+	srv.handle(http.MethodGet, "/streams/:streamID", srv.handleGetStream)
+	srv.handle(http.MethodPost, "/streams", srv.handleCreateStream)
+	srv.handle(http.MethodPut, "/streams/:streamID", srv.handleUpdateStream)
+	srv.handle(http.MethodDelete, "/streams/:streamID", srv.handleDeleteStream)
+	srv.handle(http.MethodPost, "/streams/:streamID/pause", srv.handlePauseStream)
+	srv.handle(http.MethodPost, "/streams/:streamID/resume", srv.handleResumeStream)
+
+	srv.handle(http.MethodGet, "/streams/:streamID/rules", srv.handleGetStreamRules)
+	srv.handle(http.MethodGet, "/streams/:streamID/rules/types", srv.handleGetStreamRuleTypes)
+	srv.handle(http.MethodGet, "/streams/:streamID/rules/:streamRuleID", srv.handleGetStreamRule)
+	srv.handle(http.MethodPost, "/streams/:streamID/rules", srv.handleCreateStreamRule)
+	srv.handle(http.MethodPut, "/streams/:streamID/rules/:streamRuleID", srv.handleUpdateStreamRule)
+	srv.handle(http.MethodDelete, "/streams/:streamID/rules/:streamRuleID", srv.handleDeleteStreamRule)
+
+	srv.handle(http.MethodGet, "/alerts/conditions", srv.handleGetAlertConditions)
+	srv.handle(http.MethodGet, "/alerts/conditions/types", srv.handleGetAlertConditionTypes)
+	srv.handle(http.MethodGet, "/streams/:streamID/alerts/conditions", srv.handleGetStreamAlertConditions)
+	srv.handle(http.MethodGet, "/streams/:streamID/alerts/conditions/:alertConditionID", srv.handleGetStreamAlertCondition)
+	srv.handle(http.MethodPost, "/streams/:streamID/alerts/conditions", srv.handleCreateStreamAlertCondition)
+	srv.handle(http.MethodPut, "/streams/:streamID/alerts/conditions/:alertConditionID", srv.handleUpdateStreamAlertCondition)
+	srv.handle(http.MethodDelete, "/streams/:streamID/alerts/conditions/:alertConditionID", srv.handleDeleteStreamAlertCondition)
+	srv.handle(http.MethodPost, "/streams/:streamID/alerts/conditions/:alertConditionID/test", srv.handleTestStreamAlertCondition)
+
+	srv.handle(http.MethodGet, "/alerts/callbacks", srv.handleGetAlarmCallbacks)
+	srv.handle(http.MethodGet, "/alerts/callbacks/types", srv.handleGetAlarmCallbackTypes)
+	srv.handle(http.MethodPost, "/alerts/callbacks/:alarmCallbackID/test", srv.handleTestAlarmCallback)
+	srv.handle(http.MethodGet, "/streams/:streamID/alarmcallbacks", srv.handleGetStreamAlarmCallbacks)
+	srv.handle(http.MethodGet, "/streams/:streamID/alarmcallbacks/:alarmCallbackID", srv.handleGetStreamAlarmCallback)
+	srv.handle(http.MethodPost, "/streams/:streamID/alarmcallbacks", srv.handleCreateStreamAlarmCallback)
+	srv.handle(http.MethodPut, "/streams/:streamID/alarmcallbacks/:alarmCallbackID", srv.handleUpdateStreamAlarmCallback)
+	srv.handle(http.MethodDelete, "/streams/:streamID/alarmcallbacks/:alarmCallbackID", srv.handleDeleteStreamAlarmCallback)
+
+	srv.handle(http.MethodGet, "/streams/alerts", srv.handleGetAlerts)
+	srv.handle(http.MethodGet, "/streams/alerts/paginated", srv.handleGetAlertsPaginated)
+	srv.handle(http.MethodGet, "/streams/alerts/:alertID", srv.handleGetAlert)
+	srv.handle(http.MethodGet, "/streams/:streamID/alerts", srv.handleGetStreamAlerts)
+	srv.handle(http.MethodGet, "/streams/:streamID/alerts/paginated", srv.handleGetStreamAlertsPaginated)
+	srv.handle(http.MethodPost, "/streams/:streamID/alerts/receivers", srv.handleAddStreamAlertReceiver)
+	srv.handle(http.MethodDelete, "/streams/:streamID/alerts/receivers", srv.handleRemoveStreamAlertReceiver)
+
+	srv.handle(http.MethodGet, "/dashboards", srv.handleGetDashboards)
+	srv.handle(http.MethodGet, "/dashboards/:dashboardID", srv.handleGetDashboard)
+	srv.handle(http.MethodPost, "/dashboards", srv.handleCreateDashboard)
+	srv.handle(http.MethodPut, "/dashboards/:dashboardID", srv.handleUpdateDashboard)
+	srv.handle(http.MethodDelete, "/dashboards/:dashboardID", srv.handleDeleteDashboard)
+	srv.handle(http.MethodPut, "/dashboards/:dashboardID/positions", srv.handleUpdateDashboardWidgetPositions)
+	srv.handle(http.MethodGet, "/dashboards/:dashboardID/widgets/:widgetID", srv.handleGetDashboardWidget)
+	srv.handle(http.MethodPost, "/dashboards/:dashboardID/widgets", srv.handleCreateDashboardWidget)
+	srv.handle(http.MethodPut, "/dashboards/:dashboardID/widgets/:widgetID", srv.handleUpdateDashboardWidget)
+	srv.handle(http.MethodDelete, "/dashboards/:dashboardID/widgets/:widgetID", srv.handleDeleteDashboardWidget)
+	srv.handle(http.MethodPut, "/dashboards/:dashboardID/widgets/:widgetID/cachetime", srv.handleUpdateDashboardWidgetCacheTime)
+	srv.handle(http.MethodPut, "/dashboards/:dashboardID/widgets/:widgetID/description", srv.handleUpdateDashboardWidgetDescription)
+
+	srv.handle(http.MethodGet, "/system/grok", srv.handleGetGrokPatterns)
+	srv.handle(http.MethodGet, "/system/grok/:grokPatternID", srv.handleGetGrokPattern)
+	srv.handle(http.MethodPost, "/system/grok", srv.handleCreateGrokPattern)
+	srv.handle(http.MethodPut, "/system/grok/:grokPatternID", srv.handleUpdateGrokPattern)
+	srv.handle(http.MethodDelete, "/system/grok/:grokPatternID", srv.handleDeleteGrokPattern)
+
+	for _, prefix := range pipelinePrefixes {
+		srv.handle(http.MethodGet, prefix+"/pipeline", srv.handleGetPipelines)
+		srv.handle(http.MethodGet, prefix+"/pipeline/:pipelineID", srv.handleGetPipeline)
+		srv.handle(http.MethodPost, prefix+"/pipeline", srv.handleCreatePipeline)
+		srv.handle(http.MethodPut, prefix+"/pipeline/:pipelineID", srv.handleUpdatePipeline)
+		srv.handle(http.MethodDelete, prefix+"/pipeline/:pipelineID", srv.handleDeletePipeline)
+
+		srv.handle(http.MethodGet, prefix+"/rule", srv.handleGetPipelineRules)
+		srv.handle(http.MethodGet, prefix+"/rule/:pipelineRuleID", srv.handleGetPipelineRule)
+		srv.handle(http.MethodPost, prefix+"/rule", srv.handleCreatePipelineRule)
+		srv.handle(http.MethodPut, prefix+"/rule/:pipelineRuleID", srv.handleUpdatePipelineRule)
+		srv.handle(http.MethodDelete, prefix+"/rule/:pipelineRuleID", srv.handleDeletePipelineRule)
+
+		srv.handle(http.MethodGet, prefix+"/connections", srv.handleGetPipelineConnections)
+		srv.handle(http.MethodGet, prefix+"/connections/:streamID", srv.handleGetPipelineConnectionsOfStream)
+		srv.handle(http.MethodPost, prefix+"/connections/to_stream", srv.handleConnectPipelinesToStream)
+		srv.handle(http.MethodPost, prefix+"/connections/to_pipeline", srv.handleConnectStreamsToPipeline)
+	}
+
+	srv.handle(http.MethodGet, "/system/ldap/settings", srv.handleGetLDAPSetting)
+	srv.handle(http.MethodPut, "/system/ldap/settings", srv.handleUpdateLDAPSetting)
+	srv.handle(http.MethodDelete, "/system/ldap/settings", srv.handleDeleteLDAPSetting)
+	srv.handle(http.MethodGet, "/system/ldap/groups", srv.handleGetLDAPGroups)
+	srv.handle(http.MethodGet, "/system/ldap/settings/groups", srv.handleGetLDAPGroupRoleMapping)
+	srv.handle(http.MethodPut, "/system/ldap/settings/groups", srv.handleUpdateLDAPGroupRoleMapping)
+
+	srv.handle(http.MethodGet, collectorConfigurationsPath, srv.handleGetCollectorConfigurations)
+	srv.handle(http.MethodGet, collectorConfigurationsPath+"/:collectorConfigurationID", srv.handleGetCollectorConfiguration)
+	srv.handle(http.MethodPost, collectorConfigurationsPath, srv.handleCreateCollectorConfiguration)
+	srv.handle(http.MethodPut, collectorConfigurationsPath+"/:collectorConfigurationID/name", srv.handleRenameCollectorConfiguration)
+	srv.handle(http.MethodDelete, collectorConfigurationsPath+"/:collectorConfigurationID", srv.handleDeleteCollectorConfiguration)
+	srv.handle(http.MethodPost, collectorConfigurationsPath+"/:collectorConfigurationID/inputs", srv.handleCreateCollectorConfigurationInput)
+	srv.handle(http.MethodPut, collectorConfigurationsPath+"/:collectorConfigurationID/inputs/:inputID", srv.handleUpdateCollectorConfigurationInput)
+	srv.handle(http.MethodDelete, collectorConfigurationsPath+"/:collectorConfigurationID/inputs/:inputID", srv.handleDeleteCollectorConfigurationInput)
+	srv.handle(http.MethodPost, collectorConfigurationsPath+"/:collectorConfigurationID/outputs", srv.handleCreateCollectorConfigurationOutput)
+	srv.handle(http.MethodPut, collectorConfigurationsPath+"/:collectorConfigurationID/outputs/:outputID", srv.handleUpdateCollectorConfigurationOutput)
+	srv.handle(http.MethodDelete, collectorConfigurationsPath+"/:collectorConfigurationID/outputs/:outputID", srv.handleDeleteCollectorConfigurationOutput)
+	srv.handle(http.MethodPost, collectorConfigurationsPath+"/:collectorConfigurationID/snippets", srv.handleCreateCollectorConfigurationSnippet)
+	srv.handle(http.MethodPut, collectorConfigurationsPath+"/:collectorConfigurationID/snippets/:snippetID", srv.handleUpdateCollectorConfigurationSnippet)
+	srv.handle(http.MethodDelete, collectorConfigurationsPath+"/:collectorConfigurationID/snippets/:snippetID", srv.handleDeleteCollectorConfigurationSnippet)
+}
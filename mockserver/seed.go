@@ -0,0 +1,297 @@
+package mockserver
+
+import (
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-set"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testdata"
+)
+
+const (
+	// DefaultStreamID is the id of the default stream "All messages".
+	DefaultStreamID = "000000000000000000000001"
+	// DefaultIndexSetID is the id of the default index set.
+	DefaultIndexSetID = "000000000000000000000002"
+	// DefaultPassword is the password of the seeded users which don't have a password.
+	DefaultPassword = "admin"
+)
+
+// Seed is the initial data of the mock server.
+// Stream rules and alert conditions are seeded with Stream.Rules and Stream.AlertConditions,
+// and dashboard widgets are seeded with Dashboard.Widgets and Dashboard.Positions.
+type Seed struct {
+	Users                   []graylog.User
+	Roles                   []graylog.Role
+	IndexSets               []graylog.IndexSet
+	IndexSetStats           map[string]graylog.IndexSetStats
+	Inputs                  []graylog.Input
+	Extractors              map[string][]graylog.Extractor
+	Streams                 []graylog.Stream
+	AlarmCallbacks          []graylog.AlarmCallback
+	Alerts                  []graylog.Alert
+	Dashboards              []graylog.Dashboard
+	GrokPatterns            []graylog.GrokPattern
+	Pipelines               []graylog.Pipeline
+	PipelineRules           []graylog.PipelineRule
+	PipelineConnections     []graylog.PipelineConnection
+	CollectorConfigurations []graylog.CollectorConfiguration
+	LDAPSetting             *graylog.LDAPSetting
+	LDAPGroups              []string
+	LDAPGroupRoleMapping    map[string]string
+}
+
+// DefaultSeed returns the data which a new Graylog server has.
+func DefaultSeed() *Seed {
+	return &Seed{
+		Users: []graylog.User{
+			{
+				ID:               "local:admin",
+				Username:         "admin",
+				Email:            "admin@example.com",
+				FullName:         "Administrator",
+				Password:         DefaultPassword,
+				Permissions:      set.NewStrSet("*"),
+				Roles:            set.NewStrSet("Admin"),
+				Timezone:         "UTC",
+				SessionTimeoutMs: 28800000,
+				ReadOnly:         true,
+			},
+		},
+		Roles: []graylog.Role{
+			{
+				Name:        "Admin",
+				Description: "Grants all permissions for Graylog administrators (built-in)",
+				Permissions: set.NewStrSet("*"),
+				ReadOnly:    true,
+			},
+			{
+				Name:        "Reader",
+				Description: "Grants basic permissions for every Graylog user (built-in)",
+				Permissions: set.NewStrSet(
+					"indexercluster:read", "messagecount:read", "journal:read",
+					"messages:analyze", "metrics:read", "fieldnames:read",
+					"buffers:read", "system:read", "jvmstats:read", "throughput:read",
+					"savedsearches:create", "savedsearches:edit", "savedsearches:read",
+				),
+				ReadOnly: true,
+			},
+		},
+		IndexSets: []graylog.IndexSet{
+			{
+				ID:                              DefaultIndexSetID,
+				Title:                           "Default index set",
+				Description:                     "The Graylog default index set",
+				IndexPrefix:                     "graylog",
+				Shards:                          4,
+				Replicas:                        0,
+				RotationStrategyClass:           graylog.MessageCountRotationStrategy,
+				RotationStrategy:                graylog.NewMessageCountRotationStrategy(0),
+				RetentionStrategyClass:          graylog.DeletionRetentionStrategy,
+				RetentionStrategy:               graylog.NewDeletionRetentionStrategy(0),
+				CreationDate:                    "2019-09-20T12:02:06.078Z",
+				IndexAnalyzer:                   "standard",
+				IndexOptimizationMaxNumSegments: 1,
+				FieldTypeRefreshInterval:        5000,
+				Writable:                        true,
+				Default:                         true,
+			},
+		},
+		Streams: []graylog.Stream{
+			{
+				ID:            DefaultStreamID,
+				Title:         "All messages",
+				Description:   "Stream containing all messages",
+				IndexSetID:    DefaultIndexSetID,
+				CreatedAt:     "2019-09-20T12:02:06.078Z",
+				CreatorUserID: "local:admin",
+				MatchingType:  "AND",
+				IsDefault:     true,
+			},
+		},
+	}
+}
+
+// TestdataSeed returns DefaultSeed with the fixtures of the testdata package.
+// The fixtures are captured from a real Graylog server,
+// so the client and provider tests can run against realistic data.
+// The fixtures have their own default stream and default index set,
+// so DefaultSeed's ones are replaced with them.
+// The ID of the default stream is DefaultStreamID, but the ID of the default index set isn't DefaultIndexSetID.
+// The password of the users is DefaultPassword.
+func TestdataSeed() *Seed {
+	seed := DefaultSeed()
+	seed.Users = append(seed.Users, testdata.Users.Users...)
+	seed.Roles = append(seed.Roles, testdata.Roles.Roles...)
+	indexSets := []graylog.IndexSet{}
+	for _, is := range seed.IndexSets {
+		if !is.Default {
+			indexSets = append(indexSets, is)
+		}
+	}
+	seed.IndexSets = append(indexSets, testdata.IndexSets.IndexSets...)
+	seed.IndexSetStats = testdata.IndexSets.Stats
+	seed.Inputs = append(seed.Inputs, testdata.Inputs.Inputs...)
+	streams := []graylog.Stream{}
+	for _, stream := range seed.Streams {
+		if !stream.IsDefault {
+			streams = append(streams, stream)
+		}
+	}
+	seed.Streams = append(streams, testdata.Streams.Streams...)
+	seed.AlarmCallbacks = append(seed.AlarmCallbacks, testdata.StreamAlarmCallbacks.AlarmCallbacks...)
+	seed.Dashboards = append(seed.Dashboards, testdata.Dashboards.Dashboards...)
+	for i, user := range seed.Users {
+		if user.Password == "" {
+			seed.Users[i].Password = DefaultPassword
+		}
+	}
+	return seed
+}
+
+// Seed adds the data to the server.
+// If the resource already exists, the resource is overwritten.
+// Seed doesn't validate the data, so that any fixture can be seeded.
+func (srv *Server) Seed(seed *Seed) error {
+	if seed == nil {
+		return nil
+	}
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	for _, role := range seed.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("role name is empty")
+		}
+		srv.roles[role.Name] = role
+	}
+	for _, user := range seed.Users {
+		if user.Username == "" {
+			return fmt.Errorf("user name is empty")
+		}
+		if user.ID == "" {
+			user.ID = newObjectID()
+		}
+		srv.users[user.Username] = user
+	}
+	for _, is := range seed.IndexSets {
+		if is.ID == "" {
+			is.ID = newObjectID()
+		}
+		if is.Default {
+			srv.unsetDefaultIndexSet()
+		}
+		srv.indexSets[is.ID] = is
+	}
+	for id, stats := range seed.IndexSetStats {
+		srv.indexSetStats[id] = stats
+	}
+	for _, input := range seed.Inputs {
+		if input.ID == "" {
+			input.ID = newObjectID()
+		}
+		srv.inputs[input.ID] = input
+	}
+	for inputID, extractors := range seed.Extractors {
+		m := map[string]graylog.Extractor{}
+		for _, extractor := range extractors {
+			if extractor.ID == "" {
+				extractor.ID = newUUID()
+			}
+			m[extractor.ID] = extractor
+		}
+		srv.extractors[inputID] = m
+	}
+	for _, stream := range seed.Streams {
+		if stream.ID == "" {
+			stream.ID = newObjectID()
+		}
+		rules := make([]graylog.StreamRule, len(stream.Rules))
+		for i, rule := range stream.Rules {
+			if rule.ID == "" {
+				rule.ID = newObjectID()
+			}
+			rule.StreamID = stream.ID
+			rules[i] = rule
+		}
+		stream.Rules = rules
+		conds := make([]graylog.AlertCondition, len(stream.AlertConditions))
+		for i, cond := range stream.AlertConditions {
+			if cond.ID == "" {
+				cond.ID = newUUID()
+			}
+			conds[i] = cond
+		}
+		stream.AlertConditions = conds
+		srv.streams[stream.ID] = stream
+	}
+	for _, ac := range seed.AlarmCallbacks {
+		if ac.ID == "" {
+			ac.ID = newObjectID()
+		}
+		srv.alarmCallbacks[ac.ID] = ac
+	}
+	for _, alert := range seed.Alerts {
+		if alert.ID == "" {
+			alert.ID = newObjectID()
+		}
+		srv.alerts[alert.ID] = alert
+	}
+	for _, dashboard := range seed.Dashboards {
+		if dashboard.ID == "" {
+			dashboard.ID = newObjectID()
+		}
+		widgets := make([]graylog.Widget, len(dashboard.Widgets))
+		for i, widget := range dashboard.Widgets {
+			if widget.ID == "" {
+				widget.ID = newUUID()
+			}
+			widgets[i] = widget
+		}
+		dashboard.Widgets = widgets
+		srv.dashboards[dashboard.ID] = dashboard
+	}
+	for _, pattern := range seed.GrokPatterns {
+		if pattern.ID == "" {
+			pattern.ID = newObjectID()
+		}
+		srv.grokPatterns[pattern.ID] = pattern
+	}
+	for _, rule := range seed.PipelineRules {
+		if rule.ID == "" {
+			rule.ID = newObjectID()
+		}
+		srv.pipelineRules[rule.ID] = rule
+	}
+	for _, pipe := range seed.Pipelines {
+		if pipe.ID == "" {
+			pipe.ID = newObjectID()
+		}
+		srv.pipelines[pipe.ID] = pipe
+	}
+	for _, conn := range seed.PipelineConnections {
+		if conn.StreamID == "" {
+			return fmt.Errorf("stream id of pipeline connection is empty")
+		}
+		if conn.ID == "" {
+			conn.ID = newObjectID()
+		}
+		srv.pipelineConnections[conn.StreamID] = conn
+	}
+	for _, cfg := range seed.CollectorConfigurations {
+		if cfg.ID == "" {
+			cfg.ID = newObjectID()
+		}
+		srv.collectorConfigurations[cfg.ID] = cfg
+	}
+	if seed.LDAPSetting != nil {
+		srv.ldapSetting = *seed.LDAPSetting
+	}
+	if seed.LDAPGroups != nil {
+		srv.ldapGroups = seed.LDAPGroups
+	}
+	for group, role := range seed.LDAPGroupRoleMapping {
+		srv.ldapGroupRoleMapping[group] = role
+	}
+	return nil
+}
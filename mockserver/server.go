@@ -0,0 +1,172 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+const apiPrefix = "/api"
+
+// Server represents a mock of the Graylog API.
+// Server implements http.Handler, so Server can be used with net/http/httptest directly.
+type Server struct {
+	*store
+	server      *httptest.Server
+	endpoint    string
+	authEnabled bool
+	routes      []route
+	mutex       sync.Mutex
+}
+
+// NewServer returns a new Server but doesn't start it.
+// The argument `addr` is the address which the server listens.
+//
+//	server, err := mockserver.NewServer(":8000", nil)
+//
+// If addr is an empty string, a free port is assigned automatically.
+// The argument `seed` is the initial data of the server.
+// If `seed` is nil, DefaultSeed is used.
+// To start the server, call the Start method.
+//
+//	server.Start()
+//	defer server.Close()
+func NewServer(addr string, seed *Seed) (*Server, error) {
+	if seed == nil {
+		seed = DefaultSeed()
+	}
+	srv := &Server{
+		store: newStore(),
+		// By default the authentication is enabled
+		authEnabled: true,
+	}
+	srv.setRoutes()
+	if err := srv.Seed(seed); err != nil {
+		return nil, err
+	}
+	srv.server = httptest.NewUnstartedServer(srv)
+	if addr != "" {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		srv.server.Listener.Close()
+		srv.server.Listener = ln
+	}
+	srv.endpoint = fmt.Sprintf("http://%s%s", srv.server.Listener.Addr().String(), apiPrefix)
+	return srv, nil
+}
+
+// Start starts the server.
+func (srv *Server) Start() {
+	srv.server.Start()
+}
+
+// Close shuts down the server and blocks until all outstanding requests on this server have completed.
+func (srv *Server) Close() {
+	srv.server.Close()
+}
+
+// Endpoint returns the endpoint url.
+//
+//	server, err := mockserver.NewServer(":8000", nil)
+//	fmt.Println(server.Endpoint()) // http://[::]:8000/api
+func (srv *Server) Endpoint() string {
+	return srv.endpoint
+}
+
+// SetAuth sets whether the authentication is enabled.
+// By default the authentication is enabled.
+func (srv *Server) SetAuth(authEnabled bool) {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	srv.authEnabled = authEnabled
+}
+
+// Auth returns whether the authentication is enabled.
+func (srv *Server) Auth() bool {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	return srv.authEnabled
+}
+
+// authenticate authenticates a request with the basic authentication.
+func (srv *Server) authenticate(r *http.Request) (*graylog.User, bool) {
+	name, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, false
+	}
+	user, ok := srv.users[name]
+	if !ok || user.Password != password {
+		return nil, false
+	}
+	return &user, true
+}
+
+// ServeHTTP handles a Graylog API request.
+// Requests are handled one by one, so the data is consistent.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	if !strings.HasPrefix(r.URL.Path, apiPrefix+"/") {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("HTTP 404 Not Found: %s", r.URL.Path))
+		return
+	}
+	p := strings.TrimPrefix(r.URL.Path, apiPrefix)
+	if srv.authEnabled {
+		user, ok := srv.authenticate(r)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "authentication failure")
+			return
+		}
+		srv.currentUser = user
+		defer func() {
+			srv.currentUser = nil
+		}()
+	}
+	if r.Method != http.MethodGet && r.Header.Get("X-Requested-By") == "" {
+		// Graylog rejects the request without the header "X-Requested-By" for CSRF protection
+		writeError(w, http.StatusBadRequest, "the header X-Requested-By is required")
+		return
+	}
+	rt, ps, pathMatched := srv.lookup(r.Method, p)
+	if rt == nil {
+		if pathMatched {
+			writeError(w, http.StatusMethodNotAllowed, "HTTP 405 Method Not Allowed")
+			return
+		}
+		writeError(w, http.StatusNotFound, fmt.Sprintf("HTTP 404 Not Found: %s", r.URL.Path))
+		return
+	}
+	body, sc, err := rt.handler(r, ps)
+	if err != nil {
+		writeError(w, sc, err.Error())
+		return
+	}
+	writeJSON(w, sc, body)
+}
+
+// decodeBody decodes the request body.
+func decodeBody(r *http.Request, v interface{}) (int, error) {
+	if r.Body == nil {
+		return http.StatusBadRequest, fmt.Errorf("request body is required")
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("failed to parse the request body as JSON: %v", err)
+	}
+	return http.StatusOK, nil
+}
+
+// creatorUserID returns the name of the authenticated user.
+func (srv *Server) creatorUserID() string {
+	if srv.currentUser == nil {
+		return "admin"
+	}
+	return srv.currentUser.Username
+}
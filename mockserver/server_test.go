@@ -0,0 +1,145 @@
+package mockserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+)
+
+func newServerAndClient(t *testing.T, seed *mockserver.Seed) (*mockserver.Server, *client.Client) {
+	server, err := mockserver.NewServer("", seed)
+	require.Nil(t, err)
+	server.Start()
+	cl, err := client.NewClient(server.Endpoint(), "admin", mockserver.DefaultPassword)
+	if err != nil {
+		server.Close()
+		require.Nil(t, err)
+	}
+	return server, cl
+}
+
+func request(t *testing.T, method, u, body string, header map[string]string) (*http.Response, *mockserver.APIError) {
+	var req *http.Request
+	var err error
+	if body == "" {
+		req, err = http.NewRequest(method, u, nil)
+	} else {
+		req, err = http.NewRequest(method, u, strings.NewReader(body))
+	}
+	require.Nil(t, err)
+	req.SetBasicAuth("admin", mockserver.DefaultPassword)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+	e := &mockserver.APIError{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(e))
+	return resp, e
+}
+
+func TestServer_ServeHTTP(t *testing.T) {
+	server, _ := newServerAndClient(t, nil)
+	defer server.Close()
+	xrb := map[string]string{"X-Requested-By": "test"}
+
+	resp, _ := request(t, http.MethodGet, server.Endpoint()+"/roles/Admin", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, e := request(t, http.MethodGet, server.Endpoint()+"/foo", "", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, "ApiError", e.Type)
+
+	resp, _ = request(t, http.MethodPatch, server.Endpoint()+"/roles/Admin", "", xrb)
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, e = request(t, http.MethodPost, server.Endpoint()+"/roles", `{}`, nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Contains(t, e.Message, "X-Requested-By")
+
+	resp, _ = request(t, http.MethodPost, server.Endpoint()+"/roles", `{`, xrb)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestServer_SetAuth(t *testing.T) {
+	server, err := mockserver.NewServer("", nil)
+	require.Nil(t, err)
+	server.Start()
+	defer server.Close()
+	require.True(t, server.Auth())
+
+	ctx := context.Background()
+	cl, err := client.NewClient(server.Endpoint(), "admin", "invalid password")
+	require.Nil(t, err)
+	_, _, ei, err := cl.GetRoles(ctx)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusUnauthorized, ei.Response.StatusCode)
+
+	server.SetAuth(false)
+	require.False(t, server.Auth())
+	_, _, _, err = cl.GetRoles(ctx)
+	require.Nil(t, err)
+}
+
+func TestServer_Seed(t *testing.T) {
+	server, cl := newServerAndClient(t, mockserver.TestdataSeed())
+	defer server.Close()
+	ctx := context.Background()
+
+	stream, _, err := cl.GetStream(ctx, mockserver.DefaultStreamID)
+	require.Nil(t, err)
+	require.True(t, stream.IsDefault)
+
+	// testdata has its own default stream and default index set, which replace the seeded ones.
+	seed := mockserver.TestdataSeed()
+	cnt := 0
+	for _, stream := range seed.Streams {
+		if stream.IsDefault {
+			cnt++
+		}
+	}
+	require.Equal(t, 1, cnt)
+	cnt = 0
+	for _, is := range seed.IndexSets {
+		if is.Default {
+			cnt++
+		}
+	}
+	require.Equal(t, 1, cnt)
+
+	indexSets, _, _, _, err := cl.GetIndexSets(ctx, 0, 0, false)
+	require.Nil(t, err)
+	titles := map[string]bool{}
+	for _, is := range indexSets {
+		require.False(t, titles[is.Title], "index set titles must be unique: "+is.Title)
+		titles[is.Title] = true
+		if is.Default {
+			require.Equal(t, stream.IndexSetID, is.ID)
+		}
+	}
+
+	streams, _, _, err := cl.GetStreams(ctx)
+	require.Nil(t, err)
+	require.True(t, len(streams) > 1)
+
+	dashboards, _, _, err := cl.GetDashboards(ctx)
+	require.Nil(t, err)
+	require.NotEmpty(t, dashboards)
+
+	_, ei, err := cl.GetUser(ctx, "not found")
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusNotFound, ei.Response.StatusCode)
+	require.Equal(t, "ApiError", ei.Type)
+	require.NotEmpty(t, ei.Message)
+}
@@ -0,0 +1,78 @@
+package mockserver
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// store is the in-memory data of the mock server.
+// Graylog's resources are stored as values and
+// nested slices and maps are replaced instead of being modified,
+// so the values returned by the server are never changed afterwards.
+type store struct {
+	currentUser *graylog.User
+
+	users                   map[string]graylog.User
+	roles                   map[string]graylog.Role
+	indexSets               map[string]graylog.IndexSet
+	indexSetStats           map[string]graylog.IndexSetStats
+	inputs                  map[string]graylog.Input
+	extractors              map[string]map[string]graylog.Extractor
+	streams                 map[string]graylog.Stream
+	alarmCallbacks          map[string]graylog.AlarmCallback
+	alerts                  map[string]graylog.Alert
+	dashboards              map[string]graylog.Dashboard
+	grokPatterns            map[string]graylog.GrokPattern
+	pipelines               map[string]graylog.Pipeline
+	pipelineRules           map[string]graylog.PipelineRule
+	pipelineConnections     map[string]graylog.PipelineConnection
+	collectorConfigurations map[string]graylog.CollectorConfiguration
+	ldapSetting             graylog.LDAPSetting
+	ldapGroups              []string
+	ldapGroupRoleMapping    map[string]string
+}
+
+func newStore() *store {
+	return &store{
+		users:                   map[string]graylog.User{},
+		roles:                   map[string]graylog.Role{},
+		indexSets:               map[string]graylog.IndexSet{},
+		indexSetStats:           map[string]graylog.IndexSetStats{},
+		inputs:                  map[string]graylog.Input{},
+		extractors:              map[string]map[string]graylog.Extractor{},
+		streams:                 map[string]graylog.Stream{},
+		alarmCallbacks:          map[string]graylog.AlarmCallback{},
+		alerts:                  map[string]graylog.Alert{},
+		dashboards:              map[string]graylog.Dashboard{},
+		grokPatterns:            map[string]graylog.GrokPattern{},
+		pipelines:               map[string]graylog.Pipeline{},
+		pipelineRules:           map[string]graylog.PipelineRule{},
+		pipelineConnections:     map[string]graylog.PipelineConnection{},
+		collectorConfigurations: map[string]graylog.CollectorConfiguration{},
+		ldapGroups:              []string{},
+		ldapGroupRoleMapping:    map[string]string{},
+	}
+}
+
+// newObjectID returns a new MongoDB's ObjectId as Graylog's resource id.
+func newObjectID() string {
+	return bson.NewObjectId().Hex()
+}
+
+// newUUID returns a new UUID as the id of alert conditions and dashboard widgets.
+func newUUID() string {
+	u, err := uuid.NewV4()
+	if err != nil {
+		return newObjectID()
+	}
+	return u.String()
+}
+
+// now returns the current time with the format of Graylog API.
+func now() string {
+	return time.Now().UTC().Format(graylog.CreationDateFormat)
+}
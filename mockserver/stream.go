@@ -0,0 +1,192 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+func (srv *Server) getStream(id string) (*graylog.Stream, int, error) {
+	stream, ok := srv.streams[id]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("stream <%s> not found", id)
+	}
+	return &stream, http.StatusOK, nil
+}
+
+// streamResponse returns a stream whose nil slices are empty.
+func streamResponse(stream graylog.Stream) graylog.Stream {
+	if stream.Rules == nil {
+		stream.Rules = []graylog.StreamRule{}
+	}
+	if stream.AlertConditions == nil {
+		stream.AlertConditions = []graylog.AlertCondition{}
+	}
+	if stream.Outputs == nil {
+		stream.Outputs = []graylog.Output{}
+	}
+	if stream.AlertReceivers == nil {
+		stream.AlertReceivers = &graylog.AlertReceivers{Emails: []string{}, Users: []string{}}
+	}
+	return stream
+}
+
+func (srv *Server) getStreams(enabledOnly bool) *graylog.StreamsBody {
+	streams := make([]graylog.Stream, 0, len(srv.streams))
+	for _, stream := range srv.streams {
+		if enabledOnly && stream.Disabled {
+			continue
+		}
+		streams = append(streams, streamResponse(stream))
+	}
+	sort.Slice(streams, func(i, j int) bool {
+		return streams[i].ID < streams[j].ID
+	})
+	return &graylog.StreamsBody{Streams: streams, Total: len(streams)}
+}
+
+// checkIndexSet returns an error if the index set doesn't exist.
+func (srv *Server) checkIndexSet(id string) (int, error) {
+	if _, ok := srv.indexSets[id]; !ok {
+		return http.StatusBadRequest, fmt.Errorf("invalid index set ID: %s", id)
+	}
+	return http.StatusOK, nil
+}
+
+// GET /streams Get a list of all streams
+func (srv *Server) handleGetStreams(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.getStreams(false), http.StatusOK, nil
+}
+
+// GET /streams/enabled Get a list of all enabled streams
+func (srv *Server) handleGetEnabledStreams(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.getStreams(true), http.StatusOK, nil
+}
+
+// GET /streams/{streamID} Get a single stream
+func (srv *Server) handleGetStream(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	s := streamResponse(*stream)
+	return &s, http.StatusOK, nil
+}
+
+// POST /streams Create a stream
+func (srv *Server) handleCreateStream(r *http.Request, ps params) (interface{}, int, error) {
+	stream := &graylog.Stream{}
+	if sc, err := decodeBody(r, stream); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.CreateValidator.Struct(stream); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if sc, err := srv.checkIndexSet(stream.IndexSetID); err != nil {
+		return nil, sc, err
+	}
+	if stream.MatchingType == "" {
+		stream.MatchingType = "AND"
+	}
+	stream.ID = newObjectID()
+	stream.CreatedAt = now()
+	stream.CreatorUserID = srv.creatorUserID()
+	// a new stream is paused
+	stream.Disabled = true
+	rules := make([]graylog.StreamRule, len(stream.Rules))
+	for i, rule := range stream.Rules {
+		rule.ID = newObjectID()
+		rule.StreamID = stream.ID
+		rules[i] = rule
+	}
+	stream.Rules = rules
+	srv.streams[stream.ID] = *stream
+	return map[string]string{"stream_id": stream.ID}, http.StatusCreated, nil
+}
+
+// PUT /streams/{streamID} Update a stream
+func (srv *Server) handleUpdateStream(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	prms := &graylog.StreamUpdateParams{}
+	if sc, err := decodeBody(r, prms); err != nil {
+		return nil, sc, err
+	}
+	prms.ID = stream.ID
+	if err := validator.UpdateValidator.Struct(prms); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if prms.IndexSetID != "" {
+		if sc, err := srv.checkIndexSet(prms.IndexSetID); err != nil {
+			return nil, sc, err
+		}
+		stream.IndexSetID = prms.IndexSetID
+	}
+	if prms.Title != "" {
+		stream.Title = prms.Title
+	}
+	if prms.Description != "" {
+		stream.Description = prms.Description
+	}
+	if prms.MatchingType != "" {
+		stream.MatchingType = prms.MatchingType
+	}
+	if prms.RemoveMatchesFromDefaultStream != nil {
+		stream.RemoveMatchesFromDefaultStream = *prms.RemoveMatchesFromDefaultStream
+	}
+	srv.streams[stream.ID] = *stream
+	s := streamResponse(*stream)
+	return &s, http.StatusOK, nil
+}
+
+// DELETE /streams/{streamID} Delete a stream
+func (srv *Server) handleDeleteStream(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	if stream.IsDefault {
+		return nil, http.StatusBadRequest, fmt.Errorf("the default stream cannot be deleted")
+	}
+	delete(srv.streams, stream.ID)
+	delete(srv.pipelineConnections, stream.ID)
+	for id, ac := range srv.alarmCallbacks {
+		if ac.StreamID == stream.ID {
+			delete(srv.alarmCallbacks, id)
+		}
+	}
+	for id, alert := range srv.alerts {
+		if alert.StreamID == stream.ID {
+			delete(srv.alerts, id)
+		}
+	}
+	return nil, http.StatusNoContent, nil
+}
+
+// POST /streams/{streamID}/pause Pause a stream
+func (srv *Server) handlePauseStream(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.setStreamDisabled(ps["streamID"], true)
+}
+
+// POST /streams/{streamID}/resume Resume a stream
+func (srv *Server) handleResumeStream(r *http.Request, ps params) (interface{}, int, error) {
+	return srv.setStreamDisabled(ps["streamID"], false)
+}
+
+func (srv *Server) setStreamDisabled(id string, disabled bool) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(id)
+	if err != nil {
+		return nil, sc, err
+	}
+	if stream.IsDefault && disabled {
+		return nil, http.StatusBadRequest, fmt.Errorf("the default stream cannot be paused")
+	}
+	stream.Disabled = disabled
+	srv.streams[id] = *stream
+	return nil, http.StatusNoContent, nil
+}
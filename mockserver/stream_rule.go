@@ -0,0 +1,151 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// streamRuleTypes are the stream rule types which Graylog supports.
+var streamRuleTypes = []graylog.StreamRuleType{
+	{ID: 1, Name: "EXACT", ShortDesc: "match exactly", LongDesc: "match exactly"},
+	{ID: 2, Name: "REGEX", ShortDesc: "match regular expression", LongDesc: "match regular expression"},
+	{ID: 3, Name: "GREATER", ShortDesc: "greater than", LongDesc: "be greater than"},
+	{ID: 4, Name: "SMALLER", ShortDesc: "smaller than", LongDesc: "be smaller than"},
+	{ID: 5, Name: "PRESENCE", ShortDesc: "field presence", LongDesc: "be present"},
+	{ID: 6, Name: "CONTAINS", ShortDesc: "contain", LongDesc: "contain"},
+	{ID: 7, Name: "ALWAYS_MATCH", ShortDesc: "always match", LongDesc: "always match"},
+	{ID: 8, Name: "MATCH_INPUT", ShortDesc: "match input", LongDesc: "match input"},
+}
+
+func validStreamRuleType(t int) bool {
+	for _, rt := range streamRuleTypes {
+		if rt.ID == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (srv *Server) getStreamRule(streamID, ruleID string) (*graylog.Stream, int, int, error) {
+	stream, sc, err := srv.getStream(streamID)
+	if err != nil {
+		return nil, -1, sc, err
+	}
+	for i, rule := range stream.Rules {
+		if rule.ID == ruleID {
+			return stream, i, http.StatusOK, nil
+		}
+	}
+	return nil, -1, http.StatusNotFound, fmt.Errorf("couldn't find stream rule %s in stream %s", ruleID, streamID)
+}
+
+// GET /streams/{streamid}/rules Get a list of all stream rules
+func (srv *Server) handleGetStreamRules(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	rules := stream.Rules
+	if rules == nil {
+		rules = []graylog.StreamRule{}
+	}
+	return &graylog.StreamRulesBody{StreamRules: rules, Total: len(rules)}, http.StatusOK, nil
+}
+
+// GET /streams/{streamid}/rules/{streamRuleId} Get a single stream rule
+func (srv *Server) handleGetStreamRule(r *http.Request, ps params) (interface{}, int, error) {
+	stream, i, sc, err := srv.getStreamRule(ps["streamID"], ps["streamRuleID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	rule := stream.Rules[i]
+	return &rule, http.StatusOK, nil
+}
+
+// GET /streams/{streamid}/rules/types Get all available stream types
+func (srv *Server) handleGetStreamRuleTypes(r *http.Request, ps params) (interface{}, int, error) {
+	if _, sc, err := srv.getStream(ps["streamID"]); err != nil {
+		return nil, sc, err
+	}
+	return streamRuleTypes, http.StatusOK, nil
+}
+
+// POST /streams/{streamid}/rules Create a stream rule
+func (srv *Server) handleCreateStreamRule(r *http.Request, ps params) (interface{}, int, error) {
+	stream, sc, err := srv.getStream(ps["streamID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	rule := &graylog.StreamRule{}
+	if sc, err := decodeBody(r, rule); err != nil {
+		return nil, sc, err
+	}
+	rule.StreamID = stream.ID
+	if rule.Type == 0 {
+		rule.Type = 1
+	}
+	if err := validator.CreateValidator.Struct(rule); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if !validStreamRuleType(rule.Type) {
+		return nil, http.StatusBadRequest, fmt.Errorf("unknown stream rule type %d", rule.Type)
+	}
+	rule.ID = newObjectID()
+	rules := make([]graylog.StreamRule, len(stream.Rules), len(stream.Rules)+1)
+	copy(rules, stream.Rules)
+	stream.Rules = append(rules, *rule)
+	srv.streams[stream.ID] = *stream
+	return map[string]string{"streamrule_id": rule.ID}, http.StatusCreated, nil
+}
+
+// PUT /streams/{streamid}/rules/{streamRuleId} Update a stream rule
+func (srv *Server) handleUpdateStreamRule(r *http.Request, ps params) (interface{}, int, error) {
+	stream, i, sc, err := srv.getStreamRule(ps["streamID"], ps["streamRuleID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	prms := &graylog.StreamRuleUpdateParams{}
+	if sc, err := decodeBody(r, prms); err != nil {
+		return nil, sc, err
+	}
+	prms.ID = ps["streamRuleID"]
+	prms.StreamID = stream.ID
+	if err := validator.UpdateValidator.Struct(prms); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	rule := stream.Rules[i]
+	rule.Field = prms.Field
+	rule.Value = prms.Value
+	rule.Description = prms.Description
+	if prms.Type != nil {
+		if !validStreamRuleType(*prms.Type) {
+			return nil, http.StatusBadRequest, fmt.Errorf("unknown stream rule type %d", *prms.Type)
+		}
+		rule.Type = *prms.Type
+	}
+	if prms.Inverted != nil {
+		rule.Inverted = *prms.Inverted
+	}
+	rules := make([]graylog.StreamRule, len(stream.Rules))
+	copy(rules, stream.Rules)
+	rules[i] = rule
+	stream.Rules = rules
+	srv.streams[stream.ID] = *stream
+	return map[string]string{"streamrule_id": rule.ID}, http.StatusOK, nil
+}
+
+// DELETE /streams/{streamid}/rules/{streamRuleId} Delete a stream rule
+func (srv *Server) handleDeleteStreamRule(r *http.Request, ps params) (interface{}, int, error) {
+	stream, i, sc, err := srv.getStreamRule(ps["streamID"], ps["streamRuleID"])
+	if err != nil {
+		return nil, sc, err
+	}
+	rules := make([]graylog.StreamRule, 0, len(stream.Rules)-1)
+	rules = append(rules, stream.Rules[:i]...)
+	stream.Rules = append(rules, stream.Rules[i+1:]...)
+	srv.streams[stream.ID] = *stream
+	return nil, http.StatusNoContent, nil
+}
@@ -0,0 +1,109 @@
+package mockserver_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+)
+
+func TestStream(t *testing.T) {
+	server, cl := newServerAndClient(t, nil)
+	defer server.Close()
+	ctx := context.Background()
+
+	stream := &graylog.Stream{
+		Title:      "test",
+		IndexSetID: mockserver.DefaultIndexSetID,
+		Rules: []graylog.StreamRule{{
+			Field: "tag", Value: "foo", Type: 1,
+		}},
+	}
+	_, err := cl.CreateStream(ctx, stream)
+	require.Nil(t, err)
+	require.NotEmpty(t, stream.ID)
+
+	// a new stream is paused
+	streams, _, _, err := cl.GetEnabledStreams(ctx)
+	require.Nil(t, err)
+	require.Len(t, streams, 1)
+	_, err = cl.ResumeStream(ctx, stream.ID)
+	require.Nil(t, err)
+	streams, _, _, err = cl.GetEnabledStreams(ctx)
+	require.Nil(t, err)
+	require.Len(t, streams, 2)
+
+	rules, total, _, err := cl.GetStreamRules(ctx, stream.ID)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, stream.ID, rules[0].StreamID)
+
+	rule := &graylog.StreamRule{StreamID: stream.ID, Field: "tag", Value: "bar", Type: 2}
+	_, err = cl.CreateStreamRule(ctx, rule)
+	require.Nil(t, err)
+	require.NotEmpty(t, rule.ID)
+	rule.Value = "baz"
+	_, err = cl.UpdateStreamRule(ctx, rule)
+	require.Nil(t, err)
+	r, _, err := cl.GetStreamRule(ctx, stream.ID, rule.ID)
+	require.Nil(t, err)
+	require.Equal(t, "baz", r.Value)
+
+	stream.Title = "updated"
+	_, err = cl.UpdateStream(ctx, stream)
+	require.Nil(t, err)
+	s, _, err := cl.GetStream(ctx, stream.ID)
+	require.Nil(t, err)
+	require.Equal(t, "updated", s.Title)
+	require.Len(t, s.Rules, 2)
+
+	// the index set must exist
+	_, err = cl.CreateStream(ctx, &graylog.Stream{Title: "test", IndexSetID: "000000000000000000000000"})
+	require.NotNil(t, err)
+
+	// the default stream can't be deleted
+	ei, err := cl.DeleteStream(ctx, mockserver.DefaultStreamID)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusBadRequest, ei.Response.StatusCode)
+
+	_, err = cl.DeleteStream(ctx, stream.ID)
+	require.Nil(t, err)
+	ei, err = cl.DeleteStream(ctx, stream.ID)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusNotFound, ei.Response.StatusCode)
+}
+
+func TestStreamAlertCondition(t *testing.T) {
+	server, cl := newServerAndClient(t, nil)
+	defer server.Close()
+	ctx := context.Background()
+
+	cond := &graylog.AlertCondition{
+		Title: "test",
+		Parameters: graylog.MessageCountAlertConditionParameters{
+			Grace: 1, Threshold: 1, Time: 5, ThresholdType: "MORE",
+		},
+	}
+	_, err := cl.CreateStreamAlertCondition(ctx, mockserver.DefaultStreamID, cond)
+	require.Nil(t, err)
+	require.NotEmpty(t, cond.ID)
+
+	c, _, err := cl.GetStreamAlertCondition(ctx, mockserver.DefaultStreamID, cond.ID)
+	require.Nil(t, err)
+	require.Equal(t, "message_count", c.Type())
+
+	conds, total, _, err := cl.GetAlertConditions(ctx)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, cond.ID, conds[0].ID)
+
+	_, err = cl.DeleteStreamAlertCondition(ctx, mockserver.DefaultStreamID, cond.ID)
+	require.Nil(t, err)
+	_, total, _, err = cl.GetStreamAlertConditions(ctx, mockserver.DefaultStreamID)
+	require.Nil(t, err)
+	require.Equal(t, 0, total)
+}
@@ -0,0 +1,135 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-set"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// userResponse returns a user without the password.
+func userResponse(user graylog.User) *graylog.User {
+	user.Password = ""
+	if user.Permissions == nil {
+		user.Permissions = set.StrSet{}
+	}
+	return &user
+}
+
+func (srv *Server) getUsers() []graylog.User {
+	users := make([]graylog.User, 0, len(srv.users))
+	for _, user := range srv.users {
+		users = append(users, *userResponse(user))
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+	return users
+}
+
+// checkRoles returns an error if any of roles doesn't exist.
+func (srv *Server) checkRoles(roles set.StrSet) (int, error) {
+	for _, name := range roles.ToList() {
+		if _, ok := srv.roles[name]; !ok {
+			return http.StatusBadRequest, fmt.Errorf("invalid role names: %s", name)
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// GET /users List all users
+func (srv *Server) handleGetUsers(r *http.Request, ps params) (interface{}, int, error) {
+	return &graylog.UsersBody{Users: srv.getUsers()}, http.StatusOK, nil
+}
+
+// GET /users/{username} Get user details
+func (srv *Server) handleGetUser(r *http.Request, ps params) (interface{}, int, error) {
+	user, ok := srv.users[ps["username"]]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't find user %s", ps["username"])
+	}
+	return userResponse(user), http.StatusOK, nil
+}
+
+// POST /users Create a new user account.
+func (srv *Server) handleCreateUser(r *http.Request, ps params) (interface{}, int, error) {
+	user := &graylog.User{}
+	if sc, err := decodeBody(r, user); err != nil {
+		return nil, sc, err
+	}
+	if err := validator.CreateValidator.Struct(user); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if _, ok := srv.users[user.Username]; ok {
+		return nil, http.StatusBadRequest, fmt.Errorf("user %s already exists", user.Username)
+	}
+	if sc, err := srv.checkRoles(user.Roles); err != nil {
+		return nil, sc, err
+	}
+	user.ID = newObjectID()
+	user.SetDefaultValues()
+	srv.users[user.Username] = *user
+	return nil, http.StatusCreated, nil
+}
+
+// PUT /users/{username} Modify user details.
+func (srv *Server) handleUpdateUser(r *http.Request, ps params) (interface{}, int, error) {
+	user, ok := srv.users[ps["username"]]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't find user %s", ps["username"])
+	}
+	prms := &graylog.UserUpdateParams{}
+	if sc, err := decodeBody(r, prms); err != nil {
+		return nil, sc, err
+	}
+	prms.Username = user.Username
+	if err := validator.UpdateValidator.Struct(prms); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if prms.Roles != nil {
+		if sc, err := srv.checkRoles(prms.Roles); err != nil {
+			return nil, sc, err
+		}
+		user.Roles = prms.Roles
+	}
+	if prms.Email != nil {
+		user.Email = *prms.Email
+	}
+	if prms.FullName != nil {
+		user.FullName = *prms.FullName
+	}
+	if prms.Password != nil {
+		user.Password = *prms.Password
+	}
+	if prms.Timezone != nil {
+		user.Timezone = *prms.Timezone
+	}
+	if prms.SessionTimeoutMs != nil {
+		user.SessionTimeoutMs = *prms.SessionTimeoutMs
+	}
+	if prms.Permissions != nil {
+		user.Permissions = prms.Permissions
+	}
+	if prms.Startpage != nil {
+		user.Startpage = prms.Startpage
+	}
+	srv.users[user.Username] = user
+	return nil, http.StatusNoContent, nil
+}
+
+// DELETE /users/{username} Removes a user account.
+func (srv *Server) handleDeleteUser(r *http.Request, ps params) (interface{}, int, error) {
+	name := ps["username"]
+	if _, ok := srv.users[name]; !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("couldn't find user %s", name)
+	}
+	if srv.currentUser != nil && srv.currentUser.Username == name {
+		return nil, http.StatusBadRequest, fmt.Errorf("you can't delete yourself")
+	}
+	delete(srv.users, name)
+	return nil, http.StatusNoContent, nil
+}
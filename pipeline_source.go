@@ -0,0 +1,85 @@
+package graylog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	pipelineTitleRegexp = regexp.MustCompile(`pipeline\s+"([^"]+)"`)
+	pipelineStageRegexp = regexp.MustCompile(`stage\s+(-?\d+)\s+match\s+(all|either)`)
+	pipelineRuleRegexp  = regexp.MustCompile(`rule\s+"([^"]+)"`)
+)
+
+// ParsePipelineSource returns the title and stages of a pipeline.
+// Only the title, stage numbers, match types and rule names are parsed.
+func ParsePipelineSource(source string) (string, []PipelineStage, error) {
+	m := pipelineTitleRegexp.FindStringSubmatchIndex(source)
+	if m == nil {
+		return "", nil, errors.New("failed to parse the pipeline source: pipeline title is not found")
+	}
+	title := source[m[2]:m[3]]
+	body := source[m[1]:]
+	stages := []PipelineStage{}
+	locs := pipelineStageRegexp.FindAllStringSubmatchIndex(body, -1)
+	for i, loc := range locs {
+		stage, err := strconv.Atoi(body[loc[2]:loc[3]])
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse the pipeline source: invalid stage: %v", err)
+		}
+		end := len(body)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		rules := []string{}
+		for _, r := range pipelineRuleRegexp.FindAllStringSubmatch(body[loc[1]:end], -1) {
+			rules = append(rules, r[1])
+		}
+		stages = append(stages, PipelineStage{
+			Stage:    stage,
+			MatchAll: body[loc[4]:loc[5]] == "all",
+			Rules:    rules,
+		})
+	}
+	return title, stages, nil
+}
+
+// ParsePipelineRuleSource returns the title of a pipeline rule.
+// The rule's condition and actions aren't parsed.
+func ParsePipelineRuleSource(source string) (string, error) {
+	m := pipelineRuleRegexp.FindStringSubmatch(source)
+	if m == nil {
+		return "", errors.New("failed to parse the pipeline rule source: rule title is not found")
+	}
+	return m[1], nil
+}
+
+// NewPipelineSource generates the canonical source of a pipeline.
+// The stages are sorted by the stage number and the rules of each stage are sorted by the name,
+// so the pipelines which are semantically same have the same source.
+func NewPipelineSource(title string, stages []PipelineStage) string {
+	a := make([]PipelineStage, len(stages))
+	copy(a, stages)
+	sort.Slice(a, func(i, j int) bool {
+		return a[i].Stage < a[j].Stage
+	})
+	lines := []string{fmt.Sprintf(`pipeline "%s"`, title)}
+	for _, stage := range a {
+		match := "either"
+		if stage.MatchAll {
+			match = "all"
+		}
+		lines = append(lines, fmt.Sprintf("stage %d match %s", stage.Stage, match))
+		rules := make([]string, len(stage.Rules))
+		copy(rules, stage.Rules)
+		sort.Strings(rules)
+		for _, rule := range rules {
+			lines = append(lines, fmt.Sprintf(`  rule "%s";`, rule))
+		}
+	}
+	return strings.Join(append(lines, "end"), "\n") + "\n"
+}
@@ -0,0 +1,71 @@
+package graylog_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func TestParsePipelineSource(t *testing.T) {
+	title, stages, err := graylog.ParsePipelineSource(`pipeline "test"
+stage 1 match all
+  rule "foo";
+  rule "bar";
+stage 0 match either
+end`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "test" {
+		t.Fatalf(`title = "%s", wanted "test"`, title)
+	}
+	exp := []graylog.PipelineStage{
+		{Stage: 1, MatchAll: true, Rules: []string{"foo", "bar"}},
+		{Stage: 0, MatchAll: false, Rules: []string{}},
+	}
+	if !reflect.DeepEqual(stages, exp) {
+		t.Fatalf("stages = %+v, wanted %+v", stages, exp)
+	}
+	if _, _, err := graylog.ParsePipelineSource("stage 0 match either\nend"); err == nil {
+		t.Fatal("the source without title should be invalid")
+	}
+}
+
+func TestParsePipelineRuleSource(t *testing.T) {
+	title, err := graylog.ParsePipelineRuleSource(`rule "has foo"
+when
+  has_field("foo")
+then
+end`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "has foo" {
+		t.Fatalf(`title = "%s", wanted "has foo"`, title)
+	}
+}
+
+func TestNewPipelineSource(t *testing.T) {
+	src := graylog.NewPipelineSource("test", []graylog.PipelineStage{
+		{Stage: 1, MatchAll: true, Rules: []string{"foo", "bar"}},
+		{Stage: 0, Rules: []string{}},
+	})
+	exp := `pipeline "test"
+stage 0 match either
+stage 1 match all
+  rule "bar";
+  rule "foo";
+end
+`
+	if src != exp {
+		t.Fatalf("source = %s, wanted %s", src, exp)
+	}
+	title, stages, err := graylog.ParsePipelineSource(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "test" || len(stages) != 2 {
+		t.Fatalf("failed to parse the generated source: %s", src)
+	}
+}
@@ -0,0 +1,21 @@
+/*
+Package syslog sends RFC 3164 and RFC 5424 syslog messages to Graylog's syslog inputs,
+and plain text lines to Graylog's raw inputs.
+
+	s, err := syslog.NewTCPSender("graylog.example.com:514", nil)
+	s.Framing = syslog.FramingOctetCounting
+	defer s.Close()
+	err = s.Send(&syslog.Message{
+		Facility: syslog.FacilityUser,
+		Severity: syslog.SeverityInfo,
+		AppName:  "app",
+		Message:  "hello",
+	})
+
+The sender can be created from a graylog.Input, which sets the framing and the max message size
+by the input's attributes.
+
+	s, err := syslog.NewSenderFromInput(input, &syslog.InputOptions{Host: "graylog.example.com"})
+	r, err := syslog.NewRawSenderFromInput(rawInput, &syslog.InputOptions{Host: "graylog.example.com"})
+*/
+package syslog
@@ -0,0 +1,157 @@
+package syslog
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// InputOptions is the options of NewSenderFromInput and NewRawSenderFromInput.
+type InputOptions struct {
+	// Host is the host name of the Graylog node where the input runs.
+	// If Host is empty, the input's bind address is used unless it is a wildcard address.
+	Host string
+	// TLSConfig is used when the input enables TLS. If it is nil, the default config is used.
+	TLSConfig *tls.Config
+	// Format is the syslog message format.
+	Format Format
+	// OctetCounting makes the TCP sender use the octet counting framing
+	// unless the input uses the null delimiter.
+	OctetCounting bool
+}
+
+// NewSenderFromInput returns a new sender which sends messages to a syslog UDP or TCP input.
+// The framing and the max message size are set by the input's attributes.
+func NewSenderFromInput(input *graylog.Input, opts *InputOptions) (*Sender, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+	if opts == nil {
+		opts = &InputOptions{}
+	}
+	var (
+		s   *Sender
+		err error
+	)
+	switch attrs := input.Attrs.(type) {
+	case *graylog.InputSyslogUDPAttrs:
+		s, err = newUDPSenderFromInput(attrs.BindAddress, attrs.Port, opts)
+	case *graylog.InputSyslogTCPAttrs:
+		s, err = newTCPSenderFromInput(tcpInput{
+			bindAddress: attrs.BindAddress, port: attrs.Port, tlsEnable: attrs.TLSEnable,
+			useNullDelimiter: attrs.UseNullDelimiter, maxMessageSize: attrs.MaxMessageSize,
+		}, opts)
+	default:
+		return nil, fmt.Errorf("input type %s isn't a syslog UDP or TCP input", input.Type())
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Format = opts.Format
+	return s, nil
+}
+
+// NewRawSenderFromInput returns a new sender which sends lines to a raw UDP or TCP input.
+// The framing and the max message size are set by the input's attributes.
+func NewRawSenderFromInput(input *graylog.Input, opts *InputOptions) (*RawSender, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+	if opts == nil {
+		opts = &InputOptions{}
+	}
+	var (
+		t   *Transport
+		err error
+	)
+	switch attrs := input.Attrs.(type) {
+	case *graylog.InputRawUDPAttrs:
+		t, err = newUDPTransportFromInput(attrs.BindAddress, attrs.Port, opts)
+	case *graylog.InputRawTCPAttrs:
+		t, err = newTCPTransportFromInput(tcpInput{
+			bindAddress: attrs.BindAddress, port: attrs.Port, tlsEnable: attrs.TLSEnable,
+			useNullDelimiter: attrs.UseNullDelimiter, maxMessageSize: attrs.MaxMessageSize,
+		}, opts)
+	default:
+		return nil, fmt.Errorf("input type %s isn't a raw UDP or TCP input", input.Type())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RawSender{Transport: t}, nil
+}
+
+type tcpInput struct {
+	bindAddress      string
+	port             int
+	tlsEnable        bool
+	useNullDelimiter bool
+	maxMessageSize   int
+}
+
+func inputAddr(bindAddress string, port int, opts *InputOptions) (string, error) {
+	if port == 0 {
+		return "", fmt.Errorf("port of the input is empty")
+	}
+	host := opts.Host
+	if host == "" {
+		if ip := net.ParseIP(bindAddress); bindAddress == "" || (ip != nil && ip.IsUnspecified()) {
+			return "", fmt.Errorf("host is required because the input's bind address is %q", bindAddress)
+		}
+		host = bindAddress
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
+
+func newUDPTransportFromInput(bindAddress string, port int, opts *InputOptions) (*Transport, error) {
+	addr, err := inputAddr(bindAddress, port, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newTransport("udp", addr, nil)
+}
+
+func newTCPTransportFromInput(input tcpInput, opts *InputOptions) (*Transport, error) {
+	addr, err := inputAddr(input.bindAddress, input.port, opts)
+	if err != nil {
+		return nil, err
+	}
+	var cfg *tls.Config
+	if input.tlsEnable {
+		cfg = opts.TLSConfig
+		if cfg == nil {
+			cfg = &tls.Config{}
+		}
+	}
+	t, err := newTransport("tcp", addr, cfg)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case input.useNullDelimiter:
+		t.Framing = FramingNull
+	case opts.OctetCounting:
+		t.Framing = FramingOctetCounting
+	}
+	t.MaxMessageSize = input.maxMessageSize
+	return t, nil
+}
+
+func newUDPSenderFromInput(bindAddress string, port int, opts *InputOptions) (*Sender, error) {
+	t, err := newUDPTransportFromInput(bindAddress, port, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newSender(t), nil
+}
+
+func newTCPSenderFromInput(input tcpInput, opts *InputOptions) (*Sender, error) {
+	t, err := newTCPTransportFromInput(input, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newSender(t), nil
+}
@@ -0,0 +1,153 @@
+package syslog
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Format is the syslog message format.
+type Format int
+
+const (
+	// RFC5424 is the format of RFC 5424.
+	RFC5424 Format = iota
+	// RFC3164 is the format of RFC 3164 (BSD syslog).
+	RFC3164
+)
+
+// Severities.
+const (
+	SeverityEmergency = 0
+	SeverityAlert     = 1
+	SeverityCritical  = 2
+	SeverityError     = 3
+	SeverityWarning   = 4
+	SeverityNotice    = 5
+	SeverityInfo      = 6
+	SeverityDebug     = 7
+)
+
+// Facilities.
+const (
+	FacilityKern   = 0
+	FacilityUser   = 1
+	FacilityMail   = 2
+	FacilityDaemon = 3
+	FacilityAuth   = 4
+	FacilitySyslog = 5
+	FacilityLocal0 = 16
+	FacilityLocal1 = 17
+	FacilityLocal2 = 18
+	FacilityLocal3 = 19
+	FacilityLocal4 = 20
+	FacilityLocal5 = 21
+	FacilityLocal6 = 22
+	FacilityLocal7 = 23
+)
+
+const nilValue = "-"
+
+type (
+	// Message represents a syslog message.
+	Message struct {
+		Facility  int
+		Severity  int
+		Timestamp time.Time
+		Hostname  string
+		AppName   string
+		ProcID    string
+		// MsgID is used only in RFC 5424.
+		MsgID string
+		// StructuredData is used only in RFC 5424.
+		StructuredData []StructuredData
+		Message        string
+	}
+
+	// StructuredData represents a SD-ELEMENT of RFC 5424.
+	StructuredData struct {
+		ID     string
+		Params map[string]string
+	}
+)
+
+// Priority returns the PRI value.
+func (m *Message) Priority() int {
+	return m.Facility*8 + m.Severity
+}
+
+// Encode returns the message in the format.
+func (m *Message) Encode(f Format) ([]byte, error) {
+	switch f {
+	case RFC5424:
+		return m.RFC5424(), nil
+	case RFC3164:
+		return m.RFC3164(), nil
+	}
+	return nil, fmt.Errorf("unknown format: %d", f)
+}
+
+// RFC3164 returns the message in the format of RFC 3164.
+//
+//	<PRI>Mmm dd hh:mm:ss HOSTNAME TAG[PROCID]: MSG
+func (m *Message) RFC3164() []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "<%d>%s %s ", m.Priority(), m.Timestamp.Format(time.Stamp), orNil(m.Hostname))
+	if m.AppName != "" {
+		buf.WriteString(m.AppName)
+		if m.ProcID != "" {
+			buf.WriteString("[" + m.ProcID + "]")
+		}
+		buf.WriteString(": ")
+	}
+	buf.WriteString(m.Message)
+	return buf.Bytes()
+}
+
+// RFC5424 returns the message in the format of RFC 5424.
+//
+//	<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+func (m *Message) RFC5424() []byte {
+	buf := &bytes.Buffer{}
+	ts := nilValue
+	if !m.Timestamp.IsZero() {
+		ts = m.Timestamp.Format("2006-01-02T15:04:05.000000Z07:00")
+	}
+	fmt.Fprintf(buf, "<%d>1 %s %s %s %s %s ",
+		m.Priority(), ts, orNil(m.Hostname), orNil(m.AppName), orNil(m.ProcID), orNil(m.MsgID))
+	if len(m.StructuredData) == 0 {
+		buf.WriteString(nilValue)
+	}
+	for _, sd := range m.StructuredData {
+		buf.WriteString("[" + sd.ID)
+		keys := make([]string, 0, len(sd.Params))
+		for k := range sd.Params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			buf.WriteString(" " + k + `="` + escapeParam(sd.Params[k]) + `"`)
+		}
+		buf.WriteString("]")
+	}
+	if m.Message != "" {
+		buf.WriteString(" " + m.Message)
+	}
+	return buf.Bytes()
+}
+
+func orNil(s string) string {
+	if s == "" {
+		return nilValue
+	}
+	return s
+}
+
+var paramEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// escapeParam escapes PARAM-VALUE of RFC 5424.
+func escapeParam(s string) string {
+	return paramEscaper.Replace(s)
+}
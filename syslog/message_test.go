@@ -0,0 +1,51 @@
+package syslog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/syslog"
+)
+
+func TestMessage_RFC3164(t *testing.T) {
+	m := &syslog.Message{
+		Facility:  syslog.FacilityAuth,
+		Severity:  syslog.SeverityCritical,
+		Timestamp: time.Date(2019, 10, 11, 22, 14, 15, 0, time.UTC),
+		Hostname:  "mymachine",
+		AppName:   "su",
+		ProcID:    "123",
+		Message:   "'su root' failed for lonvick on /dev/pts/8",
+	}
+	require.Equal(t, "<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8", string(m.RFC3164()))
+	m.AppName = ""
+	require.Equal(t, "<34>Oct 11 22:14:15 mymachine 'su root' failed for lonvick on /dev/pts/8", string(m.RFC3164()))
+}
+
+func TestMessage_RFC5424(t *testing.T) {
+	m := &syslog.Message{
+		Facility:  syslog.FacilityLocal4,
+		Severity:  syslog.SeverityNotice,
+		Timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+		Hostname:  "mymachine.example.com",
+		AppName:   "evntslog",
+		MsgID:     "ID47",
+		StructuredData: []syslog.StructuredData{{
+			ID:     "exampleSDID@32473",
+			Params: map[string]string{"iut": "3", "eventSource": "Application", "eventID": `1011"]`},
+		}},
+		Message: "An application event log entry...",
+	}
+	require.Equal(t,
+		`<165>1 2003-10-11T22:14:15.003000Z mymachine.example.com evntslog - ID47 `+
+			`[exampleSDID@32473 eventID="1011\"\]" eventSource="Application" iut="3"] An application event log entry...`,
+		string(m.RFC5424()))
+
+	b, err := (&syslog.Message{Severity: syslog.SeverityInfo}).Encode(syslog.RFC5424)
+	require.Nil(t, err)
+	require.Equal(t, "<6>1 - - - - - -", string(b))
+	_, err = m.Encode(syslog.Format(10))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,234 @@
+package syslog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse parses a syslog message of RFC 5424 or RFC 3164.
+// The format is detected by the version after PRI.
+func Parse(b []byte) (*Message, Format, error) {
+	pri, rest, err := parsePriority(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	m := &Message{Facility: pri / 8, Severity: pri % 8}
+	if bytes.HasPrefix(rest, []byte("1 ")) {
+		if err := parseRFC5424(m, string(rest[2:])); err != nil {
+			return nil, RFC5424, err
+		}
+		return m, RFC5424, nil
+	}
+	if err := parseRFC3164(m, string(rest)); err != nil {
+		return nil, RFC3164, err
+	}
+	return m, RFC3164, nil
+}
+
+func parsePriority(b []byte) (int, []byte, error) {
+	if len(b) < 3 || b[0] != '<' {
+		return 0, nil, fmt.Errorf("PRI is not found")
+	}
+	i := bytes.IndexByte(b, '>')
+	if i < 2 || i > 4 {
+		return 0, nil, fmt.Errorf("PRI is invalid")
+	}
+	pri, err := strconv.Atoi(string(b[1:i]))
+	if err != nil || pri > 191 {
+		return 0, nil, fmt.Errorf("PRI is invalid: %s", b[1:i])
+	}
+	return pri, b[i+1:], nil
+}
+
+// nextField returns the field until the next space and the rest.
+func nextField(s string) (string, string) {
+	i := strings.IndexByte(s, ' ')
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i+1:]
+}
+
+func fromNil(s string) string {
+	if s == nilValue {
+		return ""
+	}
+	return s
+}
+
+func parseRFC5424(m *Message, s string) error {
+	var ts string
+	ts, s = nextField(s)
+	if ts != nilValue {
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return fmt.Errorf("TIMESTAMP is invalid: %v", err)
+		}
+		m.Timestamp = t
+	}
+	var f string
+	f, s = nextField(s)
+	m.Hostname = fromNil(f)
+	f, s = nextField(s)
+	m.AppName = fromNil(f)
+	f, s = nextField(s)
+	m.ProcID = fromNil(f)
+	f, s = nextField(s)
+	m.MsgID = fromNil(f)
+	if s == "" {
+		return fmt.Errorf("STRUCTURED-DATA is not found")
+	}
+	if s[0] == '-' {
+		s = s[1:]
+	} else {
+		sds, rest, err := parseStructuredData(s)
+		if err != nil {
+			return err
+		}
+		m.StructuredData = sds
+		s = rest
+	}
+	m.Message = strings.TrimPrefix(s, " ")
+	return nil
+}
+
+func parseStructuredData(s string) ([]StructuredData, string, error) {
+	sds := []StructuredData{}
+	for len(s) > 0 && s[0] == '[' {
+		s = s[1:]
+		end := strings.IndexAny(s, " ]")
+		if end < 0 {
+			return nil, "", fmt.Errorf("SD-ELEMENT isn't closed")
+		}
+		sd := StructuredData{ID: s[:end]}
+		s = s[end:]
+		for len(s) > 0 && s[0] == ' ' {
+			eq := strings.Index(s, `="`)
+			if eq < 0 {
+				return nil, "", fmt.Errorf("SD-PARAM is invalid")
+			}
+			name := s[1:eq]
+			s = s[eq+2:]
+			val := strings.Builder{}
+			closed := false
+			for i := 0; i < len(s); i++ {
+				c := s[i]
+				if c == '\\' && i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\\' || s[i+1] == ']') {
+					val.WriteByte(s[i+1])
+					i++
+					continue
+				}
+				if c == '"' {
+					s = s[i+1:]
+					closed = true
+					break
+				}
+				val.WriteByte(c)
+			}
+			if !closed {
+				return nil, "", fmt.Errorf("PARAM-VALUE isn't closed")
+			}
+			if sd.Params == nil {
+				sd.Params = map[string]string{}
+			}
+			sd.Params[name] = val.String()
+		}
+		if len(s) == 0 || s[0] != ']' {
+			return nil, "", fmt.Errorf("SD-ELEMENT isn't closed")
+		}
+		s = s[1:]
+		sds = append(sds, sd)
+	}
+	return sds, s, nil
+}
+
+func parseRFC3164(m *Message, s string) error {
+	if len(s) < len(time.Stamp)+1 {
+		return fmt.Errorf("TIMESTAMP is not found")
+	}
+	t, err := time.ParseInLocation(time.Stamp, s[:len(time.Stamp)], time.Local)
+	if err != nil {
+		return fmt.Errorf("TIMESTAMP is invalid: %v", err)
+	}
+	// RFC 3164's timestamp doesn't have the year
+	now := time.Now()
+	t = t.AddDate(now.Year(), 0, 0)
+	if t.After(now.AddDate(0, 1, 0)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	m.Timestamp = t
+	var host string
+	host, s = nextField(s[len(time.Stamp)+1:])
+	m.Hostname = fromNil(host)
+	// TAG is the alphanumeric characters terminated by "[" or ":"
+	tag, rest := nextField(s)
+	if strings.HasSuffix(tag, ":") {
+		tag = strings.TrimSuffix(tag, ":")
+		if i := strings.IndexByte(tag, '['); i > 0 && strings.HasSuffix(tag, "]") {
+			m.ProcID = tag[i+1 : len(tag)-1]
+			tag = tag[:i]
+		}
+		m.AppName = tag
+		s = rest
+	}
+	m.Message = s
+	return nil
+}
+
+// NewScanner returns a scanner which splits syslog TCP frames.
+// The framing is detected per frame: the octet counting if the frame starts with a digit,
+// otherwise the frame is delimited by a newline or a null byte.
+// If maxSize is zero, the max frame size is 2 MiB.
+func NewScanner(r io.Reader, maxSize int) *bufio.Scanner {
+	if maxSize <= 0 {
+		maxSize = 2 * 1024 * 1024
+	}
+	initSize := 4096
+	if initSize > maxSize+16 {
+		initSize = maxSize + 16
+	}
+	scanner := bufio.NewScanner(r)
+	// the max token size is the larger of the max and the buffer's capacity
+	scanner.Buffer(make([]byte, 0, initSize), maxSize+16)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if len(data) == 0 {
+			return 0, nil, nil
+		}
+		if data[0] >= '0' && data[0] <= '9' {
+			sp := bytes.IndexByte(data, ' ')
+			if sp < 0 {
+				if atEOF {
+					return 0, nil, fmt.Errorf("MSG-LEN isn't terminated")
+				}
+				return 0, nil, nil
+			}
+			n, err := strconv.Atoi(string(data[:sp]))
+			if err != nil {
+				return 0, nil, fmt.Errorf("MSG-LEN is invalid: %v", err)
+			}
+			if n > maxSize {
+				return 0, nil, fmt.Errorf("message size %d exceeds the max message size %d", n, maxSize)
+			}
+			if len(data) < sp+1+n {
+				if atEOF {
+					return 0, nil, fmt.Errorf("frame is truncated")
+				}
+				return 0, nil, nil
+			}
+			return sp + 1 + n, data[sp+1 : sp+1+n], nil
+		}
+		if i := bytes.IndexAny(data, "\n\x00"); i >= 0 {
+			return i + 1, bytes.TrimSuffix(data[:i], []byte("\r")), nil
+		}
+		if atEOF {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	return scanner
+}
@@ -0,0 +1,76 @@
+package syslog_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/syslog"
+)
+
+func TestParse(t *testing.T) {
+	m := &syslog.Message{
+		Facility:  syslog.FacilityLocal4,
+		Severity:  syslog.SeverityNotice,
+		Timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+		Hostname:  "mymachine.example.com",
+		AppName:   "evntslog",
+		MsgID:     "ID47",
+		StructuredData: []syslog.StructuredData{{
+			ID:     "exampleSDID@32473",
+			Params: map[string]string{"iut": "3", "eventID": `1011"]`},
+		}, {
+			ID: "examplePriority@32473",
+		}},
+		Message: "An application event log entry...",
+	}
+	a, f, err := syslog.Parse(m.RFC5424())
+	require.Nil(t, err)
+	require.Equal(t, syslog.RFC5424, f)
+	require.Equal(t, m, a)
+
+	a, _, err = syslog.Parse([]byte("<6>1 - - - - - -"))
+	require.Nil(t, err)
+	require.Equal(t, &syslog.Message{Severity: syslog.SeverityInfo}, a)
+
+	m = &syslog.Message{
+		Facility:  syslog.FacilityAuth,
+		Severity:  syslog.SeverityCritical,
+		Timestamp: time.Date(time.Now().Year(), 1, 2, 22, 14, 15, 0, time.Local),
+		Hostname:  "mymachine",
+		AppName:   "su",
+		ProcID:    "123",
+		Message:   "'su root' failed for lonvick on /dev/pts/8",
+	}
+	a, f, err = syslog.Parse(m.RFC3164())
+	require.Nil(t, err)
+	require.Equal(t, syslog.RFC3164, f)
+	require.Equal(t, m, a)
+
+	m.AppName = ""
+	m.ProcID = ""
+	a, _, err = syslog.Parse(m.RFC3164())
+	require.Nil(t, err)
+	require.Equal(t, m, a)
+
+	for _, s := range []string{"foo", "<200>1 - - - - - -", "<6>1 2003-10-11 - - - - -", "<6>1 - - - - - [foo a=\"b]", "<6>Oct"} {
+		_, _, err := syslog.Parse([]byte(s))
+		require.NotNil(t, err, s)
+	}
+}
+
+func TestNewScanner(t *testing.T) {
+	scanner := syslog.NewScanner(strings.NewReader("5 hello<6>foo\n<6>bar\x0011 hello world<6>baz"), 0)
+	frames := []string{}
+	for scanner.Scan() {
+		frames = append(frames, scanner.Text())
+	}
+	require.Nil(t, scanner.Err())
+	require.Equal(t, []string{"hello", "<6>foo", "<6>bar", "hello world", "<6>baz"}, frames)
+
+	scanner = syslog.NewScanner(strings.NewReader("100 hello"), 10)
+	require.False(t, scanner.Scan())
+	require.NotNil(t, scanner.Err())
+}
@@ -0,0 +1,198 @@
+package syslog
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Framing is the method to delimit messages over TCP.
+type Framing int
+
+const (
+	// FramingNewline delimits messages by a newline.
+	FramingNewline Framing = iota
+	// FramingNull delimits messages by a null byte.
+	FramingNull
+	// FramingOctetCounting prefixes messages with their length (RFC 6587).
+	FramingOctetCounting
+)
+
+type (
+	// Transport sends frames over UDP or TCP.
+	// Over UDP, a frame is sent as a datagram and Framing is ignored.
+	// Over TCP, if the connection is broken, the transport reconnects once per frame.
+	Transport struct {
+		Framing Framing
+		// MaxMessageSize is the max size of a message. If it is zero, the size isn't checked.
+		MaxMessageSize int
+
+		network   string
+		addr      string
+		tlsConfig *tls.Config
+		mutex     sync.Mutex
+		conn      net.Conn
+	}
+
+	// Sender sends syslog messages.
+	Sender struct {
+		*Transport
+		Format Format
+		// Hostname is set to messages whose hostname is empty. The default is the hostname.
+		Hostname string
+	}
+
+	// RawSender sends plain text lines to raw inputs.
+	// RawSender implements io.Writer.
+	RawSender struct {
+		*Transport
+	}
+)
+
+func newTransport(network, addr string, tlsConfig *tls.Config) (*Transport, error) {
+	t := &Transport{network: network, addr: addr, tlsConfig: tlsConfig}
+	if err := t.connect(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Transport) connect() error {
+	var (
+		conn net.Conn
+		err  error
+	)
+	if t.tlsConfig != nil {
+		conn, err = tls.Dial(t.network, t.addr, t.tlsConfig)
+	} else {
+		conn, err = net.Dial(t.network, t.addr)
+	}
+	if err != nil {
+		return err
+	}
+	t.conn = conn
+	return nil
+}
+
+func (t *Transport) frame(b []byte) []byte {
+	if t.network == "udp" {
+		return b
+	}
+	switch t.Framing {
+	case FramingNull:
+		return append(append(make([]byte, 0, len(b)+1), b...), 0)
+	case FramingOctetCounting:
+		return append([]byte(strconv.Itoa(len(b))+" "), b...)
+	}
+	return append(append(make([]byte, 0, len(b)+1), b...), '\n')
+}
+
+// WriteFrame sends a message with the framing.
+func (t *Transport) WriteFrame(b []byte) error {
+	if t.MaxMessageSize > 0 && len(b) > t.MaxMessageSize {
+		return fmt.Errorf("message size %d exceeds the max message size %d", len(b), t.MaxMessageSize)
+	}
+	b = t.frame(b)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.conn != nil {
+		if _, err := t.conn.Write(b); err == nil || t.network == "udp" {
+			return err
+		}
+		t.conn.Close()
+		t.conn = nil
+	}
+	if err := t.connect(); err != nil {
+		return err
+	}
+	_, err := t.conn.Write(b)
+	return err
+}
+
+// Close closes the connection.
+func (t *Transport) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.conn == nil {
+		return nil
+	}
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
+
+func newSender(t *Transport) *Sender {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+	return &Sender{Transport: t, Hostname: host}
+}
+
+// NewUDPSender returns a new syslog sender over UDP.
+func NewUDPSender(addr string) (*Sender, error) {
+	t, err := newTransport("udp", addr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newSender(t), nil
+}
+
+// NewTCPSender returns a new syslog sender over TCP.
+// If tlsConfig isn't nil, the connection uses TLS.
+func NewTCPSender(addr string, tlsConfig *tls.Config) (*Sender, error) {
+	t, err := newTransport("tcp", addr, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newSender(t), nil
+}
+
+// Send sends a message.
+// The timestamp and the hostname are set if they are empty.
+func (s *Sender) Send(m *Message) error {
+	msg := *m
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	if msg.Hostname == "" {
+		msg.Hostname = s.Hostname
+	}
+	b, err := msg.Encode(s.Format)
+	if err != nil {
+		return err
+	}
+	return s.WriteFrame(b)
+}
+
+// NewRawUDPSender returns a new raw sender over UDP.
+func NewRawUDPSender(addr string) (*RawSender, error) {
+	t, err := newTransport("udp", addr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RawSender{Transport: t}, nil
+}
+
+// NewRawTCPSender returns a new raw sender over TCP.
+// If tlsConfig isn't nil, the connection uses TLS.
+func NewRawTCPSender(addr string, tlsConfig *tls.Config) (*RawSender, error) {
+	t, err := newTransport("tcp", addr, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &RawSender{Transport: t}, nil
+}
+
+// Write sends p as a message. The trailing newline is trimmed.
+func (s *RawSender) Write(p []byte) (int, error) {
+	if err := s.WriteFrame(bytes.TrimRight(p, "\n")); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
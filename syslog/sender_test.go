@@ -0,0 +1,138 @@
+package syslog_test
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/syslog"
+)
+
+// listenTCP accepts a connection and sends the received data split by the delimiter to the channel.
+func listenTCP(t *testing.T, delim byte) (net.Listener, <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	ch := make(chan string, 10)
+	go func() {
+		defer close(ch)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			s, err := r.ReadString(delim)
+			if err != nil {
+				return
+			}
+			ch <- s
+		}
+	}()
+	return ln, ch
+}
+
+func TestUDPSender(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer conn.Close()
+
+	s, err := syslog.NewUDPSender(conn.LocalAddr().String())
+	require.Nil(t, err)
+	defer s.Close()
+	s.Hostname = "example.com"
+	s.Format = syslog.RFC3164
+	require.Nil(t, s.Send(&syslog.Message{Severity: syslog.SeverityInfo, AppName: "app", Message: "hello"}))
+	buf := make([]byte, 65536)
+	n, _, err := conn.ReadFrom(buf)
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(string(buf[:n]), "<6>"))
+	require.True(t, strings.HasSuffix(string(buf[:n]), " example.com app: hello"))
+}
+
+func TestTCPSender(t *testing.T) {
+	ln, ch := listenTCP(t, '\n')
+	defer ln.Close()
+	s, err := syslog.NewTCPSender(ln.Addr().String(), nil)
+	require.Nil(t, err)
+	s.MaxMessageSize = 100
+	require.Nil(t, s.Send(&syslog.Message{Severity: syslog.SeverityInfo, Hostname: "host", Message: "hello"}))
+	require.NotNil(t, s.Send(&syslog.Message{Message: strings.Repeat("a", 100)}))
+	require.Nil(t, s.Close())
+	msg := <-ch
+	require.True(t, strings.HasPrefix(msg, "<6>1 "))
+	require.True(t, strings.HasSuffix(msg, " host - - - - hello\n"))
+}
+
+func TestNewSenderFromInput(t *testing.T) {
+	ln, ch := listenTCP(t, 0)
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	input := &graylog.Input{Attrs: &graylog.InputSyslogTCPAttrs{
+		BindAddress: "0.0.0.0", Port: port, UseNullDelimiter: true}}
+	_, err := syslog.NewSenderFromInput(input, nil)
+	require.NotNil(t, err)
+	s, err := syslog.NewSenderFromInput(input, &syslog.InputOptions{Host: "127.0.0.1", Format: syslog.RFC3164})
+	require.Nil(t, err)
+	require.Equal(t, syslog.FramingNull, s.Framing)
+	require.Nil(t, s.Send(&syslog.Message{Hostname: "host", Message: "hello"}))
+	require.Nil(t, s.Close())
+	require.True(t, strings.HasSuffix(<-ch, " host hello\x00"))
+
+	_, err = syslog.NewSenderFromInput(&graylog.Input{Attrs: &graylog.InputBeatsAttrs{}}, nil)
+	require.NotNil(t, err)
+}
+
+func TestNewRawSenderFromInput(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer ln.Close()
+	ch := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		b := make([]byte, 0, 64)
+		for {
+			c, err := r.ReadByte()
+			if err != nil {
+				ch <- string(b)
+				return
+			}
+			b = append(b, c)
+		}
+	}()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	s, err := syslog.NewRawSenderFromInput(&graylog.Input{Attrs: &graylog.InputRawTCPAttrs{
+		BindAddress: "127.0.0.1", Port: port}}, &syslog.InputOptions{OctetCounting: true})
+	require.Nil(t, err)
+	_, err = s.Write([]byte("hello\n"))
+	require.Nil(t, err)
+	_, err = s.Write([]byte("world"))
+	require.Nil(t, err)
+	require.Nil(t, s.Close())
+	require.Equal(t, "5 hello5 world", <-ch)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer conn.Close()
+	s, err = syslog.NewRawSenderFromInput(&graylog.Input{Attrs: &graylog.InputRawUDPAttrs{
+		BindAddress: "127.0.0.1", Port: conn.LocalAddr().(*net.UDPAddr).Port}}, nil)
+	require.Nil(t, err)
+	defer s.Close()
+	_, err = s.Write([]byte("foo\n"))
+	require.Nil(t, err)
+	buf := make([]byte, 100)
+	n, _, err := conn.ReadFrom(buf)
+	require.Nil(t, err)
+	require.Equal(t, "foo", string(buf[:n]))
+}
@@ -3,10 +3,8 @@ resource "graylog_input" "gelf_udp" {
   type   = "org.graylog2.inputs.gelf.udp.GELFUDPInput"
   global = "true"
 
-  attributes {
-    bind_address          = "0.0.0.0"
+  gelf_udp {
     port                  = 12201
-    recv_buffer_size      = 262144
     decompress_size_limit = 8388608
   }
 }
@@ -23,7 +21,7 @@ resource "graylog_input" "json_path" {
   type   = "org.graylog2.inputs.misc.jsonpath.JsonPathInput"
   global = "true"
 
-  attributes {
+  json_path {
     interval           = 30
     path               = "$.userId"
     throttling_allowed = true
@@ -1,11 +1,11 @@
 resource "graylog_pipeline" "test" {
-  source = <<EOF
-pipeline "test"
-  stage 0 match either
-end
-EOF
-
+  title       = "test"
   description = "test"
+
+  stage {
+    stage = 0
+    rules = [graylog_pipeline_rule.test.title]
+  }
 }
 
 resource "graylog_pipeline_rule" "test" {
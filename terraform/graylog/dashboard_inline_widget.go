@@ -0,0 +1,308 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+	"github.com/suzuki-shunsuke/go-ptr"
+)
+
+// dashboardInlineWidget is a widget declared with the "widget" block of graylog_dashboard.
+type dashboardInlineWidget struct {
+	key      string
+	widget   *graylog.Widget
+	position *graylog.DashboardWidgetPosition
+}
+
+// dashboardInlineWidgetSchema returns the schema of the "widget" block of graylog_dashboard.
+// The widget is identified by "key" instead of the index of the list,
+// so the widget isn't recreated even if the widgets are reordered.
+func dashboardInlineWidgetSchema() *schema.Schema {
+	sc := dashboardWidgetSchema()
+	sc["key"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	positionSchema := &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	sc["position"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		MinItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"width":  positionSchema,
+				"col":    positionSchema,
+				"row":    positionSchema,
+				"height": positionSchema,
+			},
+		},
+	}
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: sc,
+		},
+	}
+}
+
+func newDashboardInlineWidget(src map[string]interface{}, id string) (*dashboardInlineWidget, error) {
+	config, err := newDashboardWidgetConfig(src["type"].(string), func(k string) interface{} {
+		return src[k]
+	})
+	if err != nil {
+		return nil, err
+	}
+	w := &dashboardInlineWidget{
+		key: src["key"].(string),
+		widget: &graylog.Widget{
+			ID:          id,
+			Description: src["description"].(string),
+			CacheTime:   ptr.PInt(src["cache_time"].(int)),
+			Config:      config,
+		},
+	}
+	if p, ok := getBlock(src["position"]); ok {
+		w.position = &graylog.DashboardWidgetPosition{
+			WidgetID: id,
+			Width:    p["width"].(int),
+			Col:      p["col"].(int),
+			Row:      p["row"].(int),
+			Height:   p["height"].(int),
+		}
+	}
+	return w, nil
+}
+
+func flattenDashboardInlineWidget(
+	key string, widget *graylog.Widget, position *graylog.DashboardWidgetPosition,
+) (map[string]interface{}, error) {
+	cfgKey, cfg, err := flattenDashboardWidgetConfig(widget)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{
+		"key":         key,
+		"type":        widget.Type(),
+		"description": widget.Description,
+		cfgKey:        cfg,
+	}
+	if widget.CacheTime != nil {
+		m["cache_time"] = *widget.CacheTime
+	}
+	if position != nil {
+		m["position"] = []map[string]interface{}{{
+			"width":  position.Width,
+			"col":    position.Col,
+			"row":    position.Row,
+			"height": position.Height,
+		}}
+	}
+	return m, nil
+}
+
+// validateDashboardInlineWidgets validates the inline widgets and returns the error messages.
+func validateDashboardInlineWidgets(d *schema.ResourceDiff) ([]string, error) {
+	msgs := []string{}
+	keys := map[string]struct{}{}
+	for i, a := range d.Get("widget").([]interface{}) {
+		src := a.(map[string]interface{})
+		prefix := fmt.Sprintf("widget.%d", i)
+		if isKnown(d, prefix+".key") {
+			key := src["key"].(string)
+			if _, ok := keys[key]; ok {
+				msgs = append(msgs, fmt.Sprintf(`%s.key: "%s" is duplicated`, prefix, key))
+			}
+			keys[key] = struct{}{}
+		}
+		if !isKnown(d, prefix+".type") {
+			continue
+		}
+		w, err := newDashboardInlineWidget(src, "")
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %v", prefix, err))
+			continue
+		}
+		// cache_time is updated with the other API after the widget is created
+		w.widget.CacheTime = nil
+		a, err := getPlanErrorMessages(d, validator.CreateValidator, w.widget, map[string]string{
+			"description": prefix + ".description",
+			"config":      prefix + "." + getDashboardWidgetConfigKey(w.widget.Type()),
+		})
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, a...)
+	}
+	return msgs, nil
+}
+
+// getDashboardInlineWidgetKeys returns the keys of the inline widgets.
+func getDashboardInlineWidgetKeys(src interface{}) map[string]struct{} {
+	arr := src.([]interface{})
+	keys := make(map[string]struct{}, len(arr))
+	for _, a := range arr {
+		keys[a.(map[string]interface{})["key"].(string)] = struct{}{}
+	}
+	return keys
+}
+
+// isDashboardInlineWidgetChanged returns true if the widget's attributes
+// which are updated with UpdateDashboardWidget are changed.
+func isDashboardInlineWidgetChanged(old, new map[string]interface{}) bool {
+	for k, v := range new {
+		switch k {
+		case "key", "cache_time", "position":
+			continue
+		}
+		if !reflect.DeepEqual(old[k], v) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDashboardInlineWidgets creates, updates and deletes the inline widgets and updates their positions,
+// then sets the map of the widget key to the widget id to widget_ids.
+// The positions of the widgets which aren't the inline widgets are kept.
+func applyDashboardInlineWidgets(ctx context.Context, cl *client.Client, d *schema.ResourceData) error {
+	dashboardID := d.Id()
+	o, n := d.GetChange("widget")
+	oIDs, _ := d.GetChange("widget_ids")
+	olds := map[string]map[string]interface{}{}
+	for _, a := range o.([]interface{}) {
+		src := a.(map[string]interface{})
+		olds[src["key"].(string)] = src
+	}
+	// the inline widgets which exist
+	ids := map[string]interface{}{}
+	managed := map[string]struct{}{}
+	for key, id := range oIDs.(map[string]interface{}) {
+		ids[key] = id
+		managed[id.(string)] = struct{}{}
+	}
+	// set widget_ids even if an error occurs to keep track of the created widgets
+	defer d.Set("widget_ids", ids)
+
+	newKeys := getDashboardInlineWidgetKeys(n)
+	positionChanged := false
+	for key, id := range ids {
+		if _, ok := newKeys[key]; ok {
+			continue
+		}
+		if _, err := cl.DeleteDashboardWidget(ctx, dashboardID, id.(string)); err != nil {
+			return err
+		}
+		delete(ids, key)
+		positionChanged = true
+	}
+
+	positions := []graylog.DashboardWidgetPosition{}
+	for _, a := range n.([]interface{}) {
+		src := a.(map[string]interface{})
+		key := src["key"].(string)
+		id, _ := ids[key].(string)
+		w, err := newDashboardInlineWidget(src, id)
+		if err != nil {
+			return err
+		}
+		cacheTime := *w.widget.CacheTime
+		old, ok := olds[key]
+		if id == "" {
+			w.widget.CacheTime = nil
+			created, _, err := cl.CreateDashboardWidget(ctx, dashboardID, *w.widget)
+			if err != nil {
+				return err
+			}
+			id = created.ID
+			ids[key] = id
+			if cacheTime != 0 {
+				if _, err := cl.UpdateDashboardWidgetCacheTime(ctx, dashboardID, id, cacheTime); err != nil {
+					return err
+				}
+			}
+			positionChanged = positionChanged || w.position != nil
+		} else {
+			if !ok || isDashboardInlineWidgetChanged(old, src) {
+				if _, err := cl.UpdateDashboardWidget(ctx, dashboardID, *w.widget); err != nil {
+					return err
+				}
+			}
+			if !ok || old["cache_time"] != src["cache_time"] {
+				if _, err := cl.UpdateDashboardWidgetCacheTime(ctx, dashboardID, id, cacheTime); err != nil {
+					return err
+				}
+			}
+			positionChanged = positionChanged || !ok || !reflect.DeepEqual(old["position"], src["position"])
+		}
+		managed[id] = struct{}{}
+		if w.position != nil {
+			w.position.WidgetID = id
+			positions = append(positions, *w.position)
+		}
+	}
+	if !positionChanged {
+		return nil
+	}
+	db, _, err := cl.GetDashboard(ctx, dashboardID)
+	if err != nil {
+		return err
+	}
+	for _, p := range db.Positions {
+		if _, ok := managed[p.WidgetID]; !ok {
+			positions = append(positions, p)
+		}
+	}
+	_, err = cl.UpdateDashboardWidgetPositions(ctx, dashboardID, positions)
+	return err
+}
+
+// setDashboardInlineWidgets sets the inline widgets of the dashboard.
+// The widgets which aren't managed as the inline widgets such as graylog_dashboard_widget are ignored.
+func setDashboardInlineWidgets(d *schema.ResourceData, db *graylog.Dashboard) error {
+	ids := d.Get("widget_ids").(map[string]interface{})
+	if len(ids) == 0 {
+		return nil
+	}
+	widgets := make(map[string]*graylog.Widget, len(db.Widgets))
+	for i, w := range db.Widgets {
+		widgets[w.ID] = &db.Widgets[i]
+	}
+	positions := make(map[string]*graylog.DashboardWidgetPosition, len(db.Positions))
+	for i, p := range db.Positions {
+		positions[p.WidgetID] = &db.Positions[i]
+	}
+	arr := []map[string]interface{}{}
+	newIDs := map[string]interface{}{}
+	for _, a := range d.Get("widget").([]interface{}) {
+		key := a.(map[string]interface{})["key"].(string)
+		id, _ := ids[key].(string)
+		w, ok := widgets[id]
+		if !ok {
+			// the widget has been removed
+			continue
+		}
+		m, err := flattenDashboardInlineWidget(key, w, positions[id])
+		if err != nil {
+			return err
+		}
+		arr = append(arr, m)
+		newIDs[key] = id
+	}
+	if err := d.Set("widget", arr); err != nil {
+		return err
+	}
+	return d.Set("widget_ids", newIDs)
+}
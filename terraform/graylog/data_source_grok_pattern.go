@@ -0,0 +1,87 @@
+package graylog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func dataSourceGrokPattern() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGrokPatternRead,
+
+		Schema: map[string]*schema.Schema{
+			"grok_pattern_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// attributes
+			"pattern": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func setDataSourceGrokPattern(d *schema.ResourceData, pattern *graylog.GrokPattern) error {
+	if err := setStrToRD(d, "grok_pattern_id", pattern.ID); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "name", pattern.Name); err != nil {
+		return err
+	}
+	d.SetId(pattern.ID)
+	return setStrToRD(d, "pattern", pattern.Pattern)
+}
+
+func dataSourceGrokPatternRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+
+	if id, ok := d.GetOk("grok_pattern_id"); ok {
+		if _, ok := d.GetOk("name"); ok {
+			return errors.New("only one of grok_pattern_id or name must be set")
+		}
+		pattern, _, err := cl.GetGrokPattern(ctx, id.(string))
+		if err != nil {
+			return err
+		}
+		return setDataSourceGrokPattern(d, pattern)
+	}
+
+	if t, ok := d.GetOk("name"); ok {
+		name := t.(string)
+		patterns, _, err := cl.GetGrokPatterns(ctx)
+		if err != nil {
+			return err
+		}
+		arr := []graylog.GrokPattern{}
+		for _, a := range patterns {
+			if a.Name == name {
+				arr = append(arr, a)
+			}
+		}
+		switch len(arr) {
+		case 0:
+			return errors.New("matched grok pattern is not found")
+		case 1:
+			return setDataSourceGrokPattern(d, &arr[0])
+		}
+		return errors.New("name isn't unique")
+	}
+	return errors.New("one of grok_pattern_id or name must be set")
+}
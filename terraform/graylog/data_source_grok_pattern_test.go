@@ -0,0 +1,83 @@
+package graylog
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+)
+
+func TestAccDataSourceGrokPattern(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		t.Skip("the mock server is required to seed the grok patterns whose names are duplicated")
+	}
+	defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	server.Start()
+	defer server.Close()
+
+	pattern := &graylog.GrokPattern{Name: "TERRAFORM_DATA_SOURCE_FOO", Pattern: "foo"}
+	if _, err := cl.CreateGrokPattern(ctx, pattern); err != nil {
+		t.Fatal(err)
+	}
+	// Graylog doesn't allow the duplicated names, so they are seeded
+	dup := graylog.GrokPattern{Name: "TERRAFORM_DATA_SOURCE_DUP", Pattern: "dup"}
+	if err := server.Seed(&mockserver.Seed{
+		GrokPatterns: []graylog.GrokPattern{dup, dup},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "graylog_grok_pattern" "test" {
+  name = "TERRAFORM_DATA_SOURCE_FOO"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_grok_pattern.test", "id", pattern.ID),
+					resource.TestCheckResourceAttr("data.graylog_grok_pattern.test", "pattern", "foo"),
+				),
+			},
+			{
+				Config: `
+data "graylog_grok_pattern" "test" {
+  name = "TERRAFORM_DATA_SOURCE_NOT_FOUND"
+}`,
+				ExpectError: regexp.MustCompile("matched grok pattern is not found"),
+			},
+			{
+				Config: `
+data "graylog_grok_pattern" "test" {
+  name = "TERRAFORM_DATA_SOURCE_DUP"
+}`,
+				ExpectError: regexp.MustCompile("name isn't unique"),
+			},
+			{
+				Config: `
+data "graylog_grok_pattern" "test" {
+  grok_pattern_id = "000000000000000000000000"
+}`,
+				ExpectError: regexp.MustCompile("404"),
+			},
+		},
+	})
+}
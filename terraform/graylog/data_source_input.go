@@ -0,0 +1,123 @@
+package graylog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func dataSourceInput() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceInputRead,
+
+		Schema: map[string]*schema.Schema{
+			"input_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// attributes
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"global": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"node": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"static_fields": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"creator_user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func setDataSourceInput(d *schema.ResourceData, input *graylog.Input) error {
+	if err := setStrToRD(d, "input_id", input.ID); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "title", input.Title); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "type", input.Type()); err != nil {
+		return err
+	}
+	if err := setBoolToRD(d, "global", input.Global); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "node", input.Node); err != nil {
+		return err
+	}
+	if err := setMapStrToStrToRD(d, "static_fields", input.StaticFields); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "creator_user_id", input.CreatorUserID); err != nil {
+		return err
+	}
+	d.SetId(input.ID)
+	return setStrToRD(d, "created_at", input.CreatedAt)
+}
+
+func dataSourceInputRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+
+	if id, ok := d.GetOk("input_id"); ok {
+		if _, ok := d.GetOk("title"); ok {
+			return errors.New("only one of input_id or title must be set")
+		}
+		input, _, err := cl.GetInput(ctx, id.(string))
+		if err != nil {
+			return err
+		}
+		return setDataSourceInput(d, input)
+	}
+
+	if t, ok := d.GetOk("title"); ok {
+		title := t.(string)
+		inputs, _, _, err := cl.GetInputs(ctx)
+		if err != nil {
+			return err
+		}
+		arr := []graylog.Input{}
+		for _, input := range inputs {
+			if input.Title == title {
+				arr = append(arr, input)
+			}
+		}
+		switch len(arr) {
+		case 0:
+			return errors.New("matched input is not found")
+		case 1:
+			return setDataSourceInput(d, &arr[0])
+		}
+		return errors.New("title isn't unique")
+	}
+	return errors.New("one of input_id or title must be set")
+}
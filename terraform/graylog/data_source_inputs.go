@@ -0,0 +1,97 @@
+package graylog
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceInputs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceInputsRead,
+
+		Schema: map[string]*schema.Schema{
+			"title_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+
+			// attributes
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"inputs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"input_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"global": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"node": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceInputsRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	re, err := getTitleRegexp(d)
+	if err != nil {
+		return err
+	}
+	inputs, _, _, err := cl.GetInputs(ctx)
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	arr := []map[string]interface{}{}
+	for i := range inputs {
+		input := &inputs[i]
+		if re != nil && !re.MatchString(input.Title) {
+			continue
+		}
+		ids = append(ids, input.ID)
+		arr = append(arr, map[string]interface{}{
+			"input_id": input.ID,
+			"title":    input.Title,
+			"type":     input.Type(),
+			"global":   input.Global,
+			"node":     input.Node,
+		})
+	}
+	if err := d.Set("inputs", arr); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	return setStrListToRD(d, "ids", ids)
+}
@@ -0,0 +1,100 @@
+package graylog
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func TestAccDataSourceInputs(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	for i, title := range []string{
+		"terraform data source foo", "terraform data source bar",
+		"terraform data source dup", "terraform data source dup",
+	} {
+		input := &graylog.Input{
+			Title:  title,
+			Global: true,
+			Attrs: &graylog.InputSyslogUDPAttrs{
+				BindAddress:    "0.0.0.0",
+				Port:           5140 + i,
+				RecvBufferSize: 262144,
+			},
+		}
+		if _, err := cl.CreateInput(ctx, input); err != nil {
+			t.Fatal(err)
+		}
+		defer cl.DeleteInput(ctx, input.ID)
+	}
+
+	inputsTf := `
+data "graylog_inputs" "test" {
+  title_regex = "foo$"
+}
+
+data "graylog_input" "test" {
+  title = "terraform data source foo"
+}`
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: inputsTf,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_inputs.test", "inputs.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.graylog_inputs.test", "inputs.0.type",
+						"org.graylog2.inputs.syslog.udp.SyslogUDPInput"),
+					resource.TestCheckResourceAttrPair(
+						"data.graylog_input.test", "id",
+						"data.graylog_inputs.test", "ids.0"),
+					resource.TestCheckResourceAttr("data.graylog_input.test", "global", "true"),
+				),
+			},
+			{
+				Config: `
+data "graylog_input" "test" {
+  title = "terraform data source not found"
+}`,
+				ExpectError: regexp.MustCompile("matched input is not found"),
+			},
+			{
+				Config: `
+data "graylog_input" "test" {
+  title = "terraform data source dup"
+}`,
+				ExpectError: regexp.MustCompile("title isn't unique"),
+			},
+			{
+				Config: `
+data "graylog_input" "test" {
+  input_id = "000000000000000000000000"
+}`,
+				ExpectError: regexp.MustCompile("404"),
+			},
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package graylog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func dataSourcePipeline() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePipelineRead,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// attributes
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func setDataSourcePipeline(d *schema.ResourceData, pipeline *graylog.Pipeline) error {
+	if err := setStrToRD(d, "pipeline_id", pipeline.ID); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "title", pipeline.Title); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "description", pipeline.Description); err != nil {
+		return err
+	}
+	d.SetId(pipeline.ID)
+	return setStrToRD(d, "source", pipeline.Source)
+}
+
+func dataSourcePipelineRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+
+	if id, ok := d.GetOk("pipeline_id"); ok {
+		if _, ok := d.GetOk("title"); ok {
+			return errors.New("only one of pipeline_id or title must be set")
+		}
+		pipeline, _, err := cl.GetPipeline(ctx, id.(string))
+		if err != nil {
+			return err
+		}
+		return setDataSourcePipeline(d, pipeline)
+	}
+
+	if t, ok := d.GetOk("title"); ok {
+		title := t.(string)
+		pipelines, _, err := cl.GetPipelines(ctx)
+		if err != nil {
+			return err
+		}
+		arr := []graylog.Pipeline{}
+		for _, a := range pipelines {
+			if a.Title == title {
+				arr = append(arr, a)
+			}
+		}
+		switch len(arr) {
+		case 0:
+			return errors.New("matched pipeline is not found")
+		case 1:
+			return setDataSourcePipeline(d, &arr[0])
+		}
+		return errors.New("title isn't unique")
+	}
+	return errors.New("one of pipeline_id or title must be set")
+}
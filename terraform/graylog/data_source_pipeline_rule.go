@@ -0,0 +1,94 @@
+package graylog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func dataSourcePipelineRule() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePipelineRuleRead,
+
+		Schema: map[string]*schema.Schema{
+			"rule_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// attributes
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func setDataSourcePipelineRule(d *schema.ResourceData, rule *graylog.PipelineRule) error {
+	if err := setStrToRD(d, "rule_id", rule.ID); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "title", rule.Title); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "description", rule.Description); err != nil {
+		return err
+	}
+	d.SetId(rule.ID)
+	return setStrToRD(d, "source", rule.Source)
+}
+
+func dataSourcePipelineRuleRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+
+	if id, ok := d.GetOk("rule_id"); ok {
+		if _, ok := d.GetOk("title"); ok {
+			return errors.New("only one of rule_id or title must be set")
+		}
+		rule, _, err := cl.GetPipelineRule(ctx, id.(string))
+		if err != nil {
+			return err
+		}
+		return setDataSourcePipelineRule(d, rule)
+	}
+
+	if t, ok := d.GetOk("title"); ok {
+		title := t.(string)
+		rules, _, err := cl.GetPipelineRules(ctx)
+		if err != nil {
+			return err
+		}
+		arr := []graylog.PipelineRule{}
+		for _, a := range rules {
+			if a.Title == title {
+				arr = append(arr, a)
+			}
+		}
+		switch len(arr) {
+		case 0:
+			return errors.New("matched pipeline rule is not found")
+		case 1:
+			return setDataSourcePipelineRule(d, &arr[0])
+		}
+		return errors.New("title isn't unique")
+	}
+	return errors.New("one of rule_id or title must be set")
+}
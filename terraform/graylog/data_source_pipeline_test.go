@@ -0,0 +1,122 @@
+package graylog
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+)
+
+func TestAccDataSourcePipelineAndPipelineRule(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		t.Skip("the mock server is required to seed the pipelines whose titles are duplicated")
+	}
+	defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	server.Start()
+	defer server.Close()
+
+	rule := &graylog.PipelineRule{
+		Source: `rule "terraform data source foo"
+when
+  true
+then
+end`,
+	}
+	if _, err := cl.CreatePipelineRule(ctx, rule); err != nil {
+		t.Fatal(err)
+	}
+	pipeline := &graylog.Pipeline{
+		Source: `pipeline "terraform data source foo"
+stage 0 match either
+  rule "terraform data source foo";
+end`,
+	}
+	if _, err := cl.CreatePipeline(ctx, pipeline); err != nil {
+		t.Fatal(err)
+	}
+	// Graylog doesn't allow the duplicated rule titles, so they are seeded
+	dupRule := graylog.PipelineRule{
+		Title: "terraform data source dup",
+		Source: `rule "terraform data source dup"
+when
+  true
+then
+end`,
+	}
+	dupPipeline := graylog.Pipeline{
+		Title: "terraform data source dup",
+		Source: `pipeline "terraform data source dup"
+end`,
+	}
+	if err := server.Seed(&mockserver.Seed{
+		PipelineRules: []graylog.PipelineRule{dupRule, dupRule},
+		Pipelines:     []graylog.Pipeline{dupPipeline, dupPipeline},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "graylog_pipeline" "test" {
+  title = "terraform data source foo"
+}
+
+data "graylog_pipeline_rule" "test" {
+  title = "terraform data source foo"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_pipeline.test", "id", pipeline.ID),
+					resource.TestCheckResourceAttr("data.graylog_pipeline_rule.test", "id", rule.ID),
+				),
+			},
+			{
+				Config: `
+data "graylog_pipeline" "test" {
+  title = "terraform data source not found"
+}`,
+				ExpectError: regexp.MustCompile("matched pipeline is not found"),
+			},
+			{
+				Config: `
+data "graylog_pipeline" "test" {
+  title = "terraform data source dup"
+}`,
+				ExpectError: regexp.MustCompile("title isn't unique"),
+			},
+			{
+				Config: `
+data "graylog_pipeline_rule" "test" {
+  title = "terraform data source not found"
+}`,
+				ExpectError: regexp.MustCompile("matched pipeline rule is not found"),
+			},
+			{
+				Config: `
+data "graylog_pipeline_rule" "test" {
+  title = "terraform data source dup"
+}`,
+				ExpectError: regexp.MustCompile("title isn't unique"),
+			},
+		},
+	})
+}
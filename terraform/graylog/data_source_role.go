@@ -0,0 +1,55 @@
+package graylog
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceRole() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRoleRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// attributes
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"read_only": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRoleRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	role, _, err := cl.GetRole(ctx, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "description", role.Description); err != nil {
+		return err
+	}
+	if err := setStrListToRD(d, "permissions", role.Permissions.ToList()); err != nil {
+		return err
+	}
+	d.SetId(role.Name)
+	return setBoolToRD(d, "read_only", role.ReadOnly)
+}
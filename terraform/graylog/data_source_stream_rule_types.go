@@ -0,0 +1,87 @@
+package graylog
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// defaultStreamID is the id of Graylog's default stream "All messages".
+const defaultStreamID = "000000000000000000000001"
+
+func dataSourceStreamRuleTypes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStreamRuleTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			// Graylog API requires the stream id to get the stream rule types,
+			// but the stream rule types don't depend on the stream.
+			"stream_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultStreamID,
+			},
+
+			// attributes
+			"types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"short_desc": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"long_desc": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// map of the stream rule type's name to id
+			"ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func dataSourceStreamRuleTypesRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	streamID := d.Get("stream_id").(string)
+	ruleTypes, _, err := cl.GetStreamRuleTypes(ctx, streamID)
+	if err != nil {
+		return err
+	}
+	types := make([]map[string]interface{}, len(ruleTypes))
+	ids := make(map[string]interface{}, len(ruleTypes))
+	for i, t := range ruleTypes {
+		types[i] = map[string]interface{}{
+			"id":         t.ID,
+			"name":       t.Name,
+			"short_desc": t.ShortDesc,
+			"long_desc":  t.LongDesc,
+		}
+		ids[t.Name] = t.ID
+	}
+	if err := d.Set("types", types); err != nil {
+		return err
+	}
+	d.SetId(streamID)
+	return d.Set("ids", ids)
+}
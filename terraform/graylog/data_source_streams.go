@@ -0,0 +1,117 @@
+package graylog
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceStreams() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStreamsRead,
+
+		Schema: map[string]*schema.Schema{
+			"title_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+
+			// attributes
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"streams": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"stream_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"index_set_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"matching_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"disabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getTitleRegexp compiles the attribute "title_regex".
+// If "title_regex" isn't set, nil is returned.
+func getTitleRegexp(d *schema.ResourceData) (*regexp.Regexp, error) {
+	s, ok := d.GetOk("title_regex")
+	if !ok {
+		return nil, nil
+	}
+	return regexp.Compile(s.(string))
+}
+
+func dataSourceStreamsRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	re, err := getTitleRegexp(d)
+	if err != nil {
+		return err
+	}
+	streams, _, _, err := cl.GetStreams(ctx)
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	arr := []map[string]interface{}{}
+	for _, stream := range streams {
+		if re != nil && !re.MatchString(stream.Title) {
+			continue
+		}
+		ids = append(ids, stream.ID)
+		arr = append(arr, map[string]interface{}{
+			"stream_id":     stream.ID,
+			"title":         stream.Title,
+			"description":   stream.Description,
+			"index_set_id":  stream.IndexSetID,
+			"matching_type": stream.MatchingType,
+			"disabled":      stream.Disabled,
+			"is_default":    stream.IsDefault,
+		})
+	}
+	if err := d.Set("streams", arr); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	return setStrListToRD(d, "ids", ids)
+}
@@ -0,0 +1,84 @@
+package graylog
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+)
+
+func TestAccDataSourceStreams(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	for _, title := range []string{"terraform data source foo", "terraform data source bar"} {
+		stream := &graylog.Stream{
+			Title:        title,
+			IndexSetID:   mockserver.DefaultIndexSetID,
+			MatchingType: "AND",
+		}
+		if _, err := cl.CreateStream(ctx, stream); err != nil {
+			t.Fatal(err)
+		}
+		defer cl.DeleteStream(ctx, stream.ID)
+	}
+
+	streamsTf := `
+data "graylog_streams" "test" {
+  title_regex = "^terraform data source "
+}
+
+data "graylog_streams" "foo" {
+  title_regex = "foo$"
+}
+
+data "graylog_stream" "test" {
+  title = "terraform data source foo"
+}
+
+data "graylog_stream_rule_types" "test" {}`
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: streamsTf,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_streams.test", "streams.#", "2"),
+					resource.TestCheckResourceAttr("data.graylog_streams.test", "ids.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"data.graylog_stream.test", "id",
+						"data.graylog_streams.foo", "ids.0"),
+					resource.TestCheckResourceAttr("data.graylog_stream_rule_types.test", "ids.EXACT", "1"),
+				),
+			},
+			{
+				Config: `
+data "graylog_stream_rule_types" "test" {
+  stream_id = "000000000000000000000000"
+}`,
+				ExpectError: regexp.MustCompile("404"),
+			},
+		},
+	})
+}
@@ -0,0 +1,98 @@
+package graylog
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceUser() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUserRead,
+
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// attributes
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"full_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"roles": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"timezone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"session_timeout_ms": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"external": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"read_only": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceUserRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	user, _, err := cl.GetUser(ctx, d.Get("username").(string))
+	if err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "user_id", user.ID); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "email", user.Email); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "full_name", user.FullName); err != nil {
+		return err
+	}
+	if err := setStrListToRD(d, "roles", user.Roles.ToList()); err != nil {
+		return err
+	}
+	if err := setStrListToRD(d, "permissions", user.Permissions.ToList()); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "timezone", user.Timezone); err != nil {
+		return err
+	}
+	if err := setIntToRD(d, "session_timeout_ms", user.SessionTimeoutMs); err != nil {
+		return err
+	}
+	if err := setBoolToRD(d, "external", user.External); err != nil {
+		return err
+	}
+	d.SetId(user.Username)
+	return setBoolToRD(d, "read_only", user.ReadOnly)
+}
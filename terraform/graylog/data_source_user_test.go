@@ -0,0 +1,65 @@
+package graylog
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDataSourceUserAndRole(t *testing.T) {
+	_, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "graylog_user" "test" {
+  username = "admin"
+}
+
+data "graylog_role" "test" {
+  name = "Reader"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_user.test", "id", "admin"),
+					resource.TestCheckResourceAttr("data.graylog_role.test", "id", "Reader"),
+					resource.TestCheckResourceAttr("data.graylog_role.test", "read_only", "true"),
+				),
+			},
+			{
+				Config: `
+data "graylog_user" "test" {
+  username = "terraform-data-source-not-found"
+}`,
+				ExpectError: regexp.MustCompile("404"),
+			},
+			{
+				Config: `
+data "graylog_role" "test" {
+  name = "terraform-data-source-not-found"
+}`,
+				ExpectError: regexp.MustCompile("404"),
+			},
+		},
+	})
+}
@@ -0,0 +1,410 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+// importAll is the child segment of the import id to import all children of the parent resource.
+const importAll = "*"
+
+type (
+	// importCandidate is a resource which can be matched with the natural key.
+	importCandidate struct {
+		id   string
+		keys map[string]string
+	}
+
+	// importTarget resolves the natural key of a kind of resources to the resource id.
+	// The natural key is "<key>:<value>" such as "title:My Stream".
+	// For the child resource, "<name>:<value>" is also accepted and the first key is used.
+	importTarget struct {
+		name string
+		keys []string
+		// parentID is empty if the resource isn't a child resource
+		list func(ctx context.Context, cl *client.Client, parentID string) ([]importCandidate, error)
+	}
+)
+
+// parseKey parses the natural key.
+// If the segment isn't a natural key, false is returned and the segment is treated as the resource id.
+func (t *importTarget) parseKey(segment string) (string, string, bool) {
+	i := strings.Index(segment, ":")
+	if i == -1 {
+		return "", "", false
+	}
+	key, value := segment[:i], segment[i+1:]
+	if key == t.name {
+		return t.keys[0], value, true
+	}
+	for _, k := range t.keys {
+		if k == key {
+			return key, value, true
+		}
+	}
+	return "", "", false
+}
+
+// resolve returns the id of the resource which is matched with the segment of the import id.
+func (t *importTarget) resolve(
+	ctx context.Context, cl *client.Client, segment, parentID string,
+) (string, error) {
+	key, value, ok := t.parseKey(segment)
+	if !ok {
+		return segment, nil
+	}
+	candidates, err := t.list(ctx, cl, parentID)
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, c := range candidates {
+		if c.keys[key] == value {
+			ids = append(ids, c.id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf(`%s "%s:%s" is not found`, t.name, key, value)
+	case 1:
+		return ids[0], nil
+	}
+	sort.Strings(ids)
+	return "", fmt.Errorf(
+		`%s "%s:%s" isn't unique: %d resources are matched (%s), so please specify the id instead`,
+		t.name, key, value, len(ids), strings.Join(ids, ", "))
+}
+
+// genNaturalKeyImport returns the import function which accepts not only the id but also the natural key.
+// The resolved id is set to the resource id and attrs.
+func genNaturalKeyImport(target *importTarget, attrs ...string) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		ctx := context.Background()
+		cl, err := newClient(m)
+		if err != nil {
+			return nil, err
+		}
+		id, err := target.resolve(ctx, cl, d.Id(), "")
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		for _, k := range attrs {
+			if err := setStrToRD(d, k, id); err != nil {
+				return nil, err
+			}
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// genNestedImport returns the import function of the child resource such as graylog_extractor.
+// The format of the import id is "<parent>/<child>" and each segment is the id or the natural key.
+// If the child segment is "*", all children of the parent resource are imported.
+func genNestedImport(
+	typ, parentKey, childKey string, parent, child *importTarget, newResource func() *schema.Resource,
+) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		a := strings.SplitN(d.Id(), "/", 2)
+		if len(a) != 2 {
+			return nil, fmt.Errorf(
+				`format of import argument should be %s/%s, %s:<%s>/%s:<%s> or %s:<%s>/%s`,
+				parentKey, childKey, parent.name, parent.keys[0], child.name, child.keys[0],
+				parent.name, parent.keys[0], importAll)
+		}
+		ctx := context.Background()
+		cl, err := newClient(m)
+		if err != nil {
+			return nil, err
+		}
+		parentID, err := parent.resolve(ctx, cl, a[0], "")
+		if err != nil {
+			return nil, err
+		}
+		if a[1] != importAll {
+			id, err := child.resolve(ctx, cl, a[1], parentID)
+			if err != nil {
+				return nil, err
+			}
+			if err := setStrToRD(d, parentKey, parentID); err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+			return []*schema.ResourceData{d}, nil
+		}
+
+		candidates, err := child.list(ctx, cl, parentID)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf(`%s "%s" has no %s`, parent.name, parentID, child.name)
+		}
+		results := make([]*schema.ResourceData, len(candidates))
+		for i, c := range candidates {
+			data := d
+			if i != 0 {
+				data = newResource().Data(nil)
+				data.SetType(typ)
+			}
+			if err := setStrToRD(data, parentKey, parentID); err != nil {
+				return nil, err
+			}
+			data.SetId(c.id)
+			results[i] = data
+		}
+		return results, nil
+	}
+}
+
+var (
+	streamImportTarget = &importTarget{
+		name: "stream",
+		keys: []string{"title"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			streams, _, _, err := cl.GetStreams(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(streams))
+			for i, stream := range streams {
+				candidates[i] = importCandidate{id: stream.ID, keys: map[string]string{"title": stream.Title}}
+			}
+			return candidates, nil
+		},
+	}
+
+	indexSetImportTarget = &importTarget{
+		name: "index_set",
+		keys: []string{"title", "prefix"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			indexSets, _, _, _, err := cl.GetIndexSets(ctx, 0, 0, false)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(indexSets))
+			for i, is := range indexSets {
+				candidates[i] = importCandidate{id: is.ID, keys: map[string]string{
+					"title": is.Title, "prefix": is.IndexPrefix,
+				}}
+			}
+			return candidates, nil
+		},
+	}
+
+	inputImportTarget = &importTarget{
+		name: "input",
+		keys: []string{"title"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			inputs, _, _, err := cl.GetInputs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(inputs))
+			for i, input := range inputs {
+				candidates[i] = importCandidate{id: input.ID, keys: map[string]string{"title": input.Title}}
+			}
+			return candidates, nil
+		},
+	}
+
+	dashboardImportTarget = &importTarget{
+		name: "dashboard",
+		keys: []string{"title"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			dashboards, _, _, err := cl.GetDashboards(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(dashboards))
+			for i, db := range dashboards {
+				candidates[i] = importCandidate{id: db.ID, keys: map[string]string{"title": db.Title}}
+			}
+			return candidates, nil
+		},
+	}
+
+	roleImportTarget = &importTarget{
+		name: "role",
+		keys: []string{"name"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			roles, _, _, err := cl.GetRoles(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(roles))
+			for i, role := range roles {
+				candidates[i] = importCandidate{id: role.Name, keys: map[string]string{"name": role.Name}}
+			}
+			return candidates, nil
+		},
+	}
+
+	userImportTarget = &importTarget{
+		name: "user",
+		keys: []string{"username"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			users, _, err := cl.GetUsers(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(users))
+			for i, user := range users {
+				candidates[i] = importCandidate{id: user.Username, keys: map[string]string{"username": user.Username}}
+			}
+			return candidates, nil
+		},
+	}
+
+	pipelineImportTarget = &importTarget{
+		name: "pipeline",
+		keys: []string{"title"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			pipes, _, err := cl.GetPipelines(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(pipes))
+			for i, pipe := range pipes {
+				candidates[i] = importCandidate{id: pipe.ID, keys: map[string]string{"title": pipe.Title}}
+			}
+			return candidates, nil
+		},
+	}
+
+	pipelineRuleImportTarget = &importTarget{
+		name: "pipeline_rule",
+		keys: []string{"title"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			rules, _, err := cl.GetPipelineRules(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(rules))
+			for i, rule := range rules {
+				candidates[i] = importCandidate{id: rule.ID, keys: map[string]string{"title": rule.Title}}
+			}
+			return candidates, nil
+		},
+	}
+
+	grokPatternImportTarget = &importTarget{
+		name: "grok_pattern",
+		keys: []string{"name"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			patterns, _, err := cl.GetGrokPatterns(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(patterns))
+			for i, pattern := range patterns {
+				candidates[i] = importCandidate{id: pattern.ID, keys: map[string]string{"name": pattern.Name}}
+			}
+			return candidates, nil
+		},
+	}
+
+	collectorConfigurationImportTarget = &importTarget{
+		name: "collector_configuration",
+		keys: []string{"name"},
+		list: func(ctx context.Context, cl *client.Client, _ string) ([]importCandidate, error) {
+			cfgs, _, _, err := cl.GetCollectorConfigurations(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(cfgs))
+			for i, cfg := range cfgs {
+				candidates[i] = importCandidate{id: cfg.ID, keys: map[string]string{"name": cfg.Name}}
+			}
+			return candidates, nil
+		},
+	}
+
+	extractorImportTarget = &importTarget{
+		name: "extractor",
+		keys: []string{"title"},
+		list: func(ctx context.Context, cl *client.Client, inputID string) ([]importCandidate, error) {
+			extractors, _, _, err := cl.GetExtractors(ctx, inputID)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(extractors))
+			for i, extractor := range extractors {
+				candidates[i] = importCandidate{id: extractor.ID, keys: map[string]string{"title": extractor.Title}}
+			}
+			return candidates, nil
+		},
+	}
+
+	dashboardWidgetImportTarget = &importTarget{
+		name: "widget",
+		keys: []string{"description"},
+		list: func(ctx context.Context, cl *client.Client, dashboardID string) ([]importCandidate, error) {
+			db, _, err := cl.GetDashboard(ctx, dashboardID)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(db.Widgets))
+			for i, widget := range db.Widgets {
+				candidates[i] = importCandidate{id: widget.ID, keys: map[string]string{"description": widget.Description}}
+			}
+			return candidates, nil
+		},
+	}
+
+	streamRuleImportTarget = &importTarget{
+		name: "rule",
+		keys: []string{"field", "description"},
+		list: func(ctx context.Context, cl *client.Client, streamID string) ([]importCandidate, error) {
+			rules, _, _, err := cl.GetStreamRules(ctx, streamID)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(rules))
+			for i, rule := range rules {
+				candidates[i] = importCandidate{id: rule.ID, keys: map[string]string{
+					"field": rule.Field, "description": rule.Description,
+				}}
+			}
+			return candidates, nil
+		},
+	}
+
+	alertConditionImportTarget = &importTarget{
+		name: "alert_condition",
+		keys: []string{"title"},
+		list: func(ctx context.Context, cl *client.Client, streamID string) ([]importCandidate, error) {
+			conds, _, _, err := cl.GetStreamAlertConditions(ctx, streamID)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(conds))
+			for i, cond := range conds {
+				candidates[i] = importCandidate{id: cond.ID, keys: map[string]string{"title": cond.Title}}
+			}
+			return candidates, nil
+		},
+	}
+
+	alarmCallbackImportTarget = &importTarget{
+		name: "alarm_callback",
+		keys: []string{"title"},
+		list: func(ctx context.Context, cl *client.Client, streamID string) ([]importCandidate, error) {
+			acs, _, _, err := cl.GetStreamAlarmCallbacks(ctx, streamID)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]importCandidate, len(acs))
+			for i, ac := range acs {
+				candidates[i] = importCandidate{id: ac.ID, keys: map[string]string{"title": ac.Title}}
+			}
+			return candidates, nil
+		},
+	}
+)
@@ -0,0 +1,155 @@
+package graylog
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// inputAttrsBlockNames maps the input type to the name of the typed attributes block.
+var inputAttrsBlockNames = map[string]string{
+	graylog.InputTypeAWSFlowLogs:       "aws_flow_logs",
+	graylog.InputTypeAWSCloudWatchLogs: "aws_cloud_watch_logs",
+	graylog.InputTypeAWSCloudTrail:     "aws_cloud_trail",
+	graylog.InputTypeBeats:             "beats",
+	graylog.InputTypeCEFAMQP:           "cef_amqp",
+	graylog.InputTypeCEFKafka:          "cef_kafka",
+	graylog.InputTypeCEFTCP:            "cef_tcp",
+	graylog.InputTypeCEFUDP:            "cef_udp",
+	graylog.InputTypeFakeHTTPMessage:   "fake_http_message",
+	graylog.InputTypeGELFAMQP:          "gelf_amqp",
+	graylog.InputTypeGELFHTTP:          "gelf_http",
+	graylog.InputTypeGELFKafka:         "gelf_kafka",
+	graylog.InputTypeGELFTCP:           "gelf_tcp",
+	graylog.InputTypeGELFUDP:           "gelf_udp",
+	graylog.InputTypeJSONPath:          "json_path",
+	graylog.InputTypeNetFlowUDP:        "netflow_udp",
+	graylog.InputTypeRawAMQP:           "raw_amqp",
+	graylog.InputTypeRawKafka:          "raw_kafka",
+	graylog.InputTypeRawTCP:            "raw_tcp",
+	graylog.InputTypeRawUDP:            "raw_udp",
+	graylog.InputTypeSyslogAMQP:        "syslog_amqp",
+	graylog.InputTypeSyslogKafka:       "syslog_kafka",
+	graylog.InputTypeSyslogTCP:         "syslog_tcp",
+	graylog.InputTypeSyslogUDP:         "syslog_udp",
+}
+
+// getInputAttrsDefault returns the default value of the input attribute.
+// The values are same as Graylog Web UI's default values.
+func getInputAttrsDefault(inputType, key string) (interface{}, bool) {
+	switch key {
+	case "bind_address":
+		return "0.0.0.0", true
+	case "recv_buffer_size":
+		if strings.HasSuffix(strings.ToLower(inputType), "udpinput") {
+			return 262144, true
+		}
+		return 1048576, true
+	}
+	return nil, false
+}
+
+// newInputAttrsSchema generates the schema of the typed attributes block from the InputAttrs struct.
+// The fields whose v-create tag has "required" are required unless they have the default values.
+func newInputAttrsSchema(attrs graylog.InputAttrs) map[string]*schema.Schema {
+	t := reflect.ValueOf(attrs).Elem().Type()
+	m := make(map[string]*schema.Schema, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := strings.Split(f.Tag.Get("json"), ",")[0]
+		s := &schema.Schema{}
+		switch f.Type.Kind() {
+		case reflect.String:
+			s.Type = schema.TypeString
+			if key == "tls_client_auth" {
+				s.ValidateFunc = validation.StringInSlice([]string{"disabled", "optional", "required"}, false)
+			}
+		case reflect.Int:
+			s.Type = schema.TypeInt
+			if key == "port" || key == "broker_port" {
+				s.ValidateFunc = validation.IntBetween(1, 65535)
+			} else {
+				s.ValidateFunc = validation.IntAtLeast(0)
+			}
+		case reflect.Bool:
+			s.Type = schema.TypeBool
+		default:
+			panic(fmt.Sprintf("invalid type: %v", f.Type.Kind()))
+		}
+		if v, ok := getInputAttrsDefault(attrs.InputType(), key); ok {
+			s.Optional = true
+			s.Default = v
+		} else if strings.Contains(f.Tag.Get("v-create"), "required") {
+			s.Required = true
+		} else {
+			s.Optional = true
+		}
+		m[key] = s
+	}
+	return m
+}
+
+// flattenInputAttrs converts the InputAttrs struct to the map of the typed attributes block.
+func flattenInputAttrs(attrs graylog.InputAttrs) map[string]interface{} {
+	rv := reflect.ValueOf(attrs).Elem()
+	t := rv.Type()
+	m := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		m[strings.Split(t.Field(i).Tag.Get("json"), ",")[0]] = rv.Field(i).Interface()
+	}
+	return m
+}
+
+// getInputAttrs returns the input attributes from the typed attributes block,
+// the deprecated "attributes" block or the general_<type>_attributes attributes.
+func getInputAttrs(d resourceData) (map[string]interface{}, error) {
+	t := d.Get("type").(string)
+	name, isTyped := inputAttrsBlockNames[t]
+	if isTyped {
+		if attrs, ok := getBlock(d.Get(name)); ok {
+			return attrs, nil
+		}
+	}
+	if attrs, ok := getBlock(d.Get("attributes")); ok {
+		return attrs, nil
+	}
+	if isTyped {
+		return nil, fmt.Errorf(`"%s" must be set for the input type %s`, name, t)
+	}
+	return getGeneralMap(d, "attributes"), nil
+}
+
+// setInputAttrs sets the input attributes to the attributes which the resource uses.
+func setInputAttrs(d *schema.ResourceData, input *graylog.Input) error {
+	if input.Attrs == nil {
+		return nil
+	}
+	if attrs, ok := input.Attrs.(*graylog.InputUnknownAttrs); ok {
+		// numbers are decoded as float64,
+		// so convert them to int if they are managed as int.
+		intM := d.Get("general_int_attributes").(map[string]interface{})
+		m := make(map[string]interface{}, len(attrs.Data))
+		for k, v := range attrs.Data {
+			if f, ok := v.(float64); ok && f == math.Trunc(f) {
+				if _, ok := intM[k]; ok {
+					v = int(f)
+				}
+			}
+			m[k] = v
+		}
+		return setGeneralMap(d, "attributes", m)
+	}
+	name, ok := inputAttrsBlockNames[input.Type()]
+	// the deprecated "attributes" block is used
+	// if the resource uses it or the input type doesn't have the typed block
+	if !ok || len(d.Get("attributes").([]interface{})) != 0 {
+		return d.Set("attributes", []map[string]interface{}{flattenInputAttrs(input.Attrs)})
+	}
+	return d.Set(name, []map[string]interface{}{flattenInputAttrs(input.Attrs)})
+}
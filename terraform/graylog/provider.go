@@ -41,7 +41,9 @@ func Provider() *schema.Provider {
 		ResourcesMap: map[string]*schema.Resource{
 			"graylog_alert_condition":            resourceAlertCondition(),
 			"graylog_alarm_callback":             resourceAlarmCallback(),
+			"graylog_collector_configuration":    resourceCollectorConfiguration(),
 			"graylog_dashboard":                  resourceDashboard(),
+			"graylog_default_index_set":          resourceDefaultIndexSet(),
 			"graylog_dashboard_widget":           resourceDashboardWidget(),
 			"graylog_dashboard_widget_positions": resourceDashboardWidgetPositions(),
 			"graylog_extractor":                  resourceExtractor(),
@@ -49,19 +51,30 @@ func Provider() *schema.Provider {
 			"graylog_index_set":                  resourceIndexSet(),
 			"graylog_input":                      resourceInput(),
 			"graylog_input_static_fields":        resourceInputStaticFields(),
+			"graylog_ldap_group_role_mapping":    resourceLDAPGroupRoleMapping(),
 			"graylog_ldap_setting":               resourceLDAPSetting(),
 			"graylog_pipeline":                   resourcePipeline(),
 			"graylog_pipeline_rule":              resourcePipelineRule(),
 			"graylog_pipeline_connection":        resourcePipelineConnection(),
 			"graylog_role":                       resourceRole(),
+			"graylog_role_member":                resourceRoleMember(),
 			"graylog_stream":                     resourceStream(),
 			"graylog_stream_rule":                resourceStreamRule(),
 			"graylog_user":                       resourceUser(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"graylog_index_set": dataSourceIndexSet(),
-			"graylog_stream":    dataSourceStream(),
-			"graylog_dashboard": dataSourceDashboard(),
+			"graylog_dashboard":         dataSourceDashboard(),
+			"graylog_grok_pattern":      dataSourceGrokPattern(),
+			"graylog_index_set":         dataSourceIndexSet(),
+			"graylog_input":             dataSourceInput(),
+			"graylog_inputs":            dataSourceInputs(),
+			"graylog_pipeline":          dataSourcePipeline(),
+			"graylog_pipeline_rule":     dataSourcePipelineRule(),
+			"graylog_role":              dataSourceRole(),
+			"graylog_stream":            dataSourceStream(),
+			"graylog_stream_rule_types": dataSourceStreamRuleTypes(),
+			"graylog_streams":           dataSourceStreams(),
+			"graylog_user":              dataSourceUser(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
@@ -10,6 +10,16 @@ import (
 	"github.com/suzuki-shunsuke/go-graylog"
 )
 
+// alarmCallbackConfigurationKeys maps the alarm callback type to the configuration attribute name.
+var alarmCallbackConfigurationKeys = map[string]string{
+	graylog.HTTPAlarmCallbackType:      "http_configuration",
+	graylog.EmailAlarmCallbackType:     "email_configuration",
+	graylog.SlackAlarmCallbackType:     "slack_configuration",
+	graylog.PagerDutyAlarmCallbackType: "pagerduty_configuration",
+	graylog.ScriptAlarmCallbackType:    "script_configuration",
+	graylog.TeamsAlarmCallbackType:     "teams_configuration",
+}
+
 func resourceAlarmCallback() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAlarmCallbackCreate,
@@ -17,8 +27,13 @@ func resourceAlarmCallback() *schema.Resource {
 		Update: resourceAlarmCallbackUpdate,
 		Delete: resourceAlarmCallbackDelete,
 
+		CustomizeDiff: resourceAlarmCallbackDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: genImport("stream_id", "alarm_callback_id"),
+			State: genNestedImport(
+				"graylog_alarm_callback", "stream_id", "alarm_callback_id",
+				streamImportTarget, alarmCallbackImportTarget, resourceAlarmCallback,
+			),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -65,6 +80,13 @@ func resourceAlarmCallback() *schema.Resource {
 					Type: schema.TypeBool,
 				},
 			},
+			// the JSON configuration of the types registered in the graylog package
+			// which have fields the general attributes can't express such as objects and arrays
+			"json_configuration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
 
 			"email_configuration": {
 				Type:     schema.TypeList,
@@ -175,11 +197,112 @@ func resourceAlarmCallback() *schema.Resource {
 					},
 				},
 			},
+			"pagerduty_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Required
+						"service_key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						// Optional
+						"client_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"client_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"key_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"custom_incident": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"script_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Required
+						"script_path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						// Optional
+						"script_args": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"script_timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"script_send_stdin": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"teams_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Required
+						"webhook_url": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						// Optional
+						"color": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"graylog2_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"icon_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"proxy_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"custom_message": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"backlog_items": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func newAlarmCallback(d *schema.ResourceData) (*graylog.AlarmCallback, error) {
+func newAlarmCallback(d resourceData) (*graylog.AlarmCallback, error) {
 	ac := graylog.AlarmCallback{
 		Title:    d.Get("title").(string),
 		StreamID: d.Get("stream_id").(string),
@@ -189,7 +312,7 @@ func newAlarmCallback(d *schema.ResourceData) (*graylog.AlarmCallback, error) {
 	case graylog.HTTPAlarmCallbackType:
 		p := graylog.HTTPAlarmCallbackConfiguration{}
 		hc := d.Get("http_configuration")
-		if hc == nil {
+		if hc == nil || len(hc.([]interface{})) == 0 {
 			return nil, fmt.Errorf("http_configuration is required")
 		}
 		p.URL = hc.([]interface{})[0].(map[string]interface{})["url"].(string)
@@ -198,7 +321,7 @@ func newAlarmCallback(d *schema.ResourceData) (*graylog.AlarmCallback, error) {
 	case graylog.EmailAlarmCallbackType:
 		p := graylog.EmailAlarmCallbackConfiguration{}
 		ec := d.Get("email_configuration")
-		if ec == nil {
+		if ec == nil || len(ec.([]interface{})) == 0 {
 			return nil, fmt.Errorf("email_configuration is required")
 		}
 		emailCfg := ec.([]interface{})[0].(map[string]interface{})
@@ -231,7 +354,7 @@ func newAlarmCallback(d *schema.ResourceData) (*graylog.AlarmCallback, error) {
 	case graylog.SlackAlarmCallbackType:
 		p := graylog.SlackAlarmCallbackConfiguration{}
 		sc := d.Get("slack_configuration")
-		if sc == nil {
+		if sc == nil || len(sc.([]interface{})) == 0 {
 			return nil, fmt.Errorf("slack_configuration is required")
 		}
 		slackCfg := sc.([]interface{})[0].(map[string]interface{})
@@ -267,22 +390,117 @@ func newAlarmCallback(d *schema.ResourceData) (*graylog.AlarmCallback, error) {
 		}
 		ac.Configuration = &p
 		return &ac, nil
-	}
-	gc := graylog.GeneralAlarmCallbackConfiguration{
-		Type:          d.Get("type").(string),
-		Configuration: map[string]interface{}{},
-	}
-	for _, k := range []string{"bool", "int", "string", "float"} {
-		if cfg := d.Get(fmt.Sprintf("general_%s_configuration", k)); cfg != nil {
-			for k, v := range cfg.(map[string]interface{}) {
-				gc.Configuration[k] = v
+	case graylog.PagerDutyAlarmCallbackType:
+		p := graylog.PagerDutyAlarmCallbackConfiguration{}
+		pc := d.Get("pagerduty_configuration")
+		if pc == nil || len(pc.([]interface{})) == 0 {
+			return nil, fmt.Errorf("pagerduty_configuration is required")
+		}
+		for k, v := range pc.([]interface{})[0].(map[string]interface{}) {
+			switch k {
+			case "service_key":
+				p.ServiceKey = v.(string)
+			case "client_name":
+				p.ClientName = v.(string)
+			case "client_url":
+				p.ClientURL = v.(string)
+			case "key_prefix":
+				p.KeyPrefix = v.(string)
+			case "custom_incident":
+				p.CustomIncident = v.(bool)
+			default:
+				return nil, fmt.Errorf("invalid attribute for alarm callback type `%s`: `%s`", graylog.PagerDutyAlarmCallbackType, k)
+			}
+		}
+		ac.Configuration = &p
+		return &ac, nil
+	case graylog.ScriptAlarmCallbackType:
+		p := graylog.ScriptAlarmCallbackConfiguration{}
+		sc := d.Get("script_configuration")
+		if sc == nil || len(sc.([]interface{})) == 0 {
+			return nil, fmt.Errorf("script_configuration is required")
+		}
+		for k, v := range sc.([]interface{})[0].(map[string]interface{}) {
+			switch k {
+			case "script_path":
+				p.ScriptPath = v.(string)
+			case "script_args":
+				p.ScriptArgs = v.(string)
+			case "script_timeout":
+				p.ScriptTimeout = v.(int)
+			case "script_send_stdin":
+				p.ScriptSendStdin = v.(bool)
+			default:
+				return nil, fmt.Errorf("invalid attribute for alarm callback type `%s`: `%s`", graylog.ScriptAlarmCallbackType, k)
+			}
+		}
+		ac.Configuration = &p
+		return &ac, nil
+	case graylog.TeamsAlarmCallbackType:
+		p := graylog.TeamsAlarmCallbackConfiguration{}
+		tc := d.Get("teams_configuration")
+		if tc == nil || len(tc.([]interface{})) == 0 {
+			return nil, fmt.Errorf("teams_configuration is required")
+		}
+		for k, v := range tc.([]interface{})[0].(map[string]interface{}) {
+			switch k {
+			case "webhook_url":
+				p.WebhookURL = v.(string)
+			case "color":
+				p.Color = v.(string)
+			case "graylog2_url":
+				p.Graylog2URL = v.(string)
+			case "icon_url":
+				p.IconURL = v.(string)
+			case "proxy_address":
+				p.ProxyAddress = v.(string)
+			case "custom_message":
+				p.CustomMessage = v.(string)
+			case "backlog_items":
+				p.BacklogItems = v.(int)
+			default:
+				return nil, fmt.Errorf("invalid attribute for alarm callback type `%s`: `%s`", graylog.TeamsAlarmCallbackType, k)
 			}
 		}
+		ac.Configuration = &p
+		return &ac, nil
+	}
+	t := d.Get("type").(string)
+	// third party's alarm callback types registered with graylog.RegisterAlarmCallbackConfigurations
+	if p, ok := graylog.NewAlarmCallbackConfigurationByType(t); ok {
+		cfg, err := getRegisteredTypeMap(d, "json_configuration", "configuration")
+		if err != nil {
+			return nil, err
+		}
+		v, err := decodeRegisteredType(cfg, p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for alarm callback type `%s`: %v", t, err)
+		}
+		ac.Configuration = v.(graylog.AlarmCallbackConfiguration)
+		return &ac, nil
+	}
+	ac.Configuration = &graylog.GeneralAlarmCallbackConfiguration{
+		Type:          t,
+		Configuration: getGeneralMap(d, "configuration"),
 	}
-	ac.Configuration = &gc
 	return &ac, nil
 }
 
+func resourceAlarmCallbackDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !isKnown(d, "type", "general_int_configuration", "general_bool_configuration", "general_float_configuration", "general_string_configuration", "json_configuration") {
+		return nil
+	}
+	ac, err := newAlarmCallback(d)
+	if err != nil {
+		return err
+	}
+	keys := map[string]string{}
+	if k, ok := alarmCallbackConfigurationKeys[d.Get("type").(string)]; ok {
+		keys["configuration"] = k
+	}
+	return validatePlan(d, getValidator(d), ac, keys)
+}
+
 func resourceAlarmCallbackCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -362,44 +580,48 @@ func resourceAlarmCallbackRead(d *schema.ResourceData, m interface{}) error {
 				"link_names":     cfg.LinkNames,
 				"notify_channel": cfg.NotifyChannel,
 			}})
-		}
-		cfg, ok := ac.Configuration.(*graylog.GeneralAlarmCallbackConfiguration)
-		if !ok {
-			return fmt.Errorf("configuration is invalid type")
-		}
-		intM := map[string]interface{}{}
-		strM := map[string]interface{}{}
-		floatM := map[string]interface{}{}
-		boolM := map[string]interface{}{}
-		for k, v := range cfg.Configuration {
-			switch v.(type) {
-			case int:
-				intM[k] = v
-			case bool:
-				boolM[k] = v
-			case float64:
-				floatM[k] = v
-			case float32:
-				floatM[k] = v
-			case string:
-				strM[k] = v
-			default:
-				return fmt.Errorf("%s is invalid type", k)
+		case graylog.PagerDutyAlarmCallbackType:
+			cfg, ok := ac.Configuration.(*graylog.PagerDutyAlarmCallbackConfiguration)
+			if !ok {
+				return fmt.Errorf("configuration is invalid type")
 			}
+			return d.Set("pagerduty_configuration", []map[string]interface{}{{
+				"service_key":     cfg.ServiceKey,
+				"client_name":     cfg.ClientName,
+				"client_url":      cfg.ClientURL,
+				"key_prefix":      cfg.KeyPrefix,
+				"custom_incident": cfg.CustomIncident,
+			}})
+		case graylog.ScriptAlarmCallbackType:
+			cfg, ok := ac.Configuration.(*graylog.ScriptAlarmCallbackConfiguration)
+			if !ok {
+				return fmt.Errorf("configuration is invalid type")
+			}
+			return d.Set("script_configuration", []map[string]interface{}{{
+				"script_path":       cfg.ScriptPath,
+				"script_args":       cfg.ScriptArgs,
+				"script_timeout":    cfg.ScriptTimeout,
+				"script_send_stdin": cfg.ScriptSendStdin,
+			}})
+		case graylog.TeamsAlarmCallbackType:
+			cfg, ok := ac.Configuration.(*graylog.TeamsAlarmCallbackConfiguration)
+			if !ok {
+				return fmt.Errorf("configuration is invalid type")
+			}
+			return d.Set("teams_configuration", []map[string]interface{}{{
+				"webhook_url":    cfg.WebhookURL,
+				"color":          cfg.Color,
+				"graylog2_url":   cfg.Graylog2URL,
+				"icon_url":       cfg.IconURL,
+				"proxy_address":  cfg.ProxyAddress,
+				"custom_message": cfg.CustomMessage,
+				"backlog_items":  cfg.BacklogItems,
+			}})
 		}
-		if err := d.Set("general_int_configuration", intM); err != nil {
-			return err
-		}
-		if err := d.Set("general_string_configuration", strM); err != nil {
-			return err
-		}
-		if err := d.Set("general_float_configuration", floatM); err != nil {
-			return err
-		}
-		if err := d.Set("general_bool_configuration", boolM); err != nil {
-			return err
+		if cfg, ok := ac.Configuration.(*graylog.GeneralAlarmCallbackConfiguration); ok {
+			return setGeneralMap(d, "configuration", cfg.Configuration)
 		}
-		return nil
+		return setRegisteredTypeMap(d, "json_configuration", "configuration", ac.Configuration)
 	}
 	return nil
 }
@@ -18,8 +18,13 @@ func resourceAlertCondition() *schema.Resource {
 		Update: resourceAlertConditionUpdate,
 		Delete: resourceAlertConditionDelete,
 
+		CustomizeDiff: resourceAlertConditionDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: genImport("stream_id", "alert_condition_id"),
+			State: genNestedImport(
+				"graylog_alert_condition", "stream_id", "alert_condition_id",
+				streamImportTarget, alertConditionImportTarget, resourceAlertCondition,
+			),
 		},
 
 		SchemaVersion: 1,
@@ -187,6 +192,13 @@ func resourceAlertCondition() *schema.Resource {
 					Type: schema.TypeBool,
 				},
 			},
+			// the JSON parameters of the types registered in the graylog package
+			// which have fields the general attributes can't express such as objects and arrays
+			"json_parameters": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
 
 			"in_grace": {
 				Type:     schema.TypeBool,
@@ -240,7 +252,7 @@ func migrateAlertConditionStateV0toV1(is *terraform.InstanceState) (*terraform.I
 	return is, nil
 }
 
-func newAlertCondition(d *schema.ResourceData) (*graylog.AlertCondition, error) {
+func newAlertCondition(d resourceData) (*graylog.AlertCondition, error) {
 	cond := graylog.AlertCondition{
 		Title:   d.Get("title").(string),
 		InGrace: d.Get("in_grace").(bool),
@@ -264,7 +276,7 @@ func newAlertCondition(d *schema.ResourceData) (*graylog.AlertCondition, error)
 	case "field_content_value":
 		p := graylog.FieldContentAlertConditionParameters{}
 		prms := d.Get("field_content_value_parameters")
-		if prms == nil {
+		if prms == nil || len(prms.([]interface{})) == 0 {
 			return nil, fmt.Errorf("field_content_value is required")
 		}
 		for k, v := range prms.([]interface{})[0].(map[string]interface{}) {
@@ -302,7 +314,7 @@ func newAlertCondition(d *schema.ResourceData) (*graylog.AlertCondition, error)
 	case "field_value":
 		p := graylog.FieldAggregationAlertConditionParameters{}
 		prms := d.Get("field_value_parameters")
-		if prms == nil {
+		if prms == nil || len(prms.([]interface{})) == 0 {
 			return nil, fmt.Errorf("field_value_parameters is required")
 		}
 		for k, v := range prms.([]interface{})[0].(map[string]interface{}) {
@@ -352,7 +364,7 @@ func newAlertCondition(d *schema.ResourceData) (*graylog.AlertCondition, error)
 	case "message_count":
 		p := graylog.MessageCountAlertConditionParameters{}
 		prms := d.Get("message_count_parameters")
-		if prms == nil {
+		if prms == nil || len(prms.([]interface{})) == 0 {
 			return nil, fmt.Errorf("message_count_parameters is required")
 		}
 		for k, v := range prms.([]interface{})[0].(map[string]interface{}) {
@@ -393,21 +405,43 @@ func newAlertCondition(d *schema.ResourceData) (*graylog.AlertCondition, error)
 		return &cond, nil
 	}
 
-	gap := graylog.GeneralAlertConditionParameters{
-		Type:       d.Get("type").(string),
-		Parameters: map[string]interface{}{},
-	}
-	for _, k := range []string{"bool", "int", "string", "float"} {
-		if prms := d.Get(fmt.Sprintf("general_%s_parameters", k)); prms != nil {
-			for k, v := range prms.(map[string]interface{}) {
-				gap.Parameters[k] = v
-			}
+	t := d.Get("type").(string)
+	// third party's alert condition types registered with graylog.RegisterAlertConditionParameters
+	if p, ok := graylog.NewAlertConditionParametersByType(t); ok {
+		prms, err := getRegisteredTypeMap(d, "json_parameters", "parameters")
+		if err != nil {
+			return nil, err
 		}
+		v, err := decodeRegisteredType(prms, p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters for alert condition type `%s`: %v", t, err)
+		}
+		cond.Parameters = v.(graylog.AlertConditionParameters)
+		return &cond, nil
+	}
+	cond.Parameters = &graylog.GeneralAlertConditionParameters{
+		Type:       t,
+		Parameters: getGeneralMap(d, "parameters"),
 	}
-	cond.Parameters = &gap
 	return &cond, nil
 }
 
+func resourceAlertConditionDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !isKnown(d, "type", "general_int_parameters", "general_bool_parameters", "general_float_parameters", "general_string_parameters", "json_parameters") {
+		return nil
+	}
+	cond, err := newAlertCondition(d)
+	if err != nil {
+		return err
+	}
+	keys := map[string]string{}
+	switch t := d.Get("type").(string); t {
+	case "field_content_value", "field_value", "message_count":
+		keys["parameters"] = t + "_parameters"
+	}
+	return validatePlan(d, getValidator(d), cond, keys)
+}
+
 func resourceAlertConditionCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -503,43 +537,10 @@ func resourceAlertConditionRead(d *schema.ResourceData, m interface{}) error {
 				"threshold_type":       prms.ThresholdType,
 			}})
 	}
-	prms, ok := cond.Parameters.(graylog.GeneralAlertConditionParameters)
-	if !ok {
-		return fmt.Errorf("parameters is invalid type as GeneralAlertConditionParameters")
-	}
-	intM := map[string]interface{}{}
-	strM := map[string]interface{}{}
-	floatM := map[string]interface{}{}
-	boolM := map[string]interface{}{}
-	for k, v := range prms.Parameters {
-		switch v.(type) {
-		case int:
-			intM[k] = v
-		case bool:
-			boolM[k] = v
-		case float64:
-			floatM[k] = v
-		case float32:
-			floatM[k] = v
-		case string:
-			strM[k] = v
-		default:
-			return fmt.Errorf("%s is invalid type", k)
-		}
+	if prms, ok := cond.Parameters.(graylog.GeneralAlertConditionParameters); ok {
+		return setGeneralMap(d, "parameters", prms.Parameters)
 	}
-	if err := d.Set("general_int_parameters", intM); err != nil {
-		return err
-	}
-	if err := d.Set("general_string_parameters", strM); err != nil {
-		return err
-	}
-	if err := d.Set("general_float_parameters", floatM); err != nil {
-		return err
-	}
-	if err := d.Set("general_bool_parameters", boolM); err != nil {
-		return err
-	}
-	return nil
+	return setRegisteredTypeMap(d, "json_parameters", "parameters", cond.Parameters)
 }
 
 func resourceAlertConditionUpdate(d *schema.ResourceData, m interface{}) error {
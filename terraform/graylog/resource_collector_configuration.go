@@ -0,0 +1,614 @@
+package graylog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/suzuki-shunsuke/go-set"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+const (
+	collectorConfigurationInputTypeFile            = "file"
+	collectorConfigurationInputTypeWindowsEventLog = "windows-eventlog"
+)
+
+func resourceCollectorConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCollectorConfigurationCreate,
+		Read:   resourceCollectorConfigurationRead,
+		Update: resourceCollectorConfigurationUpdate,
+		Delete: resourceCollectorConfigurationDelete,
+
+		CustomizeDiff: resourceCollectorConfigurationDiff,
+
+		Importer: &schema.ResourceImporter{
+			State: genNaturalKeyImport(collectorConfigurationImportTarget),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Optional
+			// Graylog API can't update the tags of the collector configuration.
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"input": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backend": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"forward_to": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"file_properties": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"paths": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"exclude_files": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"scan_frequency": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"encoding": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"ignore_older": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"document_type": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"exclude_lines": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"include_lines": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"tail_files": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"windows_event_log_properties": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"event": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						// properties of the input whose type is neither "file" nor "windows-eventlog"
+						"properties": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"input_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"output": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backend": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"properties": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"output_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"snippet": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backend": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"snippet": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"snippet_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getStrMap converts a map of terraform schema.TypeMap to map[string]interface{} whose values are string.
+func getStrMap(src interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	if src == nil {
+		return m
+	}
+	for k, v := range src.(map[string]interface{}) {
+		m[k] = v.(string)
+	}
+	return m
+}
+
+// flattenStrMap converts collector configuration properties to a map whose values are string.
+func flattenStrMap(src interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	a, ok := src.(map[string]interface{})
+	if !ok {
+		return m
+	}
+	for k, v := range a {
+		if s, ok := v.(string); ok {
+			m[k] = s
+			continue
+		}
+		m[k] = fmt.Sprintf("%v", v)
+	}
+	return m
+}
+
+// convertCollectorConfigurationInputProperties converts input properties to a given struct.
+func convertCollectorConfigurationInputProperties(src, dest interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dest)
+}
+
+func newCollectorConfigurationInput(m map[string]interface{}) (*graylog.CollectorConfigurationInput, error) {
+	input := &graylog.CollectorConfigurationInput{
+		Backend:   m["backend"].(string),
+		Type:      m["type"].(string),
+		Name:      m["name"].(string),
+		ForwardTo: m["forward_to"].(string),
+		InputID:   m["input_id"].(string),
+	}
+	switch input.Type {
+	case collectorConfigurationInputTypeFile:
+		p, ok := getBlock(m["file_properties"])
+		if !ok {
+			return nil, fmt.Errorf(`file_properties is required for the input "%s"`, input.Name)
+		}
+		input.Properties = &graylog.CollectorConfigurationInputFileProperty{
+			Paths:         p["paths"].(string),
+			ExcludeFiles:  p["exclude_files"].(string),
+			ScanFrequency: p["scan_frequency"].(string),
+			Encoding:      p["encoding"].(string),
+			IgnoreOlder:   p["ignore_older"].(string),
+			DocumentType:  p["document_type"].(string),
+			ExcludeLines:  p["exclude_lines"].(string),
+			IncludeLines:  p["include_lines"].(string),
+			TailFiles:     p["tail_files"].(bool),
+		}
+	case collectorConfigurationInputTypeWindowsEventLog:
+		p, ok := getBlock(m["windows_event_log_properties"])
+		if !ok {
+			return nil, fmt.Errorf(`windows_event_log_properties is required for the input "%s"`, input.Name)
+		}
+		input.Properties = &graylog.CollectorConfigurationInputWindowsEventLogProperty{
+			Event: p["event"].(string),
+		}
+	default:
+		input.Properties = getStrMap(m["properties"])
+	}
+	return input, nil
+}
+
+func flattenCollectorConfigurationInput(input *graylog.CollectorConfigurationInput) (map[string]interface{}, error) {
+	m := map[string]interface{}{
+		"backend":    input.Backend,
+		"type":       input.Type,
+		"name":       input.Name,
+		"forward_to": input.ForwardTo,
+		"input_id":   input.InputID,
+	}
+	switch input.Type {
+	case collectorConfigurationInputTypeFile:
+		p := &graylog.CollectorConfigurationInputFileProperty{}
+		if err := convertCollectorConfigurationInputProperties(input.Properties, p); err != nil {
+			return nil, err
+		}
+		m["file_properties"] = []interface{}{map[string]interface{}{
+			"paths":          p.Paths,
+			"exclude_files":  p.ExcludeFiles,
+			"scan_frequency": p.ScanFrequency,
+			"encoding":       p.Encoding,
+			"ignore_older":   p.IgnoreOlder,
+			"document_type":  p.DocumentType,
+			"exclude_lines":  p.ExcludeLines,
+			"include_lines":  p.IncludeLines,
+			"tail_files":     p.TailFiles,
+		}}
+	case collectorConfigurationInputTypeWindowsEventLog:
+		p := &graylog.CollectorConfigurationInputWindowsEventLogProperty{}
+		if err := convertCollectorConfigurationInputProperties(input.Properties, p); err != nil {
+			return nil, err
+		}
+		m["windows_event_log_properties"] = []interface{}{map[string]interface{}{
+			"event": p.Event,
+		}}
+	default:
+		m["properties"] = flattenStrMap(input.Properties)
+	}
+	return m, nil
+}
+
+func newCollectorConfigurationOutput(m map[string]interface{}) *graylog.CollectorConfigurationOutput {
+	return &graylog.CollectorConfigurationOutput{
+		Backend:    m["backend"].(string),
+		Type:       m["type"].(string),
+		Name:       m["name"].(string),
+		OutputID:   m["output_id"].(string),
+		Properties: getStrMap(m["properties"]),
+	}
+}
+
+func flattenCollectorConfigurationOutput(output *graylog.CollectorConfigurationOutput) map[string]interface{} {
+	return map[string]interface{}{
+		"backend":    output.Backend,
+		"type":       output.Type,
+		"name":       output.Name,
+		"output_id":  output.OutputID,
+		"properties": flattenStrMap(output.Properties),
+	}
+}
+
+func newCollectorConfigurationSnippet(m map[string]interface{}) *graylog.CollectorConfigurationSnippet {
+	return &graylog.CollectorConfigurationSnippet{
+		Backend:   m["backend"].(string),
+		Name:      m["name"].(string),
+		Snippet:   m["snippet"].(string),
+		SnippetID: m["snippet_id"].(string),
+	}
+}
+
+func flattenCollectorConfigurationSnippet(snippet *graylog.CollectorConfigurationSnippet) map[string]interface{} {
+	return map[string]interface{}{
+		"backend":    snippet.Backend,
+		"name":       snippet.Name,
+		"snippet":    snippet.Snippet,
+		"snippet_id": snippet.SnippetID,
+	}
+}
+
+func getCollectorConfigurationInputs(src interface{}) ([]graylog.CollectorConfigurationInput, error) {
+	list := src.([]interface{})
+	inputs := make([]graylog.CollectorConfigurationInput, len(list))
+	for i, a := range list {
+		input, err := newCollectorConfigurationInput(a.(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		inputs[i] = *input
+	}
+	return inputs, nil
+}
+
+func getCollectorConfigurationOutputs(src interface{}) []graylog.CollectorConfigurationOutput {
+	list := src.([]interface{})
+	outputs := make([]graylog.CollectorConfigurationOutput, len(list))
+	for i, a := range list {
+		outputs[i] = *newCollectorConfigurationOutput(a.(map[string]interface{}))
+	}
+	return outputs
+}
+
+func getCollectorConfigurationSnippets(src interface{}) []graylog.CollectorConfigurationSnippet {
+	list := src.([]interface{})
+	snippets := make([]graylog.CollectorConfigurationSnippet, len(list))
+	for i, a := range list {
+		snippets[i] = *newCollectorConfigurationSnippet(a.(map[string]interface{}))
+	}
+	return snippets
+}
+
+func newCollectorConfiguration(d resourceData) (*graylog.CollectorConfiguration, error) {
+	inputs, err := getCollectorConfigurationInputs(d.Get("input"))
+	if err != nil {
+		return nil, err
+	}
+	return &graylog.CollectorConfiguration{
+		ID:       d.Id(),
+		Name:     d.Get("name").(string),
+		Tags:     set.NewStrSet(getStringArray(d.Get("tags").(*schema.Set).List())...),
+		Inputs:   inputs,
+		Outputs:  getCollectorConfigurationOutputs(d.Get("output")),
+		Snippets: getCollectorConfigurationSnippets(d.Get("snippet")),
+	}, nil
+}
+
+func resourceCollectorConfigurationDiff(d *schema.ResourceDiff, m interface{}) error {
+	cfg, err := newCollectorConfiguration(d)
+	if err != nil {
+		return err
+	}
+	return validatePlan(d, getValidator(d), cfg, nil)
+}
+
+func resourceCollectorConfigurationCreate(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	cfg, err := newCollectorConfiguration(d)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.CreateCollectorConfiguration(ctx, cfg); err != nil {
+		return err
+	}
+	d.SetId(cfg.ID)
+	return nil
+}
+
+func resourceCollectorConfigurationRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	cfg, ei, err := cl.GetCollectorConfiguration(ctx, d.Id())
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	if err := setStrToRD(d, "name", cfg.Name); err != nil {
+		return err
+	}
+	if err := setStrListToRD(d, "tags", cfg.Tags.ToList()); err != nil {
+		return err
+	}
+	inputs := make([]interface{}, len(cfg.Inputs))
+	for i := range cfg.Inputs {
+		input, err := flattenCollectorConfigurationInput(&cfg.Inputs[i])
+		if err != nil {
+			return err
+		}
+		inputs[i] = input
+	}
+	if err := d.Set("input", inputs); err != nil {
+		return err
+	}
+	outputs := make([]interface{}, len(cfg.Outputs))
+	for i := range cfg.Outputs {
+		outputs[i] = flattenCollectorConfigurationOutput(&cfg.Outputs[i])
+	}
+	if err := d.Set("output", outputs); err != nil {
+		return err
+	}
+	snippets := make([]interface{}, len(cfg.Snippets))
+	for i := range cfg.Snippets {
+		snippets[i] = flattenCollectorConfigurationSnippet(&cfg.Snippets[i])
+	}
+	return d.Set("snippet", snippets)
+}
+
+// updateCollectorConfigurationInputs updates the inputs by their index.
+// Graylog API doesn't return the id of the created input,
+// but the created input is appended to the configuration's inputs
+// so the id is read by the index after the update.
+func updateCollectorConfigurationInputs(
+	ctx context.Context, cl *client.Client, d *schema.ResourceData,
+) error {
+	o, n := d.GetChange("input")
+	oldInputs, err := getCollectorConfigurationInputs(o)
+	if err != nil {
+		return err
+	}
+	newInputs, err := getCollectorConfigurationInputs(n)
+	if err != nil {
+		return err
+	}
+	for i := range newInputs {
+		if i >= len(oldInputs) {
+			// the id of the new input is generated by Graylog
+			newInputs[i].InputID = ""
+			if _, err := cl.CreateCollectorConfigurationInput(ctx, d.Id(), &newInputs[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		newInputs[i].InputID = oldInputs[i].InputID
+		if reflect.DeepEqual(oldInputs[i], newInputs[i]) {
+			continue
+		}
+		if _, err := cl.UpdateCollectorConfigurationInput(
+			ctx, d.Id(), oldInputs[i].InputID, &newInputs[i]); err != nil {
+			return err
+		}
+	}
+	if len(oldInputs) <= len(newInputs) {
+		return nil
+	}
+	for _, input := range oldInputs[len(newInputs):] {
+		if _, err := cl.DeleteCollectorConfigurationInput(ctx, d.Id(), input.InputID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateCollectorConfigurationOutputs(
+	ctx context.Context, cl *client.Client, d *schema.ResourceData,
+) error {
+	o, n := d.GetChange("output")
+	oldOutputs := getCollectorConfigurationOutputs(o)
+	newOutputs := getCollectorConfigurationOutputs(n)
+	for i := range newOutputs {
+		if i >= len(oldOutputs) {
+			newOutputs[i].OutputID = ""
+			if _, err := cl.CreateCollectorConfigurationOutput(ctx, d.Id(), &newOutputs[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		newOutputs[i].OutputID = oldOutputs[i].OutputID
+		if reflect.DeepEqual(oldOutputs[i], newOutputs[i]) {
+			continue
+		}
+		if _, err := cl.UpdateCollectorConfigurationOutput(
+			ctx, d.Id(), oldOutputs[i].OutputID, &newOutputs[i]); err != nil {
+			return err
+		}
+	}
+	if len(oldOutputs) <= len(newOutputs) {
+		return nil
+	}
+	for _, output := range oldOutputs[len(newOutputs):] {
+		if _, err := cl.DeleteCollectorConfigurationOutput(ctx, d.Id(), output.OutputID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateCollectorConfigurationSnippets(
+	ctx context.Context, cl *client.Client, d *schema.ResourceData,
+) error {
+	o, n := d.GetChange("snippet")
+	oldSnippets := getCollectorConfigurationSnippets(o)
+	newSnippets := getCollectorConfigurationSnippets(n)
+	for i := range newSnippets {
+		if i >= len(oldSnippets) {
+			newSnippets[i].SnippetID = ""
+			if _, err := cl.CreateCollectorConfigurationSnippet(ctx, d.Id(), &newSnippets[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		newSnippets[i].SnippetID = oldSnippets[i].SnippetID
+		if reflect.DeepEqual(oldSnippets[i], newSnippets[i]) {
+			continue
+		}
+		if _, err := cl.UpdateCollectorConfigurationSnippet(
+			ctx, d.Id(), oldSnippets[i].SnippetID, &newSnippets[i]); err != nil {
+			return err
+		}
+	}
+	if len(oldSnippets) <= len(newSnippets) {
+		return nil
+	}
+	for _, snippet := range oldSnippets[len(newSnippets):] {
+		if _, err := cl.DeleteCollectorConfigurationSnippet(ctx, d.Id(), snippet.SnippetID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceCollectorConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if d.HasChange("name") {
+		if _, _, err := cl.RenameCollectorConfiguration(ctx, d.Id(), d.Get("name").(string)); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("input") {
+		if err := updateCollectorConfigurationInputs(ctx, cl, d); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("output") {
+		if err := updateCollectorConfigurationOutputs(ctx, cl, d); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("snippet") {
+		if err := updateCollectorConfigurationSnippets(ctx, cl, d); err != nil {
+			return err
+		}
+	}
+	return resourceCollectorConfigurationRead(d, m)
+}
+
+func resourceCollectorConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	_, err = cl.DeleteCollectorConfiguration(ctx, d.Id())
+	return err
+}
@@ -0,0 +1,176 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func testDeleteCollectorConfiguration(
+	ctx context.Context, cl *client.Client, key string,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		if _, _, err := cl.GetCollectorConfiguration(ctx, id); err == nil {
+			return fmt.Errorf(`collector configuration "%s" must be deleted`, id)
+		}
+		return nil
+	}
+}
+
+func testCollectorConfiguration(
+	ctx context.Context, cl *client.Client, key, name string, inputs, outputs, snippets int,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		cfg, _, err := cl.GetCollectorConfiguration(ctx, id)
+		if err != nil {
+			return err
+		}
+		if cfg.Name != name {
+			return fmt.Errorf(`cfg.Name = "%s", wanted "%s"`, cfg.Name, name)
+		}
+		if len(cfg.Inputs) != inputs {
+			return fmt.Errorf("len(cfg.Inputs) = %d, wanted %d", len(cfg.Inputs), inputs)
+		}
+		if len(cfg.Outputs) != outputs {
+			return fmt.Errorf("len(cfg.Outputs) = %d, wanted %d", len(cfg.Outputs), outputs)
+		}
+		if len(cfg.Snippets) != snippets {
+			return fmt.Errorf("len(cfg.Snippets) = %d, wanted %d", len(cfg.Snippets), snippets)
+		}
+		return nil
+	}
+}
+
+func TestAccCollectorConfiguration(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	createTf := `
+resource "graylog_collector_configuration" "test" {
+  name = "test"
+  tags = ["linux"]
+
+  input {
+    backend = "filebeat"
+    type = "file"
+    name = "nginx"
+    forward_to = "output"
+    file_properties {
+      paths = "/var/log/nginx/access.log"
+      scan_frequency = "10s"
+      tail_files = true
+    }
+  }
+
+  output {
+    backend = "filebeat"
+    type = "logstash"
+    name = "output"
+    properties = {
+      hosts = "['localhost:5044']"
+    }
+  }
+}`
+	updateTf := `
+resource "graylog_collector_configuration" "test" {
+  name = "test updated"
+  tags = ["linux"]
+
+  input {
+    backend = "filebeat"
+    type = "file"
+    name = "nginx"
+    forward_to = "output"
+    file_properties {
+      paths = "/var/log/nginx/error.log"
+      scan_frequency = "10s"
+      tail_files = true
+    }
+  }
+
+  input {
+    backend = "winlogbeat"
+    type = "windows-eventlog"
+    name = "eventlog"
+    forward_to = "output"
+    windows_event_log_properties {
+      event = "Application"
+    }
+  }
+
+  output {
+    backend = "filebeat"
+    type = "logstash"
+    name = "output"
+    properties = {
+      hosts = "['localhost:5044']"
+    }
+  }
+
+  snippet {
+    backend = "filebeat"
+    name = "snippet"
+    snippet = "logging.level: info"
+  }
+}`
+	key := "graylog_collector_configuration.test"
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteCollectorConfiguration(ctx, cl, key),
+		Steps: []resource.TestStep{
+			{
+				Config: createTf,
+				Check: resource.ComposeTestCheckFunc(
+					testCollectorConfiguration(ctx, cl, key, "test", 1, 1, 0),
+				),
+			},
+			{
+				Config: updateTf,
+				Check: resource.ComposeTestCheckFunc(
+					testCollectorConfiguration(ctx, cl, key, "test updated", 2, 1, 1),
+					resource.TestCheckResourceAttr(key, "input.0.file_properties.0.paths", "/var/log/nginx/error.log"),
+				),
+			},
+			{
+				Config: createTf,
+				Check: resource.ComposeTestCheckFunc(
+					testCollectorConfiguration(ctx, cl, key, "test", 1, 1, 0),
+				),
+			},
+			{
+				ResourceName:      key,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
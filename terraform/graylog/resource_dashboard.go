@@ -2,6 +2,7 @@ package graylog
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/hashicorp/terraform/helper/schema"
 
@@ -15,8 +16,10 @@ func resourceDashboard() *schema.Resource {
 		Update: resourceDashboardUpdate,
 		Delete: resourceDashboardDelete,
 
+		CustomizeDiff: resourceDashboardDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(dashboardImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -36,29 +39,17 @@ func resourceDashboard() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
-			// TODO support widget
-			// "widgets": {
-			// 	Type:     schema.TypeSet,
-			// 	Optional: true,
-			// 	Elem: &schema.Resource{
-			// 		Schema: map[string]*schema.Schema{
-			// 			"type": {
-			// 				Type:     schema.TypeString,
-			// 				Optional: true,
-			// 			},
-			// 			"creator_user_id": {
-			// 				Type:     schema.TypeString,
-			// 				Optional: true,
-			// 				Computed: true,
-			// 			},
-			// 			"cache_time": {
-			// 				Type:     schema.TypeInt,
-			// 				Optional: true,
-			// 			},
-			// 			// TODO add config
-			// 		},
-			// 	},
-			// },
+			"widget": dashboardInlineWidgetSchema(),
+
+			// Computed
+			// the map of the inline widget's key to the widget id
+			"widget_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -74,16 +65,35 @@ func setDashboard(d *schema.ResourceData, db *graylog.Dashboard) error {
 	return setStrToRD(d, "created_at", db.CreatedAt)
 }
 
-func newDashboard(d *schema.ResourceData) (*graylog.Dashboard, error) {
+func newDashboard(d resourceData) (*graylog.Dashboard, error) {
 	return &graylog.Dashboard{
 		ID:          d.Id(),
 		Title:       d.Get("title").(string),
 		Description: d.Get("description").(string),
 		CreatedAt:   d.Get("created_at").(string),
-		// Widgets:     d.Get("widgets").(string),
 	}, nil
 }
 
+func resourceDashboardDiff(d *schema.ResourceDiff, m interface{}) error {
+	db, err := newDashboard(d)
+	if err != nil {
+		return err
+	}
+	msgs, err := validateDashboardInlineWidgets(d)
+	if err != nil {
+		return err
+	}
+	if d.Id() != "" && d.HasChange("widget") {
+		o, n := d.GetChange("widget")
+		if !reflect.DeepEqual(getDashboardInlineWidgetKeys(o), getDashboardInlineWidgetKeys(n)) {
+			if err := d.SetNewComputed("widget_ids"); err != nil {
+				return err
+			}
+		}
+	}
+	return validatePlan(d, getValidator(d), db, nil, msgs...)
+}
+
 func resourceDashboardCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -98,7 +108,7 @@ func resourceDashboardCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	d.SetId(db.ID)
-	return nil
+	return applyDashboardInlineWidgets(ctx, cl, d)
 }
 
 func resourceDashboardRead(d *schema.ResourceData, m interface{}) error {
@@ -111,7 +121,10 @@ func resourceDashboardRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
-	return setDashboard(d, db)
+	if err := setDashboard(d, db); err != nil {
+		return err
+	}
+	return setDashboardInlineWidgets(d, db)
 }
 
 func resourceDashboardUpdate(d *schema.ResourceData, m interface{}) error {
@@ -125,8 +138,13 @@ func resourceDashboardUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	if _, err = cl.UpdateDashboard(ctx, db); err != nil {
-		return err
+	if hasChange(d, "title", "description") {
+		if _, err = cl.UpdateDashboard(ctx, db); err != nil {
+			return err
+		}
+	}
+	if hasChange(d, "widget") {
+		return applyDashboardInlineWidgets(ctx, cl, d)
 	}
 	return nil
 }
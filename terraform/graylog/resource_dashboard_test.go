@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 
+	"github.com/suzuki-shunsuke/go-graylog"
 	"github.com/suzuki-shunsuke/go-graylog/client"
 )
 
@@ -59,6 +61,55 @@ func testUpdateDashboard(
 	}
 }
 
+// testDashboardInlineWidgets checks the inline widgets.
+// descriptions maps the widget key to the description.
+// ids maps the widget key to the widget id and is used to check that the widget isn't recreated.
+func testDashboardInlineWidgets(
+	ctx context.Context, cl *client.Client, key string,
+	ids, descriptions map[string]string, positions map[string]int,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		db, _, err := cl.GetDashboard(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(db.Widgets) != len(descriptions) {
+			return fmt.Errorf("len(db.Widgets) == %d, wanted %d", len(db.Widgets), len(descriptions))
+		}
+		widgets := make(map[string]graylog.Widget, len(db.Widgets))
+		for _, w := range db.Widgets {
+			widgets[w.ID] = w
+		}
+		cols := make(map[string]int, len(db.Positions))
+		for _, p := range db.Positions {
+			cols[p.WidgetID] = p.Col
+		}
+		attrs := tfState.RootModule().Resources[key].Primary.Attributes
+		for k, description := range descriptions {
+			widgetID := attrs["widget_ids."+k]
+			w, ok := widgets[widgetID]
+			if !ok {
+				return fmt.Errorf(`the widget "%s" isn't found`, k)
+			}
+			if w.Description != description {
+				return fmt.Errorf(`the description of the widget "%s" == "%s", wanted "%s"`, k, w.Description, description)
+			}
+			if cols[widgetID] != positions[k] {
+				return fmt.Errorf(`the col of the widget "%s" == %d, wanted %d`, k, cols[widgetID], positions[k])
+			}
+			if a, ok := ids[k]; ok && a != widgetID {
+				return fmt.Errorf(`the widget "%s" is recreated`, k)
+			}
+			ids[k] = widgetID
+		}
+		return nil
+	}
+}
+
 func TestAccDashboard(t *testing.T) {
 	ctx := context.Background()
 	cl, server, err := setEnv()
@@ -111,3 +162,100 @@ resource "graylog_dashboard" "zoo" {
 		},
 	})
 }
+
+func TestAccDashboardInlineWidgets(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	fooWidget := `
+  widget {
+    key = "foo"
+    type = "SEARCH_RESULT_COUNT"
+    description = "%s"
+    search_result_count_configuration {
+      timerange {
+        type = "relative"
+        range = 300
+      }
+      query = "status:500"
+    }
+    position {
+      width = 1
+      height = 1
+      row = 1
+      col = %d
+    }
+  }`
+	barWidget := `
+  widget {
+    key = "bar"
+    type = "QUICKVALUES"
+    description = "%s"
+    cache_time = 10
+    quick_values_configuration {
+      timerange {
+        type = "relative"
+        range = 300
+      }
+      field = "status"
+      show_data_table = true
+      show_pie_chart = true
+      limit = 5
+      data_table_limit = 60
+    }
+  }`
+	dbTf := `
+resource "graylog_dashboard" "test" {
+  title = "test-dashboard-inline-widgets"
+  description = "test dashboard"
+%s
+%s
+}`
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	key := "graylog_dashboard.test"
+	ids := map[string]string{}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteDashboard(ctx, cl, key),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(dbTf, fmt.Sprintf(fooWidget, "foo", 1), fmt.Sprintf(barWidget, "bar")),
+				Check: testDashboardInlineWidgets(
+					ctx, cl, key, ids,
+					map[string]string{"foo": "foo", "bar": "bar"}, map[string]int{"foo": 1}),
+			},
+			{
+				// reorder the widgets
+				Config: fmt.Sprintf(dbTf, fmt.Sprintf(barWidget, "bar updated"), fmt.Sprintf(fooWidget, "foo", 2)),
+				Check: testDashboardInlineWidgets(
+					ctx, cl, key, ids,
+					map[string]string{"foo": "foo", "bar": "bar updated"}, map[string]int{"foo": 2}),
+			},
+			{
+				Config: fmt.Sprintf(dbTf, fmt.Sprintf(fooWidget, "foo", 2), ""),
+				Check: testDashboardInlineWidgets(
+					ctx, cl, key, ids,
+					map[string]string{"foo": "foo"}, map[string]int{"foo": 2}),
+			},
+			{
+				Config:      fmt.Sprintf(dbTf, fmt.Sprintf(fooWidget, "foo", 2), fmt.Sprintf(fooWidget, "foo", 3)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`widget.1.key: "foo" is duplicated`),
+			},
+		},
+	})
+}
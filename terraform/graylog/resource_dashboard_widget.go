@@ -2,283 +2,416 @@ package graylog
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
 	"github.com/suzuki-shunsuke/go-ptr"
 )
 
 func resourceDashboardWidget() *schema.Resource {
+	sc := dashboardWidgetSchema()
+	// Required
+	sc["dashboard_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+	// Optional
+	sc["creator_user_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
 	return &schema.Resource{
 		Create: resourceDashboardWidgetCreate,
 		Read:   resourceDashboardWidgetRead,
 		Update: resourceDashboardWidgetUpdate,
 		Delete: resourceDashboardWidgetDelete,
 
+		CustomizeDiff: resourceDashboardWidgetDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: genImport("dashboard_id", "dashboard_widget_id"),
+			State: genNestedImport(
+				"graylog_dashboard_widget", "dashboard_id", "dashboard_widget_id",
+				dashboardImportTarget, dashboardWidgetImportTarget, resourceDashboardWidget,
+			),
 		},
 
-		Schema: map[string]*schema.Schema{
-			// Required
-			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"dashboard_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Required: true,
+		Schema: sc,
+	}
+}
+
+// dashboardWidgetSchema returns the schema of the widget's attributes
+// which are common to graylog_dashboard_widget and the inline widget of graylog_dashboard.
+func dashboardWidgetSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Required
+		"type": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+
+		// Optional
+		// Graylog sets the default cache time if it isn't set
+		"cache_time": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+
+		// the JSON configuration of the other widget types such as types registered with graylog.RegisterWidgetConfigs
+		"json_configuration": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressEquivalentJSONDiffs,
+		},
+
+		"quick_values_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// Required
+					"timerange": timeRangeSchema(),
+					// Optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"query": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"interval": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"field": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"sort_order": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"stacked_fields": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"show_data_table": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"show_pie_chart": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"limit": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+					"data_table_limit": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+				},
 			},
+		},
 
-			// Optional
-			"cache_time": {
-				Type:     schema.TypeInt,
-				Optional: true,
+		"quick_values_histogram_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// Required
+					"timerange": timeRangeSchema(),
+					// Optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"query": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"field": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"sort_order": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"stacked_fields": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"limit": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+				},
 			},
-			"creator_user_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+		},
+
+		"stats_count_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// Required
+					"timerange": timeRangeSchema(),
+					// Optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"query": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"field": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"stats_function": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"lower_is_better": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"trend": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+				},
 			},
+		},
 
-			"quick_values_configuration": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				MinItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						// Required
-						"timerange": timeRangeSchema(),
-						// Optional
-						"stream_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"query": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"interval": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"field": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"sort_order": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"stacked_fields": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"show_data_table": {
-							Type:     schema.TypeBool,
-							Optional: true,
-						},
-						"show_pie_chart": {
-							Type:     schema.TypeBool,
-							Optional: true,
-						},
-						"limit": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
-						"data_table_limit": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
+		"search_result_chart_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// Required
+					"timerange": timeRangeSchema(),
+					// Optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"query": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"interval": {
+						Type:     schema.TypeString,
+						Optional: true,
 					},
 				},
 			},
+		},
 
-			"quick_values_histogram_configuration": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				MinItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						// Required
-						"timerange": timeRangeSchema(),
-						// Optional
-						"stream_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"query": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"field": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"sort_order": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"stacked_fields": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"limit": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
+		"stream_search_result_count_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"timerange": timeRangeSchema(),
+					// optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"query": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"lower_is_better": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"trend": {
+						Type:     schema.TypeBool,
+						Optional: true,
 					},
 				},
 			},
+		},
 
-			"stats_count_configuration": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				MinItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						// Required
-						"timerange": timeRangeSchema(),
-						// Optional
-						"stream_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"query": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"field": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"stats_function": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"lower_is_better": {
-							Type:     schema.TypeBool,
-							Optional: true,
-						},
-						"trend": {
-							Type:     schema.TypeBool,
-							Optional: true,
-						},
+		"field_chart_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// Required
+					"timerange": timeRangeSchema(),
+					// Optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"query": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"interval": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"valuetype": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"renderer": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"interpolation": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"range_type": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"field": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"relative": {
+						Type:     schema.TypeInt,
+						Optional: true,
 					},
 				},
 			},
+		},
 
-			"search_result_chart_configuration": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				MinItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						// Required
-						"timerange": timeRangeSchema(),
-						// Optional
-						"stream_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"query": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"interval": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+		"world_map_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// Required
+					"timerange": timeRangeSchema(),
+					"field": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					// Optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"query": {
+						Type:     schema.TypeString,
+						Optional: true,
 					},
 				},
 			},
+		},
 
-			"stream_search_result_count_configuration": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				MinItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"timerange": timeRangeSchema(),
-						// optional
-						"stream_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"query": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"lower_is_better": {
-							Type:     schema.TypeBool,
-							Optional: true,
-						},
-						"trend": {
-							Type:     schema.TypeBool,
-							Optional: true,
+		"stacked_chart_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// Required
+					"timerange": timeRangeSchema(),
+					"series": {
+						Type:     schema.TypeList,
+						Required: true,
+						MinItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"query": {
+									Type:     schema.TypeString,
+									Optional: true,
+								},
+								"field": {
+									Type:     schema.TypeString,
+									Optional: true,
+								},
+								"statistical_function": {
+									Type:     schema.TypeString,
+									Optional: true,
+								},
+							},
 						},
 					},
+					// Optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"interval": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"renderer": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"interpolation": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
 				},
 			},
+		},
 
-			"field_chart_configuration": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				MinItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						// Required
-						"timerange": timeRangeSchema(),
-						// Optional
-						"stream_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"query": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"interval": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"valuetype": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"renderer": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"interpolation": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"range_type": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"field": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"relative": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
+		"search_result_count_configuration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// Required
+					"timerange": timeRangeSchema(),
+					// Optional
+					"stream_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"query": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"lower_is_better": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"trend": {
+						Type:     schema.TypeBool,
+						Optional: true,
 					},
 				},
 			},
@@ -307,18 +440,54 @@ func timeRangeSchema() *schema.Schema {
 	}
 }
 
-func newDashboardWidget(d *schema.ResourceData) (*graylog.Widget, string, error) {
-	var config graylog.WidgetConfig
-	t := d.Get("type").(string)
+func newDashboardWidget(d resourceData) (*graylog.Widget, string, error) {
+	config, err := newDashboardWidgetConfig(d.Get("type").(string), d.Get)
+	if err != nil {
+		return nil, "", err
+	}
+	return &graylog.Widget{
+		Description:   d.Get("description").(string),
+		CreatorUserID: d.Get("creator_user_id").(string),
+		CacheTime:     ptr.PInt(d.Get("cache_time").(int)),
+		ID:            d.Id(),
+		Config:        config,
+	}, d.Get("dashboard_id").(string), nil
+}
+
+// dashboardWidgetConfigKeys maps the widget type to the name of the configuration block.
+var dashboardWidgetConfigKeys = map[string]string{
+	"QUICKVALUES":                "quick_values_configuration",
+	"QUICKVALUES_HISTOGRAM":      "quick_values_histogram_configuration",
+	"STATS_COUNT":                "stats_count_configuration",
+	"STREAM_SEARCH_RESULT_COUNT": "stream_search_result_count_configuration",
+	"SEARCH_RESULT_CHART":        "search_result_chart_configuration",
+	"FIELD_CHART":                "field_chart_configuration",
+	"STACKED_CHART":              "stacked_chart_configuration",
+	"SEARCH_RESULT_COUNT":        "search_result_count_configuration",
+
+	"org.graylog.plugins.map.widget.strategy.MapWidgetStrategy": "world_map_configuration",
+}
+
+// getDashboardWidgetConfigKey returns the name of the attribute of the widget configuration.
+// The configuration of the other widget types is set with json_configuration.
+func getDashboardWidgetConfigKey(t string) string {
+	if k, ok := dashboardWidgetConfigKeys[t]; ok {
+		return k
+	}
+	return "json_configuration"
+}
+
+// newDashboardWidgetConfig returns the widget configuration of the given widget type.
+// get returns the value of the widget's attribute.
+func newDashboardWidgetConfig(t string, get func(string) interface{}) (graylog.WidgetConfig, error) {
 	switch t {
 	case "QUICKVALUES":
-		c, ok := d.GetOk("quick_values_configuration")
+		cfg, ok := getBlock(get("quick_values_configuration"))
 		if !ok {
-			return nil, "", errors.New(`"quick_values_configuration" must be set`)
+			return nil, errors.New(`"quick_values_configuration" must be set`)
 		}
-		cfg := c.([]interface{})[0].(map[string]interface{})
 		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
-		config = &graylog.WidgetConfigQuickValues{
+		return &graylog.WidgetConfigQuickValues{
 			Timerange: &graylog.Timerange{
 				Type:  timeRange["type"].(string),
 				Range: timeRange["range"].(int),
@@ -333,15 +502,14 @@ func newDashboardWidget(d *schema.ResourceData) (*graylog.Widget, string, error)
 			ShowPieChart:   cfg["show_pie_chart"].(bool),
 			Limit:          cfg["limit"].(int),
 			DataTableLimit: cfg["data_table_limit"].(int),
-		}
+		}, nil
 	case "QUICKVALUES_HISTOGRAM":
-		c, ok := d.GetOk("quick_values_histogram_configuration")
+		cfg, ok := getBlock(get("quick_values_histogram_configuration"))
 		if !ok {
-			return nil, "", errors.New(`"quick_values_histogram_configuration" must be set`)
+			return nil, errors.New(`"quick_values_histogram_configuration" must be set`)
 		}
-		cfg := c.([]interface{})[0].(map[string]interface{})
 		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
-		config = &graylog.WidgetConfigQuickValuesHistogram{
+		return &graylog.WidgetConfigQuickValuesHistogram{
 			Timerange: &graylog.Timerange{
 				Type:  timeRange["type"].(string),
 				Range: timeRange["range"].(int),
@@ -352,15 +520,14 @@ func newDashboardWidget(d *schema.ResourceData) (*graylog.Widget, string, error)
 			SortOrder:     cfg["sort_order"].(string),
 			StackedFields: cfg["stacked_fields"].(string),
 			Limit:         cfg["limit"].(int),
-		}
+		}, nil
 	case "STATS_COUNT":
-		c, ok := d.GetOk("stats_count_configuration")
+		cfg, ok := getBlock(get("stats_count_configuration"))
 		if !ok {
-			return nil, "", errors.New(`"stats_count_configuration" must be set`)
+			return nil, errors.New(`"stats_count_configuration" must be set`)
 		}
-		cfg := c.([]interface{})[0].(map[string]interface{})
 		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
-		config = &graylog.WidgetConfigStatsCount{
+		return &graylog.WidgetConfigStatsCount{
 			Timerange: &graylog.Timerange{
 				Type:  timeRange["type"].(string),
 				Range: timeRange["range"].(int),
@@ -371,15 +538,14 @@ func newDashboardWidget(d *schema.ResourceData) (*graylog.Widget, string, error)
 			StatsFunction: cfg["stats_function"].(string),
 			LowerIsBetter: cfg["lower_is_better"].(bool),
 			Trend:         cfg["trend"].(bool),
-		}
+		}, nil
 	case "STREAM_SEARCH_RESULT_COUNT":
-		c, ok := d.GetOk("stream_search_result_count_configuration")
+		cfg, ok := getBlock(get("stream_search_result_count_configuration"))
 		if !ok {
-			return nil, "", errors.New(`"stream_search_result_count_configuration" must be set`)
+			return nil, errors.New(`"stream_search_result_count_configuration" must be set`)
 		}
-		cfg := c.([]interface{})[0].(map[string]interface{})
 		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
-		config = &graylog.WidgetConfigStreamSearchResultCount{
+		return &graylog.WidgetConfigStreamSearchResultCount{
 			Timerange: &graylog.Timerange{
 				Type:  timeRange["type"].(string),
 				Range: timeRange["range"].(int),
@@ -388,15 +554,14 @@ func newDashboardWidget(d *schema.ResourceData) (*graylog.Widget, string, error)
 			Query:         cfg["query"].(string),
 			LowerIsBetter: cfg["lower_is_better"].(bool),
 			Trend:         cfg["trend"].(bool),
-		}
+		}, nil
 	case "SEARCH_RESULT_CHART":
-		c, ok := d.GetOk("search_result_chart_configuration")
+		cfg, ok := getBlock(get("search_result_chart_configuration"))
 		if !ok {
-			return nil, "", errors.New(`"search_result_chart_configuration" must be set`)
+			return nil, errors.New(`"search_result_chart_configuration" must be set`)
 		}
-		cfg := c.([]interface{})[0].(map[string]interface{})
 		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
-		config = &graylog.WidgetConfigSearchResultChart{
+		return &graylog.WidgetConfigSearchResultChart{
 			Timerange: &graylog.Timerange{
 				Type:  timeRange["type"].(string),
 				Range: timeRange["range"].(int),
@@ -404,15 +569,14 @@ func newDashboardWidget(d *schema.ResourceData) (*graylog.Widget, string, error)
 			StreamID: cfg["stream_id"].(string),
 			Query:    cfg["query"].(string),
 			Interval: cfg["interval"].(string),
-		}
+		}, nil
 	case "FIELD_CHART":
-		c, ok := d.GetOk("field_chart_configuration")
+		cfg, ok := getBlock(get("field_chart_configuration"))
 		if !ok {
-			return nil, "", errors.New(`"field_chart_configuration" must be set`)
+			return nil, errors.New(`"field_chart_configuration" must be set`)
 		}
-		cfg := c.([]interface{})[0].(map[string]interface{})
 		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
-		config = &graylog.WidgetConfigFieldChart{
+		return &graylog.WidgetConfigFieldChart{
 			Timerange: &graylog.Timerange{
 				Type:  timeRange["type"].(string),
 				Range: timeRange["range"].(int),
@@ -426,17 +590,94 @@ func newDashboardWidget(d *schema.ResourceData) (*graylog.Widget, string, error)
 			RangeType:     cfg["range_type"].(string),
 			Field:         cfg["field"].(string),
 			Relative:      cfg["relative"].(int),
+		}, nil
+	case "org.graylog.plugins.map.widget.strategy.MapWidgetStrategy":
+		cfg, ok := getBlock(get("world_map_configuration"))
+		if !ok {
+			return nil, errors.New(`"world_map_configuration" must be set`)
+		}
+		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
+		return &graylog.WidgetConfigWorldMap{
+			Timerange: &graylog.Timerange{
+				Type:  timeRange["type"].(string),
+				Range: timeRange["range"].(int),
+			},
+			StreamID: cfg["stream_id"].(string),
+			Query:    cfg["query"].(string),
+			Field:    cfg["field"].(string),
+		}, nil
+	case "STACKED_CHART":
+		cfg, ok := getBlock(get("stacked_chart_configuration"))
+		if !ok {
+			return nil, errors.New(`"stacked_chart_configuration" must be set`)
+		}
+		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
+		srcSeries := cfg["series"].([]interface{})
+		series := make([]graylog.WidgetConfigStackedChartSeries, len(srcSeries))
+		for i, a := range srcSeries {
+			sr := a.(map[string]interface{})
+			series[i] = graylog.WidgetConfigStackedChartSeries{
+				Query:               sr["query"].(string),
+				Field:               sr["field"].(string),
+				StatisticalFunction: sr["statistical_function"].(string),
+			}
+		}
+		return &graylog.WidgetConfigStackedChart{
+			Timerange: &graylog.Timerange{
+				Type:  timeRange["type"].(string),
+				Range: timeRange["range"].(int),
+			},
+			StreamID:      cfg["stream_id"].(string),
+			Interval:      cfg["interval"].(string),
+			Renderer:      cfg["renderer"].(string),
+			Interpolation: cfg["interpolation"].(string),
+			Series:        series,
+		}, nil
+	case "SEARCH_RESULT_COUNT":
+		cfg, ok := getBlock(get("search_result_count_configuration"))
+		if !ok {
+			return nil, errors.New(`"search_result_count_configuration" must be set`)
 		}
+		timeRange := cfg["timerange"].([]interface{})[0].(map[string]interface{})
+		return &graylog.WidgetConfigSearchResultCount{
+			Timerange: &graylog.Timerange{
+				Type:  timeRange["type"].(string),
+				Range: timeRange["range"].(int),
+			},
+			StreamID:      cfg["stream_id"].(string),
+			Query:         cfg["query"].(string),
+			LowerIsBetter: cfg["lower_is_better"].(bool),
+			Trend:         cfg["trend"].(bool),
+		}, nil
 	default:
-		return nil, "", errors.New("unsupported type: " + t)
+		c, ok := get("json_configuration").(string)
+		if !ok || c == "" {
+			return nil, errors.New(`"json_configuration" must be set`)
+		}
+		cfg, ok := graylog.NewWidgetConfigByType(t)
+		if !ok {
+			cfg = &graylog.WidgetConfigUnknownType{T: t}
+		}
+		if err := json.Unmarshal([]byte(c), cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse json_configuration: %v", err)
+		}
+		if _, ok := cfg.(*graylog.WidgetConfigUnknownType); !ok {
+			if err := validator.CreateValidator.Struct(cfg); err != nil {
+				return nil, fmt.Errorf("invalid json_configuration: %v", err)
+			}
+		}
+		return cfg, nil
 	}
-	return &graylog.Widget{
-		Description:   d.Get("description").(string),
-		CreatorUserID: d.Get("creator_user_id").(string),
-		CacheTime:     ptr.PInt(d.Get("cache_time").(int)),
-		ID:            d.Id(),
-		Config:        config,
-	}, d.Get("dashboard_id").(string), nil
+}
+
+func resourceDashboardWidgetDiff(d *schema.ResourceDiff, m interface{}) error {
+	widget, _, err := newDashboardWidget(d)
+	if err != nil {
+		return err
+	}
+	return validatePlan(d, getValidator(d), widget, map[string]string{
+		"config": getDashboardWidgetConfigKey(widget.Type()),
+	})
 }
 
 func resourceDashboardWidgetCreate(d *schema.ResourceData, m interface{}) error {
@@ -480,13 +721,65 @@ func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
 			return err
 		}
 	}
+	key, cfg, err := flattenDashboardWidgetConfig(&widget)
+	if err != nil {
+		return err
+	}
+	if err := d.Set(key, cfg); err != nil {
+		return err
+	}
+	return setStrToRD(d, "creator_user_id", widget.CreatorUserID)
+}
+
+func resourceDashboardWidgetUpdate(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	widget, dashboardID, err := newDashboardWidget(d)
+	if err != nil {
+		return err
+	}
+	if d.HasChange("cache_time") {
+		if _, err := cl.UpdateDashboardWidgetCacheTime(ctx, dashboardID, widget.ID, d.Get("cache_time").(int)); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("description") {
+		if _, err := cl.UpdateDashboardWidgetDescription(ctx, dashboardID, widget.ID, widget.Description); err != nil {
+			return err
+		}
+	}
+	if hasChange(d, "type", "quick_values_histogram_configuration", "quick_values_configuration", "stats_count_configuration", "search_result_chart_configuration", "stream_search_result_count_configuration", "field_chart_configuration", "world_map_configuration", "stacked_chart_configuration", "search_result_count_configuration", "json_configuration") {
+		if _, err := cl.UpdateDashboardWidget(ctx, dashboardID, *widget); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceDashboardWidgetDelete(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.DeleteDashboardWidget(ctx, d.Get("dashboard_id").(string), d.Id()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// flattenDashboardWidgetConfig returns the attribute name and value of the widget configuration.
+func flattenDashboardWidgetConfig(widget *graylog.Widget) (string, interface{}, error) {
 	switch widget.Type() {
 	case "STATS_COUNT":
 		cfg, ok := widget.Config.(*graylog.WidgetConfigStatsCount)
 		if !ok {
-			return errors.New("invalid type")
+			return "", nil, errors.New("invalid type")
 		}
-		if err := d.Set("stats_count_configuration", []map[string]interface{}{{
+		return "stats_count_configuration", []map[string]interface{}{{
 			"timerange": []map[string]interface{}{{
 				"type":  cfg.Timerange.Type,
 				"range": cfg.Timerange.Range,
@@ -497,15 +790,13 @@ func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
 			"stats_function":  cfg.StatsFunction,
 			"lower_is_better": cfg.LowerIsBetter,
 			"trend":           cfg.Trend,
-		}}); err != nil {
-			return err
-		}
+		}}, nil
 	case "QUICKVALUES":
 		cfg, ok := widget.Config.(*graylog.WidgetConfigQuickValues)
 		if !ok {
-			return errors.New("invalid type")
+			return "", nil, errors.New("invalid type")
 		}
-		if err := d.Set("quick_values_configuration", []map[string]interface{}{{
+		return "quick_values_configuration", []map[string]interface{}{{
 			"timerange": []map[string]interface{}{{
 				"type":  cfg.Timerange.Type,
 				"range": cfg.Timerange.Range,
@@ -520,15 +811,13 @@ func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
 			"show_pie_chart":   cfg.ShowPieChart,
 			"limit":            cfg.Limit,
 			"data_table_limit": cfg.DataTableLimit,
-		}}); err != nil {
-			return err
-		}
+		}}, nil
 	case "QUICKVALUES_HISTOGRAM":
 		cfg, ok := widget.Config.(*graylog.WidgetConfigQuickValuesHistogram)
 		if !ok {
-			return errors.New("invalid type")
+			return "", nil, errors.New("invalid type")
 		}
-		if err := d.Set("quick_values_histogram_configuration", []map[string]interface{}{{
+		return "quick_values_histogram_configuration", []map[string]interface{}{{
 			"timerange": []map[string]interface{}{{
 				"type":  cfg.Timerange.Type,
 				"range": cfg.Timerange.Range,
@@ -539,15 +828,13 @@ func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
 			"sort_order":     cfg.SortOrder,
 			"stacked_fields": cfg.StackedFields,
 			"limit":          cfg.Limit,
-		}}); err != nil {
-			return err
-		}
+		}}, nil
 	case "SEARCH_RESULT_CHART":
 		cfg, ok := widget.Config.(*graylog.WidgetConfigSearchResultChart)
 		if !ok {
-			return errors.New("invalid type")
+			return "", nil, errors.New("invalid type")
 		}
-		if err := d.Set("search_result_chart_configuration", []map[string]interface{}{{
+		return "search_result_chart_configuration", []map[string]interface{}{{
 			"timerange": []map[string]interface{}{{
 				"type":  cfg.Timerange.Type,
 				"range": cfg.Timerange.Range,
@@ -555,15 +842,13 @@ func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
 			"stream_id": cfg.StreamID,
 			"query":     cfg.Query,
 			"interval":  cfg.Interval,
-		}}); err != nil {
-			return err
-		}
+		}}, nil
 	case "STREAM_SEARCH_RESULT_COUNT":
 		cfg, ok := widget.Config.(*graylog.WidgetConfigStreamSearchResultCount)
 		if !ok {
-			return errors.New("invalid type")
+			return "", nil, errors.New("invalid type")
 		}
-		if err := d.Set("stream_search_result_count_configuration", []map[string]interface{}{{
+		return "stream_search_result_count_configuration", []map[string]interface{}{{
 			"timerange": []map[string]interface{}{{
 				"type":  cfg.Timerange.Type,
 				"range": cfg.Timerange.Range,
@@ -572,15 +857,13 @@ func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
 			"trend":           cfg.Trend,
 			"stream_id":       cfg.StreamID,
 			"query":           cfg.Query,
-		}}); err != nil {
-			return err
-		}
+		}}, nil
 	case "FIELD_CHART":
 		cfg, ok := widget.Config.(*graylog.WidgetConfigFieldChart)
 		if !ok {
-			return errors.New("invalid type")
+			return "", nil, errors.New("invalid type")
 		}
-		if err := d.Set("field_chart_configuration", []map[string]interface{}{{
+		return "field_chart_configuration", []map[string]interface{}{{
 			"timerange": []map[string]interface{}{{
 				"type":  cfg.Timerange.Type,
 				"range": cfg.Timerange.Range,
@@ -594,51 +877,65 @@ func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
 			"range_type":    cfg.RangeType,
 			"field":         cfg.Field,
 			"relative":      cfg.Relative,
-		}}); err != nil {
-			return err
+		}}, nil
+	case "org.graylog.plugins.map.widget.strategy.MapWidgetStrategy":
+		cfg, ok := widget.Config.(*graylog.WidgetConfigWorldMap)
+		if !ok {
+			return "", nil, errors.New("invalid type")
 		}
-	default:
-		return errors.New("unsupported type: " + widget.Type())
-	}
-	return setStrToRD(d, "creator_user_id", widget.CreatorUserID)
-}
-
-func resourceDashboardWidgetUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
-	cl, err := newClient(m)
-	if err != nil {
-		return err
-	}
-	widget, dashboardID, err := newDashboardWidget(d)
-	if err != nil {
-		return err
-	}
-	if d.HasChange("cache_time") {
-		if _, err := cl.UpdateDashboardWidgetCacheTime(ctx, dashboardID, widget.ID, d.Get("cache_time").(int)); err != nil {
-			return err
+		return "world_map_configuration", []map[string]interface{}{{
+			"timerange": []map[string]interface{}{{
+				"type":  cfg.Timerange.Type,
+				"range": cfg.Timerange.Range,
+			}},
+			"stream_id": cfg.StreamID,
+			"query":     cfg.Query,
+			"field":     cfg.Field,
+		}}, nil
+	case "STACKED_CHART":
+		cfg, ok := widget.Config.(*graylog.WidgetConfigStackedChart)
+		if !ok {
+			return "", nil, errors.New("invalid type")
 		}
-	}
-	if d.HasChange("description") {
-		if _, err := cl.UpdateDashboardWidgetDescription(ctx, dashboardID, widget.ID, widget.Description); err != nil {
-			return err
+		series := make([]map[string]interface{}, len(cfg.Series))
+		for i, sr := range cfg.Series {
+			series[i] = map[string]interface{}{
+				"query":                sr.Query,
+				"field":                sr.Field,
+				"statistical_function": sr.StatisticalFunction,
+			}
 		}
-	}
-	if hasChange(d, "type", "quick_values_histogram_configuration", "quick_values_configuration", "stats_count_configuration", "search_result_chart_configuration", "stream_search_result_count_configuration", "field_chart_configuration") {
-		if _, err := cl.UpdateDashboardWidget(ctx, dashboardID, *widget); err != nil {
-			return err
+		return "stacked_chart_configuration", []map[string]interface{}{{
+			"timerange": []map[string]interface{}{{
+				"type":  cfg.Timerange.Type,
+				"range": cfg.Timerange.Range,
+			}},
+			"stream_id":     cfg.StreamID,
+			"interval":      cfg.Interval,
+			"renderer":      cfg.Renderer,
+			"interpolation": cfg.Interpolation,
+			"series":        series,
+		}}, nil
+	case "SEARCH_RESULT_COUNT":
+		cfg, ok := widget.Config.(*graylog.WidgetConfigSearchResultCount)
+		if !ok {
+			return "", nil, errors.New("invalid type")
 		}
+		return "search_result_count_configuration", []map[string]interface{}{{
+			"timerange": []map[string]interface{}{{
+				"type":  cfg.Timerange.Type,
+				"range": cfg.Timerange.Range,
+			}},
+			"stream_id":       cfg.StreamID,
+			"query":           cfg.Query,
+			"lower_is_better": cfg.LowerIsBetter,
+			"trend":           cfg.Trend,
+		}}, nil
+	default:
+		b, err := json.Marshal(widget.Config)
+		if err != nil {
+			return "", nil, err
+		}
+		return "json_configuration", string(b), nil
 	}
-	return nil
-}
-
-func resourceDashboardWidgetDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
-	cl, err := newClient(m)
-	if err != nil {
-		return err
-	}
-	if _, err := cl.DeleteDashboardWidget(ctx, d.Get("dashboard_id").(string), d.Id()); err != nil {
-		return err
-	}
-	return nil
 }
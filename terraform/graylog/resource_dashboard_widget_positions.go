@@ -16,7 +16,7 @@ func resourceDashboardWidgetPositions() *schema.Resource {
 		Delete: resourceDashboardWidgetPositionsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(dashboardImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
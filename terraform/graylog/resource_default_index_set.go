@@ -0,0 +1,80 @@
+package graylog
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// defaultIndexSetID is the resource id of graylog_default_index_set.
+// Graylog has only one default index set, so the id is fixed.
+const defaultIndexSetID = "default"
+
+func resourceDefaultIndexSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDefaultIndexSetCreate,
+		Read:   resourceDefaultIndexSetRead,
+		Update: resourceDefaultIndexSetUpdate,
+		Delete: resourceDefaultIndexSetDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"index_set_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceDefaultIndexSetCreate(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if _, _, err := cl.SetDefaultIndexSet(ctx, d.Get("index_set_id").(string)); err != nil {
+		return err
+	}
+	d.SetId(defaultIndexSetID)
+	return nil
+}
+
+func resourceDefaultIndexSetRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	indexSets, _, _, _, err := cl.GetIndexSets(ctx, 0, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, is := range indexSets {
+		if is.Default {
+			return setStrToRD(d, "index_set_id", is.ID)
+		}
+	}
+	return setStrToRD(d, "index_set_id", "")
+}
+
+func resourceDefaultIndexSetUpdate(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	_, _, err = cl.SetDefaultIndexSet(ctx, d.Get("index_set_id").(string))
+	return err
+}
+
+// resourceDefaultIndexSetDelete does nothing,
+// because Graylog always requires the default index set.
+// The index set remains the default index set and isn't deleted.
+func resourceDefaultIndexSetDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
@@ -0,0 +1,121 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func getDefaultIndexSetID(ctx context.Context, cl *client.Client) (string, error) {
+	indexSets, _, _, _, err := cl.GetIndexSets(ctx, 0, 0, false)
+	if err != nil {
+		return "", err
+	}
+	for _, is := range indexSets {
+		if is.Default {
+			return is.ID, nil
+		}
+	}
+	return "", fmt.Errorf("the default index set is not found")
+}
+
+func testDefaultIndexSet(
+	ctx context.Context, cl *client.Client, key, attr string,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		rs, ok := tfState.RootModule().Resources[key]
+		if !ok {
+			return fmt.Errorf("not found: %s", key)
+		}
+		exp := rs.Primary.Attributes[attr]
+		id, err := getDefaultIndexSetID(ctx, cl)
+		if err != nil {
+			return err
+		}
+		if id != exp {
+			return fmt.Errorf("the default index set id = %s, wanted %s", id, exp)
+		}
+		return nil
+	}
+}
+
+func TestAccDefaultIndexSet(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	u, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := u.String()
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	origID, err := getDefaultIndexSetID(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexSetTf := fmt.Sprintf(`
+resource "graylog_index_set" "test" {
+  title = "terraform test default index set"
+  index_prefix = "%s"
+  shards = 4
+  replicas = 0
+  rotation_strategy_class = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy"
+  rotation_strategy {
+    type = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig"
+    max_docs_per_index = 20000000
+  }
+  retention_strategy_class = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
+  retention_strategy {
+    type = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
+    max_number_of_indices = 20
+  }
+  index_analyzer = "standard"
+  writable = true
+  index_optimization_max_num_segments = 1
+}`, prefix)
+	defaultTf := indexSetTf + `
+
+resource "graylog_default_index_set" "test" {
+  index_set_id = %s
+}`
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(defaultTf, "graylog_index_set.test.id"),
+				Check: resource.ComposeTestCheckFunc(
+					testDefaultIndexSet(ctx, cl, "graylog_index_set.test", "id"),
+				),
+			},
+			{
+				// switch the default index set back before the index set is deleted
+				Config: fmt.Sprintf(defaultTf, fmt.Sprintf(`"%s"`, origID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_default_index_set.test", "index_set_id", origID),
+					testDefaultIndexSet(ctx, cl, "graylog_default_index_set.test", "index_set_id"),
+				),
+			},
+		},
+	})
+}
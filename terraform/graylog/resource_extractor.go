@@ -18,8 +18,10 @@ func resourceExtractor() *schema.Resource {
 		Update: resourceExtractorUpdate,
 		Delete: resourceExtractorDelete,
 
+		CustomizeDiff: resourceExtractorDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: genImport("input_id", "extractor_id"),
+			State: genNestedImport("graylog_extractor", "input_id", "extractor_id", inputImportTarget, extractorImportTarget, resourceExtractor),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -194,10 +196,13 @@ func resourceExtractor() *schema.Resource {
 	}
 }
 
-func newExtractorConfig(d *schema.ResourceData, t string) interface{} {
+func newExtractorConfig(d resourceData, t string) (interface{}, error) {
 	switch t {
 	case "json":
-		a := d.Get("json_type_extractor_config").([]interface{})[0].(map[string]interface{})
+		a, ok := getBlock(d.Get("json_type_extractor_config"))
+		if !ok {
+			return nil, errors.New(`"json_type_extractor_config" must be set`)
+		}
 		return &graylog.ExtractorTypeJSONConfig{
 			ListSeparator:            a["list_separator"].(string),
 			KVSeparator:              a["kv_separator"].(string),
@@ -205,17 +210,23 @@ func newExtractorConfig(d *schema.ResourceData, t string) interface{} {
 			KeySeparator:             a["key_separator"].(string),
 			ReplaceKeyWhitespace:     a["replace_key_whitespace"].(bool),
 			KeyWhitespaceReplacement: a["key_whitespace_replacement"].(string),
-		}
+		}, nil
 	case "grok":
-		a := d.Get("grok_type_extractor_config").([]interface{})[0].(map[string]interface{})
+		a, ok := getBlock(d.Get("grok_type_extractor_config"))
+		if !ok {
+			return nil, errors.New(`"grok_type_extractor_config" must be set`)
+		}
 		return &graylog.ExtractorTypeGrokConfig{
 			GrokPattern: a["grok_pattern"].(string),
-		}
+		}, nil
 	case "regex":
-		a := d.Get("regex_type_extractor_config").([]interface{})[0].(map[string]interface{})
+		a, ok := getBlock(d.Get("regex_type_extractor_config"))
+		if !ok {
+			return nil, errors.New(`"regex_type_extractor_config" must be set`)
+		}
 		return &graylog.ExtractorTypeRegexConfig{
 			RegexValue: a["regex_value"].(string),
-		}
+		}, nil
 	default:
 		cfg := map[string]interface{}{}
 		for _, k := range []string{"bool", "int", "string", "float"} {
@@ -225,13 +236,16 @@ func newExtractorConfig(d *schema.ResourceData, t string) interface{} {
 				}
 			}
 		}
-		return cfg
+		return cfg, nil
 	}
 }
 
-func newExtractor(d *schema.ResourceData) (*graylog.Extractor, string, error) {
+func newExtractor(d resourceData) (*graylog.Extractor, string, error) {
 	t := d.Get("type").(string)
-	cfg := newExtractorConfig(d, t)
+	cfg, err := newExtractorConfig(d, t)
+	if err != nil {
+		return nil, "", err
+	}
 	list := d.Get("converters").([]interface{})
 	converters := make([]graylog.ExtractorConverter, len(list))
 	for i, a := range list {
@@ -265,6 +279,14 @@ func newExtractor(d *schema.ResourceData) (*graylog.Extractor, string, error) {
 	}, d.Get("input_id").(string), nil
 }
 
+func resourceExtractorDiff(d *schema.ResourceDiff, m interface{}) error {
+	extractor, _, err := newExtractor(d)
+	if err != nil {
+		return err
+	}
+	return validatePlan(d, getValidator(d), extractor, nil)
+}
+
 func resourceExtractorCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -16,7 +16,7 @@ func resourceGrokPattern() *schema.Resource {
 		Delete: resourceGrokPatternDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(grokPatternImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -32,7 +32,7 @@ func resourceGrokPattern() *schema.Resource {
 	}
 }
 
-func newGrokPattern(d *schema.ResourceData) *graylog.GrokPattern {
+func newGrokPattern(d resourceData) *graylog.GrokPattern {
 	return &graylog.GrokPattern{
 		Name:    d.Get("name").(string),
 		Pattern: d.Get("pattern").(string),
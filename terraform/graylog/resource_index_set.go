@@ -2,6 +2,8 @@ package graylog
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/hashicorp/terraform/helper/schema"
 
@@ -16,8 +18,10 @@ func resourceIndexSet() *schema.Resource {
 		Update: resourceIndexSetUpdate,
 		Delete: resourceIndexSetDelete,
 
+		CustomizeDiff: resourceIndexSetDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(indexSetImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -122,9 +126,11 @@ func resourceIndexSet() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			// Use graylog_default_index_set to change the default index set.
 			"default": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			// field_type_refresh_interval is added from Graylog API v3
 			"field_type_refresh_interval": {
@@ -135,12 +141,18 @@ func resourceIndexSet() *schema.Resource {
 	}
 }
 
-func newIndexSet(d *schema.ResourceData) (*graylog.IndexSet, error) {
+func newIndexSet(d resourceData) (*graylog.IndexSet, error) {
 	rotationStrategy := &graylog.RotationStrategy{}
 	retentionStrategy := &graylog.RetentionStrategy{}
 
-	ros := d.Get("rotation_strategy").([]interface{})[0].(map[string]interface{})
-	res := d.Get("retention_strategy").([]interface{})[0].(map[string]interface{})
+	ros, ok := getBlock(d.Get("rotation_strategy"))
+	if !ok {
+		return nil, errors.New(`"rotation_strategy" must be set`)
+	}
+	res, ok := getBlock(d.Get("retention_strategy"))
+	if !ok {
+		return nil, errors.New(`"retention_strategy" must be set`)
+	}
 	if err := util.MSDecode(ros, rotationStrategy); err != nil {
 		return nil, err
 	}
@@ -169,6 +181,56 @@ func newIndexSet(d *schema.ResourceData) (*graylog.IndexSet, error) {
 	}, nil
 }
 
+func resourceIndexSetDiff(d *schema.ResourceDiff, m interface{}) error {
+	is, err := newIndexSet(d)
+	if err != nil {
+		return err
+	}
+	msgs := validateIndexSetStrategies(d, is)
+	if d.Id() == "" {
+		return validatePlan(d, getValidator(d), is, nil, msgs...)
+	}
+	return validatePlan(d, getValidator(d), is.NewUpdateParams(), nil, msgs...)
+}
+
+// validateIndexSetStrategies validates the consistency between the strategy classes and the strategies
+// and returns the error messages.
+func validateIndexSetStrategies(d *schema.ResourceDiff, is *graylog.IndexSet) []string {
+	msgs := []string{}
+	switch is.RotationStrategyClass {
+	case graylog.MessageCountRotationStrategy, graylog.SizeBasedRotationStrategy, graylog.TimeBasedRotationStrategy:
+		if isKnown(d, "rotation_strategy_class", "rotation_strategy.0.type") && is.RotationStrategy.Type != is.RotationStrategyClass+"Config" {
+			msgs = append(msgs, fmt.Sprintf(
+				"rotation_strategy.0.type: must be %sConfig for the rotation_strategy_class", is.RotationStrategyClass))
+		}
+	}
+	switch is.RotationStrategyClass {
+	case graylog.MessageCountRotationStrategy:
+		if isKnown(d, "rotation_strategy.0.max_docs_per_index") && is.RotationStrategy.MaxDocsPerIndex <= 0 {
+			msgs = append(msgs, "rotation_strategy.0.max_docs_per_index: must be greater than 0 for the rotation_strategy_class")
+		}
+	case graylog.SizeBasedRotationStrategy:
+		if isKnown(d, "rotation_strategy.0.max_size") && is.RotationStrategy.MaxSize <= 0 {
+			msgs = append(msgs, "rotation_strategy.0.max_size: must be greater than 0 for the rotation_strategy_class")
+		}
+	case graylog.TimeBasedRotationStrategy:
+		if isKnown(d, "rotation_strategy.0.rotation_period") && is.RotationStrategy.RotationPeriod == "" {
+			msgs = append(msgs, "rotation_strategy.0.rotation_period: is required for the rotation_strategy_class")
+		}
+	}
+	switch is.RetentionStrategyClass {
+	case graylog.DeletionRetentionStrategy, graylog.ClosingRetentionStrategy, graylog.NoopRetentionStrategy:
+		if isKnown(d, "retention_strategy_class", "retention_strategy.0.type") && is.RetentionStrategy.Type != is.RetentionStrategyClass+"Config" {
+			msgs = append(msgs, fmt.Sprintf(
+				"retention_strategy.0.type: must be %sConfig for the retention_strategy_class", is.RetentionStrategyClass))
+		}
+		if isKnown(d, "retention_strategy.0.max_number_of_indices") && is.RetentionStrategy.MaxNumberOfIndices <= 0 {
+			msgs = append(msgs, "retention_strategy.0.max_number_of_indices: must be greater than 0")
+		}
+	}
+	return msgs
+}
+
 func resourceIndexSetCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -11,8 +11,8 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 
 	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
 	"github.com/suzuki-shunsuke/go-graylog/testutil"
-	"github.com/suzuki-shunsuke/graylog-mock-server/mockserver"
 )
 
 func testDeleteIndexSet(
@@ -96,10 +96,12 @@ resource "graylog_index_set" "test" {
   rotation_strategy_class = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy"
   rotation_strategy {
     type = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig"
+    max_docs_per_index = 20000000
   }
   retention_strategy_class = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
   retention_strategy {
     type = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
+    max_number_of_indices = 20
   }
   index_analyzer = "standard"
   index_optimization_max_num_segments = 1
@@ -127,6 +129,12 @@ resource "graylog_index_set" "test" {
 					testUpdateIndexSet(ctx, cl, key, updateTitle),
 				),
 			},
+			{
+				ResourceName:      key,
+				ImportState:       true,
+				ImportStateId:     "prefix:" + prefix,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -2,9 +2,13 @@ package graylog
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/suzuki-shunsuke/go-set"
 
 	"github.com/suzuki-shunsuke/go-graylog"
 )
@@ -29,72 +33,130 @@ func resourceInput() *schema.Resource {
 			Optional: true,
 		}
 	}
-	return &schema.Resource{
-		Create: resourceInputCreate,
-		Read:   resourceInputRead,
-		Update: resourceInputUpdate,
-		Delete: resourceInputDelete,
-
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+	sc := map[string]*schema.Schema{
+		// required
+		"title": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Required: true,
 		},
 
-		Schema: map[string]*schema.Schema{
-			// required
-			"title": {
-				Type:     schema.TypeString,
-				Required: true,
+		// deprecated
+		// "attributes" has the attributes of all input types.
+		"attributes": {
+			Type:       schema.TypeList,
+			Optional:   true,
+			Deprecated: "use the typed attributes block of the input type (ex. gelf_udp) instead",
+			Elem: &schema.Resource{
+				Schema: cfgSchema,
 			},
-			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+			MaxItems: 1,
+			MinItems: 1,
+		},
+
+		// the attributes of the input types which don't have the typed attributes block
+		"general_string_attributes": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"attributes": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: cfgSchema,
-				},
-				MaxItems: 1,
-				MinItems: 1,
+		},
+		"general_int_attributes": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
 			},
-
-			"global": {
-				Type:     schema.TypeBool,
-				Optional: true,
+		},
+		"general_float_attributes": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeFloat,
 			},
-			"node": {
-				Type:     schema.TypeString,
-				Optional: true,
+		},
+		"general_bool_attributes": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeBool,
 			},
+		},
 
-			"created_at": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+		"global": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"node": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"created_at": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"creator_user_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"static_fields": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"creator_user_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+			// an empty map isn't saved in the state,
+			// so the empty static_fields is always planned as computed without this.
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return k == "static_fields.%" && (old == "" || old == "0") && (new == "" || new == "0")
 			},
-			"static_fields": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+		},
+		// "context_pack": &schema.Schema{
+		// 	Type:     schema.TypeString,
+		// 	Optional: true,
+		// },
+	}
+	// typed attributes blocks
+	for t, name := range inputAttrsBlockNames {
+		sc[name] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: newInputAttrsSchema(graylog.NewInputAttrsByType(t)),
 			},
-			// "context_pack": &schema.Schema{
-			// 	Type:     schema.TypeString,
-			// 	Optional: true,
-			// },
+		}
+	}
+	return &schema.Resource{
+		Create: resourceInputCreate,
+		Read:   resourceInputRead,
+		Update: resourceInputUpdate,
+		Delete: resourceInputDelete,
+
+		CustomizeDiff: resourceInputDiff,
+
+		Importer: &schema.ResourceImporter{
+			State: genNaturalKeyImport(inputImportTarget),
 		},
+
+		Schema: sc,
 	}
 }
 
-func newInput(d *schema.ResourceData) (*graylog.Input, error) {
+func newInput(d resourceData) (*graylog.Input, error) {
+	attrs, err := getInputAttrs(d)
+	if err != nil {
+		return nil, err
+	}
 	sf := d.Get("static_fields").(map[string]interface{})
 	staticFields := make(map[string]string, len(sf))
 	for k, v := range sf {
@@ -109,7 +171,7 @@ func newInput(d *schema.ResourceData) (*graylog.Input, error) {
 		ID:            d.Id(),
 		CreatorUserID: d.Get("creator_user_id").(string),
 		CreatedAt:     d.Get("created_at").(string),
-		Attrs:         d.Get("attributes").([]interface{})[0].(map[string]interface{}),
+		Attrs:         attrs,
 		StaticFields:  staticFields,
 	}
 	input := &graylog.Input{}
@@ -119,6 +181,90 @@ func newInput(d *schema.ResourceData) (*graylog.Input, error) {
 	return input, nil
 }
 
+func resourceInputDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !isKnown(d, "type") {
+		return nil
+	}
+	input, err := newInput(d)
+	if err != nil {
+		return newPlanError(append(validateInputAttrsBlocks(d, d.Get("type").(string)), err.Error()))
+	}
+	keys := map[string]string{}
+	if name, ok := inputAttrsBlockNames[input.Type()]; ok {
+		if _, ok := getBlock(d.Get(name)); ok {
+			keys["attributes"] = name
+		}
+	}
+	msgs := validateInputAttrs(d, input)
+	if d.Id() == "" {
+		return validatePlan(d, getValidator(d), input, keys, msgs...)
+	}
+	return validatePlan(d, getValidator(d), input.NewUpdateParams(), keys, msgs...)
+}
+
+// validateInputAttrs validates that only the attributes which the input type supports are set
+// and returns the error messages.
+func validateInputAttrs(d *schema.ResourceDiff, input *graylog.Input) []string {
+	t := input.Type()
+	msgs := validateInputAttrsBlocks(d, t)
+	own, isTyped := inputAttrsBlockNames[t]
+	_, isUnknown := input.Attrs.(*graylog.InputUnknownAttrs)
+	if !isUnknown {
+		for _, k := range []string{"bool", "float", "int", "string"} {
+			key := fmt.Sprintf("general_%s_attributes", k)
+			if len(d.Get(key).(map[string]interface{})) != 0 {
+				msgs = append(msgs, fmt.Sprintf(
+					"%s: is available only for the input types which the typed attributes block isn't provided", key))
+			}
+		}
+	}
+	attrs, ok := getBlock(d.Get("attributes"))
+	if !ok {
+		return msgs
+	}
+	if isTyped {
+		if _, ok := getBlock(d.Get(own)); ok {
+			return append(msgs, fmt.Sprintf("attributes: conflicts with %s", own))
+		}
+	}
+	rv := indirectValue(reflect.ValueOf(input.Attrs))
+	if isUnknown || !rv.IsValid() || rv.Kind() != reflect.Struct {
+		return msgs
+	}
+	fields := set.NewStrSet()
+	for i := 0; i < rv.NumField(); i++ {
+		fields.Add(strings.Split(rv.Type().Field(i).Tag.Get("json"), ",")[0])
+	}
+	keys := make([]string, 0, len(attrs))
+	for k, v := range attrs {
+		if fields.Has(k) {
+			continue
+		}
+		switch a := v.(type) {
+		case string:
+			if a == "" {
+				continue
+			}
+		case int:
+			if a == 0 {
+				continue
+			}
+		case bool:
+			if !a {
+				continue
+			}
+		case nil:
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		msgs = append(msgs, fmt.Sprintf("attributes.0.%s: isn't supported by the input type %s", k, t))
+	}
+	return msgs
+}
+
 func resourceInputCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -147,18 +293,8 @@ func resourceInputRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
-	if input.Attrs != nil {
-		b, err := json.Marshal(input.Attrs)
-		if err != nil {
-			return err
-		}
-		dest := map[string]interface{}{}
-		if err := json.Unmarshal(b, &dest); err != nil {
-			return err
-		}
-		if err := d.Set("attributes", []map[string]interface{}{dest}); err != nil {
-			return err
-		}
+	if err := setInputAttrs(d, input); err != nil {
+		return err
 	}
 	if err := setStrToRD(d, "title", input.Title); err != nil {
 		return err
@@ -208,3 +344,24 @@ func resourceInputDelete(d *schema.ResourceData, m interface{}) error {
 	}
 	return nil
 }
+
+// validateInputAttrsBlocks validates that the typed attributes blocks of the other input types aren't set
+// and returns the error messages.
+func validateInputAttrsBlocks(d *schema.ResourceDiff, t string) []string {
+	own := inputAttrsBlockNames[t]
+	names := make([]string, 0, len(inputAttrsBlockNames))
+	for _, name := range inputAttrsBlockNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := []string{}
+	for _, name := range names {
+		if name == own {
+			continue
+		}
+		if _, ok := getBlock(d.Get(name)); ok {
+			msgs = append(msgs, fmt.Sprintf("%s: isn't available for the input type %s", name, t))
+		}
+	}
+	return msgs
+}
@@ -14,7 +14,7 @@ func resourceInputStaticFields() *schema.Resource {
 		Delete: resourceInputStaticFieldsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(inputImportTarget, "input_id"),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1,103 +1,202 @@
 package graylog
 
-// import (
-// 	"fmt"
-// 	"os"
-// 	"testing"
-//
-// 	"github.com/hashicorp/terraform/helper/resource"
-// 	"github.com/hashicorp/terraform/terraform"
-//
-// 	"github.com/suzuki-shunsuke/go-graylog/client"
-// )
-//
-// var (
-// 	terraformTestInputID string
-// )
-//
-// func testDeleteInput(cl *client.Client, key string) resource.TestCheckFunc {
-// 	return func(tfState *terraform.State) error {
-// 		if _, _, err := cl.GetInput(terraformTestInputID); err == nil {
-// 			return fmt.Errorf(`input "%s" must be deleted`, terraformTestInputID)
-// 		}
-// 		return nil
-// 	}
-// }
-//
-// func testCreateInput(cl *client.Client, key string) resource.TestCheckFunc {
-// 	return func(tfState *terraform.State) error {
-// 		id, err := getIDFromTfState(tfState, key)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		terraformTestInputID = id
-//
-// 		_, _, err = cl.GetInput(id)
-// 		return err
-// 	}
-// }
-//
-// func testUpdateInput(cl *client.Client, key, title string) resource.TestCheckFunc {
-// 	return func(tfState *terraform.State) error {
-// 		id, err := getIDFromTfState(tfState, key)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		input, _, err := cl.GetInput(id)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		if input.Title != title {
-// 			return fmt.Errorf("input.Title == %s, wanted %s", input.Title, title)
-// 		}
-// 		return nil
-// 	}
-// }
-//
-// func TestAccInput(t *testing.T) {
-// 	cl, server, err := setEnv()
-// 	if err != nil {
-// 		t.Fatal(err)
-// 	}
-// 	if server != nil {
-// 		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
-// 	}
-//
-// 	testAccProvider := Provider()
-// 	testAccProviders := map[string]terraform.ResourceProvider{
-// 		"graylog": testAccProvider,
-// 	}
-//
-// 	roleTf := `
-// resource "graylog_input" "test" {
-//   title = "%s"
-//   type = "org.graylog2.inputs.syslog.udp.SyslogUDPInput"
-//   attributes {
-//     bind_address = "0.0.0.0"
-//     port = 514
-//     recv_buffer_size = 262144
-//   }
-// }`
-// 	createTitle := "terraform test input title"
-// 	updateTitle := "terraform test input title updated"
-//
-// 	key := "graylog_input.test"
-// 	if server != nil {
-// 		server.Start()
-// 		defer server.Close()
-// 	}
-// 	resource.Test(t, resource.TestCase{
-// 		Providers:    testAccProviders,
-// 		CheckDestroy: testDeleteInput(cl, key),
-// 		Steps: []resource.TestStep{
-// 			{
-// 				Config: fmt.Sprintf(roleTf, createTitle),
-// 				Check:  testCreateInput(cl, key),
-// 			},
-// 			{
-// 				Config: fmt.Sprintf(roleTf, updateTitle),
-// 				Check:  testUpdateInput(cl, key, updateTitle),
-// 			},
-// 		},
-// 	})
-// }
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func testDeleteInput(ctx context.Context, cl *client.Client, key string) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		if _, _, err := cl.GetInput(ctx, id); err == nil {
+			return fmt.Errorf(`input "%s" must be deleted`, id)
+		}
+		return nil
+	}
+}
+
+func testGELFUDPInput(
+	ctx context.Context, cl *client.Client, key, title string, port int,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		input, _, err := cl.GetInput(ctx, id)
+		if err != nil {
+			return err
+		}
+		if input.Title != title {
+			return fmt.Errorf("input.Title == %s, wanted %s", input.Title, title)
+		}
+		attrs, ok := input.Attrs.(*graylog.InputGELFUDPAttrs)
+		if !ok {
+			return fmt.Errorf("input.Attrs is %T, wanted *graylog.InputGELFUDPAttrs", input.Attrs)
+		}
+		if attrs.Port != port {
+			return fmt.Errorf("attrs.Port == %d, wanted %d", attrs.Port, port)
+		}
+		// default values
+		if attrs.BindAddress != "0.0.0.0" {
+			return fmt.Errorf(`attrs.BindAddress == "%s", wanted "0.0.0.0"`, attrs.BindAddress)
+		}
+		if attrs.RecvBufferSize != 262144 {
+			return fmt.Errorf("attrs.RecvBufferSize == %d, wanted 262144", attrs.RecvBufferSize)
+		}
+		return nil
+	}
+}
+
+func testUnknownTypeInput(
+	ctx context.Context, cl *client.Client, key string, port int,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		input, _, err := cl.GetInput(ctx, id)
+		if err != nil {
+			return err
+		}
+		attrs, ok := input.Attrs.(*graylog.InputUnknownAttrs)
+		if !ok {
+			return fmt.Errorf("input.Attrs is %T, wanted *graylog.InputUnknownAttrs", input.Attrs)
+		}
+		if attrs.Data["port"] != float64(port) {
+			return fmt.Errorf(`attrs.Data["port"] == %v, wanted %d`, attrs.Data["port"], port)
+		}
+		return nil
+	}
+}
+
+func TestAccInput(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	inputTf := `
+resource "graylog_input" "test" {
+  title = "%s"
+  type = "org.graylog2.inputs.gelf.udp.GELFUDPInput"
+  global = true
+  gelf_udp {
+    port = %d
+  }
+}`
+	createTitle := "terraform test input title"
+	updateTitle := "terraform test input title updated"
+
+	key := "graylog_input.test"
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteInput(ctx, cl, key),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(inputTf, createTitle, 12201),
+				Check:  testGELFUDPInput(ctx, cl, key, createTitle, 12201),
+			},
+			{
+				Config: fmt.Sprintf(inputTf, updateTitle, 12202),
+				Check:  testGELFUDPInput(ctx, cl, key, updateTitle, 12202),
+			},
+			{
+				ResourceName:      key,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccInputUnknownType(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	inputTf := `
+resource "graylog_input" "test" {
+  title = "terraform test unknown type input"
+  type = "com.example.graylog.inputs.ExampleInput"
+  global = true
+  general_string_attributes = {
+    bind_address = "0.0.0.0"
+  }
+  general_int_attributes = {
+    port = %d
+  }
+}`
+
+	// the typed attributes block of the other input type can't be used
+	invalidTf := `
+resource "graylog_input" "test" {
+  title = "terraform test input"
+  type = "org.graylog2.inputs.gelf.udp.GELFUDPInput"
+  global = true
+  syslog_tcp {
+    port = 514
+  }
+}`
+
+	key := "graylog_input.test"
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteInput(ctx, cl, key),
+		Steps: []resource.TestStep{
+			{
+				Config:      invalidTf,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`syslog_tcp: isn't available for the input type org.graylog2.inputs.gelf.udp.GELFUDPInput`),
+			},
+			{
+				Config: fmt.Sprintf(inputTf, 5000),
+				Check:  testUnknownTypeInput(ctx, cl, key, 5000),
+			},
+			{
+				Config: fmt.Sprintf(inputTf, 5001),
+				Check:  testUnknownTypeInput(ctx, cl, key, 5001),
+			},
+		},
+	})
+}
@@ -0,0 +1,216 @@
+package graylog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// ldapGroupRoleMappingAllID is the resource id of graylog_ldap_group_role_mapping
+// which manages the whole LDAP group to role mapping.
+const ldapGroupRoleMappingAllID = "*"
+
+// ldapGroupRoleMappingMutex serializes the updates of the mapping,
+// because Graylog API can update only the whole mapping and
+// terraform creates the resources in parallel.
+var ldapGroupRoleMappingMutex sync.Mutex
+
+func resourceLDAPGroupRoleMapping() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLDAPGroupRoleMappingCreate,
+		Read:   resourceLDAPGroupRoleMappingRead,
+		Update: resourceLDAPGroupRoleMappingUpdate,
+		Delete: resourceLDAPGroupRoleMappingDelete,
+
+		CustomizeDiff: resourceLDAPGroupRoleMappingDiff,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceLDAPGroupRoleMappingImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Optional
+			// Either "group" and "role" or "mapping" is required.
+			"group": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"mapping"},
+			},
+			"role": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"mapping"},
+			},
+			"mapping": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"group", "role"},
+			},
+		},
+	}
+}
+
+// resourceLDAPGroupRoleMappingDiff validates at plan that exactly one of "group" and "mapping" is set,
+// because the resource without both of them would remove the whole mapping.
+func resourceLDAPGroupRoleMappingDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !isKnown(d, "group", "role", "mapping") {
+		return nil
+	}
+	_, hasGroup := d.GetOk("group")
+	_, hasMapping := d.GetOk("mapping")
+	if hasGroup == hasMapping {
+		return errors.New(`exactly one of "group" and "mapping" must be set`)
+	}
+	if _, ok := d.GetOk("role"); hasGroup && !ok {
+		return errors.New(`"role" is required when "group" is set`)
+	}
+	return nil
+}
+
+// isLDAPGroupRoleMappingAll returns true if the resource manages the whole mapping.
+func isLDAPGroupRoleMappingAll(d *schema.ResourceData) bool {
+	_, ok := d.GetOk("group")
+	return !ok
+}
+
+func getLDAPGroupRoleMapping(d *schema.ResourceData) (map[string]string, error) {
+	mapping := map[string]string{}
+	for k, v := range d.Get("mapping").(map[string]interface{}) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("mapping's value must be string")
+		}
+		mapping[k] = s
+	}
+	return mapping, nil
+}
+
+func resourceLDAPGroupRoleMappingImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != ldapGroupRoleMappingAllID {
+		if err := setStrToRD(d, "group", d.Id()); err != nil {
+			return nil, err
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceLDAPGroupRoleMappingCreate(d *schema.ResourceData, m interface{}) error {
+	ldapGroupRoleMappingMutex.Lock()
+	defer ldapGroupRoleMappingMutex.Unlock()
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if isLDAPGroupRoleMappingAll(d) {
+		mapping, err := getLDAPGroupRoleMapping(d)
+		if err != nil {
+			return err
+		}
+		if _, err := cl.UpdateLDAPGroupRoleMapping(ctx, mapping); err != nil {
+			return err
+		}
+		d.SetId(ldapGroupRoleMappingAllID)
+		return nil
+	}
+	group := d.Get("group").(string)
+	role, ok := d.GetOk("role")
+	if !ok {
+		return fmt.Errorf(`"role" is required when "group" is set`)
+	}
+	mapping, _, err := cl.GetLDAPGroupRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	if r, ok := mapping[group]; ok {
+		return fmt.Errorf(`the LDAP group "%s" is already mapped to the role "%s"`, group, r)
+	}
+	mapping[group] = role.(string)
+	if _, err := cl.UpdateLDAPGroupRoleMapping(ctx, mapping); err != nil {
+		return err
+	}
+	d.SetId(group)
+	return nil
+}
+
+func resourceLDAPGroupRoleMappingRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	mapping, _, err := cl.GetLDAPGroupRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	if d.Id() == ldapGroupRoleMappingAllID {
+		return setMapStrToStrToRD(d, "mapping", mapping)
+	}
+	role, ok := mapping[d.Id()]
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+	if err := setStrToRD(d, "group", d.Id()); err != nil {
+		return err
+	}
+	return setStrToRD(d, "role", role)
+}
+
+func resourceLDAPGroupRoleMappingUpdate(d *schema.ResourceData, m interface{}) error {
+	ldapGroupRoleMappingMutex.Lock()
+	defer ldapGroupRoleMappingMutex.Unlock()
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if d.Id() == ldapGroupRoleMappingAllID {
+		mapping, err := getLDAPGroupRoleMapping(d)
+		if err != nil {
+			return err
+		}
+		_, err = cl.UpdateLDAPGroupRoleMapping(ctx, mapping)
+		return err
+	}
+	mapping, _, err := cl.GetLDAPGroupRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	mapping[d.Id()] = d.Get("role").(string)
+	_, err = cl.UpdateLDAPGroupRoleMapping(ctx, mapping)
+	return err
+}
+
+func resourceLDAPGroupRoleMappingDelete(d *schema.ResourceData, m interface{}) error {
+	ldapGroupRoleMappingMutex.Lock()
+	defer ldapGroupRoleMappingMutex.Unlock()
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	mapping, _, err := cl.GetLDAPGroupRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	if d.Id() == ldapGroupRoleMappingAllID {
+		// remove only the groups which this resource manages
+		managed, err := getLDAPGroupRoleMapping(d)
+		if err != nil {
+			return err
+		}
+		for k := range managed {
+			delete(mapping, k)
+		}
+	} else {
+		delete(mapping, d.Id())
+	}
+	_, err = cl.UpdateLDAPGroupRoleMapping(ctx, mapping)
+	return err
+}
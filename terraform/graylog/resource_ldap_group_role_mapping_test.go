@@ -0,0 +1,116 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func testLDAPGroupRoleMapping(
+	ctx context.Context, cl *client.Client, exp map[string]string,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		mapping, _, err := cl.GetLDAPGroupRoleMapping(ctx)
+		if err != nil {
+			return err
+		}
+		if len(mapping) != len(exp) {
+			return fmt.Errorf("mapping = %v, wanted %v", mapping, exp)
+		}
+		for k, v := range exp {
+			if mapping[k] != v {
+				return fmt.Errorf("mapping = %v, wanted %v", mapping, exp)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccLDAPGroupRoleMapping(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	groupTf := `
+resource "graylog_ldap_group_role_mapping" "foo" {
+  group = "foo"
+  role = "Reader"
+}
+
+resource "graylog_ldap_group_role_mapping" "bar" {
+  group = "bar"
+  role = "Reader"
+}`
+	updateGroupTf := `
+resource "graylog_ldap_group_role_mapping" "foo" {
+  group = "foo"
+  role = "Admin"
+}`
+	mappingTf := `
+resource "graylog_ldap_group_role_mapping" "all" {
+  mapping = {
+    foo = "Reader"
+    zoo = "Admin"
+  }
+}`
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testLDAPGroupRoleMapping(ctx, cl, map[string]string{}),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "graylog_ldap_group_role_mapping" "foo" {}`,
+				ExpectError: regexp.MustCompile(`exactly one of "group" and "mapping" must be set`),
+			},
+			{
+				Config: `
+resource "graylog_ldap_group_role_mapping" "foo" {
+  group = "foo"
+}`,
+				ExpectError: regexp.MustCompile(`"role" is required when "group" is set`),
+			},
+			{
+				Config: groupTf,
+				Check: resource.ComposeTestCheckFunc(
+					testLDAPGroupRoleMapping(ctx, cl, map[string]string{
+						"foo": "Reader", "bar": "Reader"}),
+				),
+			},
+			{
+				Config: updateGroupTf,
+				Check: resource.ComposeTestCheckFunc(
+					testLDAPGroupRoleMapping(ctx, cl, map[string]string{
+						"foo": "Admin"}),
+				),
+			},
+			{
+				Config: mappingTf,
+				Check: resource.ComposeTestCheckFunc(
+					testLDAPGroupRoleMapping(ctx, cl, map[string]string{
+						"foo": "Reader", "zoo": "Admin"}),
+				),
+			},
+		},
+	})
+}
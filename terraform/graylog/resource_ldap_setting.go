@@ -8,6 +8,7 @@ import (
 	"github.com/suzuki-shunsuke/go-set"
 
 	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
 )
 
 const (
@@ -21,6 +22,8 @@ func resourceLDAPSetting() *schema.Resource {
 		Update: resourceLDAPSettingUpdate,
 		Delete: resourceLDAPSettingDelete,
 
+		CustomizeDiff: resourceLDAPSettingDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -101,7 +104,7 @@ func resourceLDAPSetting() *schema.Resource {
 	}
 }
 
-func newLDAPSetting(d *schema.ResourceData) (*graylog.LDAPSetting, error) {
+func newLDAPSetting(d resourceData) (*graylog.LDAPSetting, error) {
 	setting := &graylog.LDAPSetting{
 		Enabled:                 d.Get("enabled").(bool),
 		UseStartTLS:             d.Get("use_start_tls").(bool),
@@ -131,6 +134,15 @@ func newLDAPSetting(d *schema.ResourceData) (*graylog.LDAPSetting, error) {
 	return setting, nil
 }
 
+func resourceLDAPSettingDiff(d *schema.ResourceDiff, m interface{}) error {
+	setting, err := newLDAPSetting(d)
+	if err != nil {
+		return err
+	}
+	// LDAP setting is always updated
+	return validatePlan(d, validator.UpdateValidator, setting, nil)
+}
+
 func resourceLDAPSettingCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -16,30 +16,109 @@ func resourcePipeline() *schema.Resource {
 		Update: resourcePipelineUpdate,
 		Delete: resourcePipelineDelete,
 
+		CustomizeDiff: resourcePipelineDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(pipelineImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
-			// Required
+			// Optional
+			// Either source or stage is required.
 			"source": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"stage", "title"},
+				DiffSuppressFunc: suppressPipelineSourceDiff,
+			},
+			"stage": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"source"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"stage": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"match_all": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						// the titles of the pipeline rules
+						"rules": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
 			},
-
-			// Optional
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-
-			// We don't define the attribute "title",
+			// title is used to generate the source from stage.
+			// If source is set, title is parsed from the source
 			// because the request parameter "title" is ignored in create and update API.
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func newPipeline(d *schema.ResourceData) *graylog.Pipeline {
+// suppressPipelineSourceDiff compares the pipeline sources semantically,
+// so the differences of whitespace and the order of stages and rules are ignored.
+func suppressPipelineSourceDiff(k, old, new string, d *schema.ResourceData) bool {
+	return isSamePipelineSource(old, new)
+}
+
+func isSamePipelineSource(a, b string) bool {
+	if a == b {
+		return true
+	}
+	aTitle, aStages, err := graylog.ParsePipelineSource(a)
+	if err != nil {
+		return false
+	}
+	bTitle, bStages, err := graylog.ParsePipelineSource(b)
+	if err != nil {
+		return false
+	}
+	return graylog.NewPipelineSource(aTitle, aStages) == graylog.NewPipelineSource(bTitle, bStages)
+}
+
+func newPipelineStages(set *schema.Set) []graylog.PipelineStage {
+	arr := set.List()
+	stages := make([]graylog.PipelineStage, len(arr))
+	for i, a := range arr {
+		stage := a.(map[string]interface{})
+		stages[i] = graylog.PipelineStage{
+			Stage:    stage["stage"].(int),
+			MatchAll: stage["match_all"].(bool),
+			Rules:    getStringArray(stage["rules"].(*schema.Set).List()),
+		}
+	}
+	return stages
+}
+
+func flattenPipelineStages(stages []graylog.PipelineStage) []map[string]interface{} {
+	arr := make([]map[string]interface{}, len(stages))
+	for i, stage := range stages {
+		arr[i] = map[string]interface{}{
+			"stage":     stage.Stage,
+			"match_all": stage.MatchAll,
+			"rules":     stage.Rules,
+		}
+	}
+	return arr
+}
+
+func newPipeline(d resourceData) *graylog.Pipeline {
 	return &graylog.Pipeline{
 		ID:          d.Id(),
 		Source:      d.Get("source").(string),
@@ -47,6 +126,39 @@ func newPipeline(d *schema.ResourceData) *graylog.Pipeline {
 	}
 }
 
+func resourcePipelineDiff(d *schema.ResourceDiff, m interface{}) error {
+	msgs := []string{}
+	stages := d.Get("stage").(*schema.Set)
+	switch {
+	case stages.Len() == 0:
+		// title is parsed from source
+		if !isKnown(d, "source") {
+			break
+		}
+		title, _, err := graylog.ParsePipelineSource(d.Get("source").(string))
+		if err == nil && title != d.Get("title").(string) {
+			if err := d.SetNew("title", title); err != nil {
+				return err
+			}
+		}
+	case !isKnown(d, "title", "stage"):
+		if err := d.SetNewComputed("source"); err != nil {
+			return err
+		}
+	case d.Get("title").(string) == "":
+		msgs = append(msgs, "title: is required if stage is set")
+	default:
+		// generate the source from stage
+		src := graylog.NewPipelineSource(d.Get("title").(string), newPipelineStages(stages))
+		if !isSamePipelineSource(d.Get("source").(string), src) {
+			if err := d.SetNew("source", src); err != nil {
+				return err
+			}
+		}
+	}
+	return validatePlan(d, getValidator(d), newPipeline(d), nil, msgs...)
+}
+
 func resourcePipelineCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -77,6 +189,14 @@ func resourcePipelineRead(d *schema.ResourceData, m interface{}) error {
 	if err := setStrToRD(d, "source", pipe.Source); err != nil {
 		return err
 	}
+	if err := setStrToRD(d, "title", pipe.Title); err != nil {
+		return err
+	}
+	if d.Get("stage").(*schema.Set).Len() != 0 {
+		if err := d.Set("stage", flattenPipelineStages(pipe.Stages)); err != nil {
+			return err
+		}
+	}
 	return setStrToRD(d, "description", pipe.Description)
 }
 
@@ -16,7 +16,7 @@ func resourcePipelineConnection() *schema.Resource {
 		Delete: resourcePipelineConnectionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(streamImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -16,8 +16,10 @@ func resourcePipelineRule() *schema.Resource {
 		Update: resourcePipelineRuleUpdate,
 		Delete: resourcePipelineRuleDelete,
 
+		CustomizeDiff: resourcePipelineRuleDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(pipelineRuleImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -32,13 +34,20 @@ func resourcePipelineRule() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			// We don't define the attribute "title",
+
+			// Computed
+			// title is parsed from source at the plan,
 			// because the request parameter "title" is ignored in create and update API.
+			// It can be referred from the stage of graylog_pipeline.
+			"title": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func newPipelineRule(d *schema.ResourceData) *graylog.PipelineRule {
+func newPipelineRule(d resourceData) *graylog.PipelineRule {
 	return &graylog.PipelineRule{
 		ID:          d.Id(),
 		Source:      d.Get("source").(string),
@@ -46,6 +55,18 @@ func newPipelineRule(d *schema.ResourceData) *graylog.PipelineRule {
 	}
 }
 
+func resourcePipelineRuleDiff(d *schema.ResourceDiff, m interface{}) error {
+	if isKnown(d, "source") {
+		title, err := graylog.ParsePipelineRuleSource(d.Get("source").(string))
+		if err == nil && title != d.Get("title").(string) {
+			if err := d.SetNew("title", title); err != nil {
+				return err
+			}
+		}
+	}
+	return validatePlan(d, getValidator(d), newPipelineRule(d), nil)
+}
+
 func resourcePipelineRuleCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -76,6 +97,9 @@ func resourcePipelineRuleRead(d *schema.ResourceData, m interface{}) error {
 	if err := setStrToRD(d, "source", rule.Source); err != nil {
 		return err
 	}
+	if err := setStrToRD(d, "title", rule.Title); err != nil {
+		return err
+	}
 	return setStrToRD(d, "description", rule.Description)
 }
 
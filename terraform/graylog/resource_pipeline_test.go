@@ -0,0 +1,162 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func testDeletePipeline(ctx context.Context, cl *client.Client, key string) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		if _, _, err := cl.GetPipeline(ctx, id); err == nil {
+			return fmt.Errorf(`pipeline "%s" must be deleted`, id)
+		}
+		return nil
+	}
+}
+
+func testPipelineStages(
+	ctx context.Context, cl *client.Client, key, title string, stages []graylog.PipelineStage,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		pipe, _, err := cl.GetPipeline(ctx, id)
+		if err != nil {
+			return err
+		}
+		if pipe.Title != title {
+			return fmt.Errorf(`pipe.Title == "%s", wanted "%s"`, pipe.Title, title)
+		}
+		if !reflect.DeepEqual(pipe.Stages, stages) {
+			return fmt.Errorf("pipe.Stages == %+v, wanted %+v", pipe.Stages, stages)
+		}
+		return nil
+	}
+}
+
+func TestAccPipeline(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	ruleTf := `
+resource "graylog_pipeline_rule" "foo" {
+  source = <<EOF
+rule "foo"
+when
+  has_field("foo")
+then
+end
+EOF
+}
+
+resource "graylog_pipeline_rule" "bar" {
+  source = <<EOF
+rule "bar"
+when
+  has_field("bar")
+then
+end
+EOF
+}
+`
+	stageTf := ruleTf + `
+resource "graylog_pipeline" "test" {
+  title = "terraform test pipeline"
+  description = "test"
+
+  stage {
+    stage = 0
+    rules = [graylog_pipeline_rule.foo.title, graylog_pipeline_rule.bar.title]
+  }
+  stage {
+    stage = 1
+    match_all = true
+    rules = [graylog_pipeline_rule.bar.title]
+  }
+}`
+	// the order of stages and rules is different
+	reorderedTf := ruleTf + `
+resource "graylog_pipeline" "test" {
+  title = "terraform test pipeline"
+  description = "test"
+
+  stage {
+    stage = 1
+    match_all = true
+    rules = [graylog_pipeline_rule.bar.title]
+  }
+  stage {
+    stage = 0
+    rules = [graylog_pipeline_rule.bar.title, graylog_pipeline_rule.foo.title]
+  }
+}`
+	sourceTf := `
+resource "graylog_pipeline" "test" {
+  description = "test"
+  source = <<EOF
+pipeline "terraform test pipeline updated"
+%s
+end
+EOF
+}`
+
+	key := "graylog_pipeline.test"
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeletePipeline(ctx, cl, key),
+		Steps: []resource.TestStep{
+			{
+				Config: stageTf,
+				Check: testPipelineStages(ctx, cl, key, "terraform test pipeline", []graylog.PipelineStage{
+					{Stage: 0, Rules: []string{"bar", "foo"}},
+					{Stage: 1, MatchAll: true, Rules: []string{"bar"}},
+				}),
+			},
+			{
+				Config:   reorderedTf,
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(sourceTf, "stage 0 match either\n  rule \"foo\";"),
+				Check: testPipelineStages(ctx, cl, key, "terraform test pipeline updated", []graylog.PipelineStage{
+					{Stage: 0, Rules: []string{"foo"}},
+				}),
+			},
+			{
+				// the difference of whitespace is ignored
+				Config:   fmt.Sprintf(sourceTf, "stage   0  match either\n\n    rule \"foo\""),
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -16,8 +16,10 @@ func resourceRole() *schema.Resource {
 		Update: resourceRoleUpdate,
 		Delete: resourceRoleDelete,
 
+		CustomizeDiff: resourceRoleDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(roleImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,7 +45,7 @@ func resourceRole() *schema.Resource {
 	}
 }
 
-func newRole(d *schema.ResourceData) *graylog.Role {
+func newRole(d resourceData) *graylog.Role {
 	return &graylog.Role{
 		Name:        d.Get("name").(string),
 		Permissions: set.NewStrSet(getStringArray(d.Get("permissions").(*schema.Set).List())...),
@@ -52,6 +54,14 @@ func newRole(d *schema.ResourceData) *graylog.Role {
 	}
 }
 
+func resourceRoleDiff(d *schema.ResourceDiff, m interface{}) error {
+	role := newRole(d)
+	if d.Id() == "" {
+		return validatePlan(d, getValidator(d), role, nil)
+	}
+	return validatePlan(d, getValidator(d), role.NewUpdateParams(), nil)
+}
+
 func resourceRoleCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
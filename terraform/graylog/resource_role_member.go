@@ -0,0 +1,104 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRoleMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRoleMemberCreate,
+		Read:   resourceRoleMemberRead,
+		Delete: resourceRoleMemberDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"role_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+// parseRoleMemberID parses the resource id "<role name>/<user name>".
+func parseRoleMemberID(id string) (string, string, error) {
+	a := strings.SplitN(id, "/", 2)
+	if len(a) != 2 || a[0] == "" || a[1] == "" {
+		return "", "", fmt.Errorf("format of the id should be <role name>/<user name>: %s", id)
+	}
+	return a[0], a[1], nil
+}
+
+func resourceRoleMemberCreate(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	roleName := d.Get("role_name").(string)
+	userName := d.Get("username").(string)
+	if _, err := cl.AddUserToRole(ctx, userName, roleName); err != nil {
+		return err
+	}
+	d.SetId(roleName + "/" + userName)
+	return nil
+}
+
+func resourceRoleMemberRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	roleName, userName, err := parseRoleMemberID(d.Id())
+	if err != nil {
+		return err
+	}
+	users, ei, err := cl.GetRoleMembers(ctx, roleName)
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	for _, user := range users {
+		if user.Username != userName {
+			continue
+		}
+		if err := setStrToRD(d, "role_name", roleName); err != nil {
+			return err
+		}
+		return setStrToRD(d, "username", userName)
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceRoleMemberDelete(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	roleName, userName, err := parseRoleMemberID(d.Id())
+	if err != nil {
+		return err
+	}
+	if ei, err := cl.RemoveUserFromRole(ctx, userName, roleName); err != nil {
+		if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func testRoleMember(
+	ctx context.Context, cl *client.Client, userName, roleName string, exp bool,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		user, _, err := cl.GetUser(ctx, userName)
+		if err != nil {
+			return err
+		}
+		if user.Roles.Has(roleName) != exp {
+			return fmt.Errorf(`user.Roles.Has("%s") = %t, wanted %t`, roleName, !exp, exp)
+		}
+		return nil
+	}
+}
+
+func TestAccRoleMember(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	userName := "test-terraform-role-member"
+	roleName := "test terraform role member"
+	baseTf := fmt.Sprintf(`
+resource "graylog_user" "test-terraform" {
+  username = "%s"
+  password = "password"
+  email = "foo@example.com"
+  full_name = "foo"
+  permissions = ["users:read:%s"]
+}
+
+resource "graylog_role" "test-terraform" {
+  name = "%s"
+  permissions = ["streams:read"]
+}`, userName, userName, roleName)
+	memberTf := baseTf + `
+
+resource "graylog_role_member" "test-terraform" {
+  role_name = graylog_role.test-terraform.name
+  username = graylog_user.test-terraform.username
+}`
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteUser(ctx, cl, userName),
+		Steps: []resource.TestStep{
+			{
+				Config: memberTf,
+				Check: resource.ComposeTestCheckFunc(
+					testRoleMember(ctx, cl, userName, roleName, true),
+				),
+			},
+			{
+				Config: baseTf,
+				Check: resource.ComposeTestCheckFunc(
+					testRoleMember(ctx, cl, userName, roleName, false),
+				),
+			},
+		},
+	})
+}
@@ -14,8 +14,10 @@ func resourceStream() *schema.Resource {
 		Update: resourceStreamUpdate,
 		Delete: resourceStreamDelete,
 
+		CustomizeDiff: resourceStreamDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(streamImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -31,7 +33,7 @@ func resourceStream() *schema.Resource {
 			},
 
 			// Optional
-			// rules
+			"rule": streamInlineRuleSchema(),
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -72,7 +74,7 @@ func resourceStream() *schema.Resource {
 	}
 }
 
-func newStream(d *schema.ResourceData) (*graylog.Stream, error) {
+func newStream(d resourceData) (*graylog.Stream, error) {
 	return &graylog.Stream{
 		IndexSetID:   d.Get("index_set_id").(string),
 		Title:        d.Get("title").(string),
@@ -84,6 +86,26 @@ func newStream(d *schema.ResourceData) (*graylog.Stream, error) {
 	}, nil
 }
 
+func resourceStreamDiff(d *schema.ResourceDiff, m interface{}) error {
+	ctx := context.Background()
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	stream, err := newStream(d)
+	if err != nil {
+		return err
+	}
+	msgs, err := validateStreamInlineRules(ctx, cl, d)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return validatePlan(d, getValidator(d), stream, nil, msgs...)
+	}
+	return validatePlan(d, getValidator(d), stream.NewUpdateParams(), nil, msgs...)
+}
+
 func resourceStreamCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -99,6 +121,11 @@ func resourceStreamCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	d.SetId(stream.ID)
+	if d.Get("rule").(*schema.Set).Len() != 0 {
+		if err := applyStreamInlineRules(ctx, cl, d); err != nil {
+			return err
+		}
+	}
 	// resume if needed
 	disabled := d.Get("disabled").(bool)
 	if !disabled {
@@ -119,7 +146,10 @@ func resourceStreamRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
-	return setStream(d, stream, m.(*Config))
+	if err := setStream(d, stream, m.(*Config)); err != nil {
+		return err
+	}
+	return setStreamInlineRules(ctx, cl, d)
 
 	// alert_receivers
 	// alert_conditions
@@ -138,6 +168,20 @@ func resourceStreamUpdate(d *schema.ResourceData, m interface{}) error {
 	if _, err := cl.UpdateStream(ctx, stream); err != nil {
 		return err
 	}
+	if d.HasChange("rule") {
+		if err := applyStreamInlineRules(ctx, cl, d); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("disabled") {
+		if d.Get("disabled").(bool) {
+			if _, err := cl.PauseStream(ctx, stream.ID); err != nil {
+				return err
+			}
+		} else if _, err := cl.ResumeStream(ctx, stream.ID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -15,8 +15,10 @@ func resourceStreamRule() *schema.Resource {
 		Update: resourceStreamRuleUpdate,
 		Delete: resourceStreamRuleDelete,
 
+		CustomizeDiff: resourceStreamRuleDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: genImport("stream_id", "stream_rule_id"),
+			State: genNestedImport("graylog_stream_rule", "stream_id", "stream_rule_id", streamImportTarget, streamRuleImportTarget, resourceStreamRule),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -52,7 +54,7 @@ func resourceStreamRule() *schema.Resource {
 	}
 }
 
-func newStreamRule(d *schema.ResourceData) (*graylog.StreamRule, error) {
+func newStreamRule(d resourceData) (*graylog.StreamRule, error) {
 	return &graylog.StreamRule{
 		StreamID:    d.Get("stream_id").(string),
 		Field:       d.Get("field").(string),
@@ -64,6 +66,17 @@ func newStreamRule(d *schema.ResourceData) (*graylog.StreamRule, error) {
 	}, nil
 }
 
+func resourceStreamRuleDiff(d *schema.ResourceDiff, m interface{}) error {
+	rule, err := newStreamRule(d)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return validatePlan(d, getValidator(d), rule, nil)
+	}
+	return validatePlan(d, getValidator(d), rule.NewUpdateParams(), nil)
+}
+
 func resourceStreamRuleCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
@@ -4,13 +4,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/suzuki-shunsuke/go-graylog"
 	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
 	"github.com/suzuki-shunsuke/go-graylog/testutil"
-	"github.com/suzuki-shunsuke/graylog-mock-server/mockserver"
 )
 
 func testDeleteStreamRule(
@@ -76,6 +78,23 @@ func testUpdateStreamRule(
 	}
 }
 
+func testImportedStreamRules(size int) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != size {
+			return fmt.Errorf("%d stream rules are imported, wanted %d", len(states), size)
+		}
+		for _, state := range states {
+			if state.Ephemeral.Type != "graylog_stream_rule" {
+				return fmt.Errorf(`the type of the imported resource is "%s", wanted "graylog_stream_rule"`, state.Ephemeral.Type)
+			}
+			if state.Attributes["stream_id"] == "" {
+				return fmt.Errorf(`stream_id of the stream rule "%s" is empty`, state.ID)
+			}
+		}
+		return nil
+	}
+}
+
 func TestAccStreamRule(t *testing.T) {
 	ctx := context.Background()
 	cl, server, err := setEnv()
@@ -101,10 +120,12 @@ resource "graylog_index_set" "test" {
   rotation_strategy_class = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy"
   rotation_strategy {
     type = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig"
+    max_docs_per_index = 20000000
   }
   retention_strategy_class = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
   retention_strategy {
     type = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
+    max_number_of_indices = 20
   }
   index_analyzer = "standard"
 	writable = true
@@ -148,6 +169,42 @@ resource "graylog_stream_rule" "test" {
 					testUpdateStreamRule(ctx, cl, key, updateDesc),
 				),
 			},
+			{
+				ResourceName:      key,
+				ImportState:       true,
+				ImportStateId:     "stream:stream test/field:tag",
+				ImportStateVerify: true,
+			},
+			{
+				// the natural key must match only one resource
+				PreConfig: func() {
+					streams, _, _, err := cl.GetStreams(ctx)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, stream := range streams {
+						if stream.Title != "stream test" {
+							continue
+						}
+						if _, err := cl.CreateStreamRule(ctx, &graylog.StreamRule{
+							StreamID: stream.ID, Field: "tag", Value: "foo", Type: 1,
+						}); err != nil {
+							t.Fatal(err)
+						}
+					}
+				},
+				ResourceName:  key,
+				ImportState:   true,
+				ImportStateId: "stream:stream test/field:tag",
+				ExpectError:   regexp.MustCompile("isn't unique"),
+			},
+			{
+				// import all stream rules of the stream
+				ResourceName:     key,
+				ImportState:      true,
+				ImportStateId:    "stream:stream test/*",
+				ImportStateCheck: testImportedStreamRules(2),
+			},
 		},
 	})
 }
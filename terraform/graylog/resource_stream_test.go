@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
+	"sort"
 	"testing"
 
 	"github.com/gofrs/uuid"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/suzuki-shunsuke/go-graylog"
 	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
 	"github.com/suzuki-shunsuke/go-graylog/testutil"
-	"github.com/suzuki-shunsuke/graylog-mock-server/mockserver"
 )
 
 func testDeleteStream(
@@ -63,6 +67,53 @@ func testUpdateStream(
 	}
 }
 
+func testStreamDisabled(
+	ctx context.Context, cl *client.Client, key string, disabled bool,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		stream, _, err := cl.GetStream(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stream.Disabled != disabled {
+			return fmt.Errorf("stream.Disabled == %t, wanted %t", stream.Disabled, disabled)
+		}
+		return nil
+	}
+}
+
+// testStreamRules checks the stream rules.
+// Each rule is represented as "<field> <type> <value>".
+func testStreamRules(
+	ctx context.Context, cl *client.Client, key string, streamID *string, want ...string,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		id, err := getIDFromTfState(tfState, key)
+		if err != nil {
+			return err
+		}
+		*streamID = id
+		rules, _, _, err := cl.GetStreamRules(ctx, id)
+		if err != nil {
+			return err
+		}
+		got := make([]string, len(rules))
+		for i, rule := range rules {
+			got[i] = fmt.Sprintf("%s %d %s", rule.Field, rule.Type, rule.Value)
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if len(got) != len(want) || (len(got) != 0 && !reflect.DeepEqual(got, want)) {
+			return fmt.Errorf("stream rules == %v, wanted %v", got, want)
+		}
+		return nil
+	}
+}
+
 func TestAccStream(t *testing.T) {
 	ctx := context.Background()
 	cl, server, err := setEnv()
@@ -93,10 +144,12 @@ resource "graylog_index_set" "test" {
   rotation_strategy_class = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy"
   rotation_strategy {
     type = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig"
+    max_docs_per_index = 20000000
   }
   retention_strategy_class = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
   retention_strategy {
     type = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
+    max_number_of_indices = 20
   }
   index_analyzer = "standard"
 	writable = true
@@ -107,6 +160,7 @@ resource "graylog_stream" "test" {
   title = "%s"
 	index_set_id = "${graylog_index_set.test.id}"
 	matching_type = "AND"
+	disabled = %t
 }`
 	createTitle := "terraform stream test"
 	updateTitle := "terraform stream test updated"
@@ -121,17 +175,166 @@ resource "graylog_stream" "test" {
 		CheckDestroy: testDeleteStream(ctx, cl, key),
 		Steps: []resource.TestStep{
 			{
-				Config: fmt.Sprintf(roleTf, prefix, createTitle),
+				Config: fmt.Sprintf(roleTf, prefix, createTitle, false),
 				Check: resource.ComposeTestCheckFunc(
 					testCreateStream(ctx, cl, server, key),
+					testStreamDisabled(ctx, cl, key, false),
 				),
 			},
 			{
-				Config: fmt.Sprintf(roleTf, prefix, updateTitle),
+				Config: fmt.Sprintf(roleTf, prefix, updateTitle, false),
 				Check: resource.ComposeTestCheckFunc(
 					testUpdateStream(ctx, cl, key, updateTitle),
 				),
 			},
+			{
+				Config: fmt.Sprintf(roleTf, prefix, updateTitle, true),
+				Check: resource.ComposeTestCheckFunc(
+					testStreamDisabled(ctx, cl, key, true),
+				),
+			},
+			{
+				Config: fmt.Sprintf(roleTf, prefix, updateTitle, false),
+				Check: resource.ComposeTestCheckFunc(
+					testStreamDisabled(ctx, cl, key, false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStreamInlineRules(t *testing.T) {
+	ctx := context.Background()
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	u, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexSetTf := `
+resource "graylog_index_set" "test" {
+  title = "terraform test index set"
+  index_prefix = "%s"
+  shards = 4
+  replicas = 0
+  rotation_strategy_class = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy"
+  rotation_strategy {
+    type = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig"
+    max_docs_per_index = 20000000
+  }
+  retention_strategy_class = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
+  retention_strategy {
+    type = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
+    max_number_of_indices = 20
+  }
+  index_analyzer = "standard"
+  writable = true
+  index_optimization_max_num_segments = 1
+}
+`
+	noRuleTf := indexSetTf + `
+resource "graylog_stream" "test" {
+  title = "terraform stream inline rules test"
+  index_set_id = graylog_index_set.test.id
+  matching_type = "AND"
+}`
+	streamTf := indexSetTf + `
+resource "graylog_stream" "test" {
+  title = "terraform stream inline rules test"
+  index_set_id = graylog_index_set.test.id
+  matching_type = "AND"
+
+  rule {
+    field = "status"
+    value = "%s"
+  }
+  rule {
+    field = "source"
+    value = "^web"
+    type = "%s"
+  }
+}`
+	prefix := u.String()
+
+	key := "graylog_stream.test"
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	streamID := ""
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteStream(ctx, cl, key),
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(streamTf, prefix, "500", "foo"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rule: invalid stream rule type "foo"`),
+			},
+			{
+				Config: fmt.Sprintf(streamTf, prefix, "500", "regex"),
+				Check:  testStreamRules(ctx, cl, key, &streamID, "status 1 500", "source 2 ^web"),
+			},
+			{
+				// the rule which is added out of Terraform is removed
+				PreConfig: func() {
+					if _, err := cl.CreateStreamRule(ctx, &graylog.StreamRule{
+						StreamID: streamID, Field: "extra", Value: "foo", Type: 1,
+					}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(streamTf, prefix, "503", "regex"),
+				Check:  testStreamRules(ctx, cl, key, &streamID, "status 1 503", "source 2 ^web"),
+			},
+			{
+				// the rules aren't imported
+				ResourceName:            key,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rule"},
+			},
+			{
+				ResourceName:            key,
+				ImportState:             true,
+				ImportStateId:           "title:terraform stream inline rules test",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rule"},
+			},
+			{
+				// the type id isn't accepted
+				Config:      fmt.Sprintf(streamTf, prefix, "503", "2"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rule: invalid stream rule type "2"`),
+			},
+			{
+				// removing all rule blocks removes all rules
+				Config: fmt.Sprintf(noRuleTf, prefix),
+				Check:  testStreamRules(ctx, cl, key, &streamID),
+			},
+			{
+				// the rules which are added out of Terraform aren't managed
+				PreConfig: func() {
+					if _, err := cl.CreateStreamRule(ctx, &graylog.StreamRule{
+						StreamID: streamID, Field: "extra", Value: "foo", Type: 1,
+					}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(noRuleTf, prefix),
+				Check:  testStreamRules(ctx, cl, key, &streamID, "extra 1 foo"),
+			},
 		},
 	})
 }
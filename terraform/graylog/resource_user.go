@@ -17,8 +17,10 @@ func resourceUser() *schema.Resource {
 		Update: resourceUserUpdate,
 		Delete: resourceUserDelete,
 
+		CustomizeDiff: resourceUserDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: genNaturalKeyImport(userImportTarget),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -50,9 +52,11 @@ func resourceUser() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			// roles is computed so that graylog_role_member can add the user to roles.
 			"roles": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"timezone": {
@@ -100,7 +104,7 @@ func resourceUser() *schema.Resource {
 	}
 }
 
-func newUser(d *schema.ResourceData) *graylog.User {
+func newUser(d resourceData) *graylog.User {
 	return &graylog.User{
 		Username:         d.Get("username").(string),
 		Password:         d.Get("password").(string),
@@ -119,6 +123,14 @@ func newUser(d *schema.ResourceData) *graylog.User {
 	}
 }
 
+func resourceUserDiff(d *schema.ResourceDiff, m interface{}) error {
+	user := newUser(d)
+	if d.Id() == "" {
+		return validatePlan(d, getValidator(d), user, nil)
+	}
+	return validatePlan(d, getValidator(d), user.NewUpdateParams(), nil)
+}
+
 func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 	ctx := context.Background()
 	cl, err := newClient(m)
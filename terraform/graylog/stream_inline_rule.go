@@ -0,0 +1,241 @@
+package graylog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+// streamInlineRuleSchema returns the schema of the "rule" block of graylog_stream.
+// If the block is set, the rules of the stream are managed authoritatively,
+// so the rules which aren't declared are removed.
+// Removing all blocks removes all rules of the stream.
+func streamInlineRuleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// Required
+				"field": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				// Optional
+				// the lower case name of the stream rule type (ex. "exact", "regex")
+				"type": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "exact",
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"inverted": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// streamRuleTypes maps the names of the stream rule types to the ids and vice versa.
+type streamRuleTypes struct {
+	ids   map[string]int
+	names map[int]string
+}
+
+// getStreamRuleTypes gets the stream rule types with Graylog API.
+// The names are converted to lower case.
+func getStreamRuleTypes(ctx context.Context, cl *client.Client) (*streamRuleTypes, error) {
+	// the stream rule types don't depend on the stream
+	types, _, err := cl.GetStreamRuleTypes(ctx, defaultStreamID)
+	if err != nil {
+		return nil, err
+	}
+	ts := &streamRuleTypes{
+		ids:   make(map[string]int, len(types)),
+		names: make(map[int]string, len(types)),
+	}
+	for _, t := range types {
+		name := strings.ToLower(t.Name)
+		ts.ids[name] = t.ID
+		ts.names[t.ID] = name
+	}
+	return ts, nil
+}
+
+// getID returns the id of the stream rule type.
+// The id isn't accepted because the name is set to the state.
+func (ts *streamRuleTypes) getID(name string) (int, error) {
+	if id, ok := ts.ids[name]; ok {
+		return id, nil
+	}
+	names := make([]string, 0, len(ts.ids))
+	for n := range ts.ids {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return 0, fmt.Errorf(`invalid stream rule type "%s": must be one of %s`, name, strings.Join(names, ", "))
+}
+
+// getName returns the name of the stream rule type.
+// If the type is unknown, the id is returned as string.
+func (ts *streamRuleTypes) getName(id int) string {
+	if name, ok := ts.names[id]; ok {
+		return name
+	}
+	return strconv.Itoa(id)
+}
+
+func newStreamInlineRules(set *schema.Set, streamID string, ts *streamRuleTypes) ([]graylog.StreamRule, error) {
+	arr := set.List()
+	rules := make([]graylog.StreamRule, len(arr))
+	for i, a := range arr {
+		src := a.(map[string]interface{})
+		t, err := ts.getID(src["type"].(string))
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = graylog.StreamRule{
+			StreamID:    streamID,
+			Field:       src["field"].(string),
+			Value:       src["value"].(string),
+			Description: src["description"].(string),
+			Type:        t,
+			Inverted:    src["inverted"].(bool),
+		}
+	}
+	return rules, nil
+}
+
+// validateStreamInlineRules validates the types of the inline rules and returns the error messages.
+// The rule types are got only if "rule" is changed.
+func validateStreamInlineRules(ctx context.Context, cl *client.Client, d *schema.ResourceDiff) ([]string, error) {
+	set := d.Get("rule").(*schema.Set)
+	if set.Len() == 0 || !d.HasChange("rule") || !isKnown(d, "rule") {
+		return nil, nil
+	}
+	ts, err := getStreamRuleTypes(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	msgs := []string{}
+	for _, a := range set.List() {
+		if _, err := ts.getID(a.(map[string]interface{})["type"].(string)); err != nil {
+			msgs = append(msgs, "rule: "+err.Error())
+		}
+	}
+	return msgs, nil
+}
+
+func isSameStreamRule(a, b *graylog.StreamRule) bool {
+	return a.Field == b.Field && a.Value == b.Value && a.Description == b.Description &&
+		a.Type == b.Type && a.Inverted == b.Inverted
+}
+
+// applyStreamInlineRules makes the rules of the stream same as the inline rules.
+// The rules which are same as the inline rules are kept,
+// the rules whose field is same as the changed inline rule are updated,
+// and the other rules are created or deleted.
+func applyStreamInlineRules(ctx context.Context, cl *client.Client, d *schema.ResourceData) error {
+	streamID := d.Id()
+	ts, err := getStreamRuleTypes(ctx, cl)
+	if err != nil {
+		return err
+	}
+	desired, err := newStreamInlineRules(d.Get("rule").(*schema.Set), streamID, ts)
+	if err != nil {
+		return err
+	}
+	actual, _, _, err := cl.GetStreamRules(ctx, streamID)
+	if err != nil {
+		return err
+	}
+
+	stale := []graylog.StreamRule{}
+	for _, rule := range actual {
+		matched := false
+		for i := range desired {
+			if isSameStreamRule(&rule, &desired[i]) {
+				desired = append(desired[:i], desired[i+1:]...)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			stale = append(stale, rule)
+		}
+	}
+
+	for i := range desired {
+		rule := &desired[i]
+		updated := false
+		for j, s := range stale {
+			if s.Field != rule.Field {
+				continue
+			}
+			rule.ID = s.ID
+			if _, err := cl.UpdateStreamRule(ctx, rule); err != nil {
+				return err
+			}
+			stale = append(stale[:j], stale[j+1:]...)
+			updated = true
+			break
+		}
+		if updated {
+			continue
+		}
+		if _, err := cl.CreateStreamRule(ctx, rule); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range stale {
+		if _, err := cl.DeleteStreamRule(ctx, streamID, rule.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setStreamInlineRules sets all rules of the stream to "rule" to detect the rules which are added out of Terraform.
+// If "rule" is empty, the rules aren't managed by graylog_stream so nothing is set.
+func setStreamInlineRules(ctx context.Context, cl *client.Client, d *schema.ResourceData) error {
+	if d.Get("rule").(*schema.Set).Len() == 0 {
+		return nil
+	}
+	rules, _, _, err := cl.GetStreamRules(ctx, d.Id())
+	if err != nil {
+		return err
+	}
+	ts, err := getStreamRuleTypes(ctx, cl)
+	if err != nil {
+		return err
+	}
+	arr := make([]map[string]interface{}, len(rules))
+	for i, rule := range rules {
+		arr[i] = map[string]interface{}{
+			"field":       rule.Field,
+			"value":       rule.Value,
+			"type":        ts.getName(rule.Type),
+			"description": rule.Description,
+			"inverted":    rule.Inverted,
+		}
+	}
+	return d.Set("rule", arr)
+}
@@ -0,0 +1,181 @@
+package graylog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// getGeneralMap merges the general_<type>_<suffix> attributes into a map.
+func getGeneralMap(d resourceData, suffix string) map[string]interface{} {
+	m := map[string]interface{}{}
+	for _, k := range []string{"bool", "int", "string", "float"} {
+		if a := d.Get(fmt.Sprintf("general_%s_%s", k, suffix)); a != nil {
+			for k, v := range a.(map[string]interface{}) {
+				m[k] = v
+			}
+		}
+	}
+	return m
+}
+
+// setGeneralMap splits a map by the value's type and sets them to the general_<type>_<suffix> attributes.
+func setGeneralMap(d *schema.ResourceData, suffix string, m map[string]interface{}) error {
+	intM := map[string]interface{}{}
+	strM := map[string]interface{}{}
+	floatM := map[string]interface{}{}
+	boolM := map[string]interface{}{}
+	for k, v := range m {
+		switch a := v.(type) {
+		case int:
+			intM[k] = a
+		case bool:
+			boolM[k] = a
+		case float64:
+			floatM[k] = a
+		case float32:
+			floatM[k] = a
+		case string:
+			strM[k] = a
+		case json.Number:
+			if i, err := a.Int64(); err == nil {
+				intM[k] = int(i)
+				continue
+			}
+			f, err := a.Float64()
+			if err != nil {
+				return fmt.Errorf("%s is invalid number: %v", k, err)
+			}
+			floatM[k] = f
+		default:
+			return fmt.Errorf("%s is invalid type", k)
+		}
+	}
+	if err := d.Set(fmt.Sprintf("general_int_%s", suffix), intM); err != nil {
+		return err
+	}
+	if err := d.Set(fmt.Sprintf("general_string_%s", suffix), strM); err != nil {
+		return err
+	}
+	if err := d.Set(fmt.Sprintf("general_float_%s", suffix), floatM); err != nil {
+		return err
+	}
+	return d.Set(fmt.Sprintf("general_bool_%s", suffix), boolM)
+}
+
+// getRegisteredTypeMap returns the value of a registered type as a map.
+// The value is read from the JSON string attribute if it is set,
+// otherwise it is merged from the general_<type>_<suffix> attributes.
+func getRegisteredTypeMap(d resourceData, key, suffix string) (map[string]interface{}, error) {
+	s, ok := d.GetOk(key)
+	if !ok {
+		return getGeneralMap(d, suffix), nil
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(s.(string)), &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+	}
+	return m, nil
+}
+
+// setRegisteredTypeMap sets a value of a registered type to the general_<type>_<suffix> attributes.
+// If the JSON string attribute is used or the value has fields which the general attributes can't express
+// such as objects, arrays and null, the value is set to the JSON string attribute instead.
+func setRegisteredTypeMap(d *schema.ResourceData, key, suffix string, v interface{}) error {
+	if _, ok := d.GetOk(key); !ok {
+		m, err := encodeRegisteredType(v)
+		if err != nil {
+			return err
+		}
+		if err := setGeneralMap(d, suffix, m); err == nil {
+			return nil
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := setGeneralMap(d, suffix, map[string]interface{}{}); err != nil {
+		return err
+	}
+	return d.Set(key, string(b))
+}
+
+// decodeRegisteredType decodes a map into a value of a type registered to the graylog package
+// and validates it.
+// If the value isn't a pointer, the decoded value is returned as not a pointer.
+func decodeRegisteredType(m map[string]interface{}, v interface{}) (interface{}, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(v)
+	p := rv
+	if rv.Kind() != reflect.Ptr {
+		p = reflect.New(rv.Type())
+		p.Elem().Set(rv)
+	}
+	if err := json.Unmarshal(b, p.Interface()); err != nil {
+		return nil, err
+	}
+	if p.Elem().Kind() == reflect.Struct {
+		if err := validator.CreateValidator.Struct(p.Interface()); err != nil {
+			return nil, err
+		}
+	}
+	if rv.Kind() != reflect.Ptr {
+		return p.Elem().Interface(), nil
+	}
+	return p.Interface(), nil
+}
+
+// encodeRegisteredType encodes a value of a registered type into a map.
+// Numbers are decoded as json.Number to distinguish int from float.
+func encodeRegisteredType(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// suppressEquivalentJSONDiffs suppresses the diffs of JSON string attributes which are semantically same.
+func suppressEquivalentJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
+	o, err := normalizeJSON(old)
+	if err != nil {
+		return false
+	}
+	n, err := normalizeJSON(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+// normalizeJSON returns the canonical JSON string to suppress meaningless diffs.
+func normalizeJSON(s string) (string, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", nil
+	}
+	var a interface{}
+	if err := json.Unmarshal([]byte(s), &a); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,82 @@
+package graylog
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+type nestedAlarmCallbackConfiguration struct {
+	URL     string            `json:"url" v-create:"required"`
+	Headers map[string]string `json:"headers"`
+	Tags    []string          `json:"tags"`
+}
+
+func (cfg *nestedAlarmCallbackConfiguration) AlarmCallbackType() string {
+	return "com.example.NestedAlarmCallback"
+}
+
+type flatAlarmCallbackConfiguration struct {
+	URL     string `json:"url"`
+	Retries int    `json:"retries"`
+}
+
+func (cfg *flatAlarmCallbackConfiguration) AlarmCallbackType() string {
+	return "com.example.FlatAlarmCallback"
+}
+
+func TestRegisteredTypeMap(t *testing.T) {
+	require.Nil(t, graylog.RegisterAlarmCallbackConfigurations(
+		func() graylog.AlarmCallbackConfiguration {
+			return &nestedAlarmCallbackConfiguration{}
+		},
+		func() graylog.AlarmCallbackConfiguration {
+			return &flatAlarmCallbackConfiguration{}
+		},
+	))
+	sc := resourceAlarmCallback().Schema
+
+	d := schema.TestResourceDataRaw(t, sc, map[string]interface{}{
+		"type":               "com.example.NestedAlarmCallback",
+		"title":              "nested",
+		"stream_id":          "foo",
+		"json_configuration": `{"url": "http://example.com", "headers": {"X-Foo": "bar"}, "tags": ["a", "b"]}`,
+	})
+	ac, err := newAlarmCallback(d)
+	require.Nil(t, err)
+	require.Equal(t, &nestedAlarmCallbackConfiguration{
+		URL: "http://example.com", Headers: map[string]string{"X-Foo": "bar"}, Tags: []string{"a", "b"},
+	}, ac.Configuration)
+
+	// the nested fields can't be set to the general attributes
+	d = schema.TestResourceDataRaw(t, sc, map[string]interface{}{})
+	require.Nil(t, setRegisteredTypeMap(d, "json_configuration", "configuration", ac.Configuration))
+	require.JSONEq(
+		t, `{"url": "http://example.com", "headers": {"X-Foo": "bar"}, "tags": ["a", "b"]}`,
+		d.Get("json_configuration").(string))
+	require.Empty(t, d.Get("general_string_configuration"))
+
+	d = schema.TestResourceDataRaw(t, sc, map[string]interface{}{
+		"type":      "com.example.FlatAlarmCallback",
+		"title":     "flat",
+		"stream_id": "foo",
+		"general_string_configuration": map[string]interface{}{
+			"url": "http://example.com",
+		},
+		"general_int_configuration": map[string]interface{}{
+			"retries": 3,
+		},
+	})
+	ac, err = newAlarmCallback(d)
+	require.Nil(t, err)
+	require.Equal(t, &flatAlarmCallbackConfiguration{URL: "http://example.com", Retries: 3}, ac.Configuration)
+
+	d = schema.TestResourceDataRaw(t, sc, map[string]interface{}{})
+	require.Nil(t, setRegisteredTypeMap(d, "json_configuration", "configuration", ac.Configuration))
+	require.Equal(t, "", d.Get("json_configuration"))
+	require.Equal(t, map[string]interface{}{"url": "http://example.com"}, d.Get("general_string_configuration"))
+	require.Equal(t, map[string]interface{}{"retries": 3}, d.Get("general_int_configuration"))
+}
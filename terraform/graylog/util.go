@@ -3,30 +3,21 @@ package graylog
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 
 	"github.com/suzuki-shunsuke/go-graylog/client"
-	"github.com/suzuki-shunsuke/graylog-mock-server/mockserver"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
 )
 
-func genImport(keys ...string) schema.StateFunc {
-	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-		a := strings.Split(d.Id(), "/")
-		size := len(keys)
-		if len(a) != size {
-			return nil, fmt.Errorf("format of import argument should be %s", strings.Join(keys, "/"))
-		}
-		for i, k := range keys[:size-1] {
-			if err := setStrToRD(d, k, a[i]); err != nil {
-				return nil, err
-			}
-		}
-		d.SetId(a[size-1])
-		return []*schema.ResourceData{d}, nil
-	}
+// resourceData is the common interface of *schema.ResourceData and *schema.ResourceDiff.
+// The functions which build a model from the resource accept it,
+// so that they can be used both to call API and to validate the planned state in CustomizeDiff.
+type resourceData interface {
+	Get(string) interface{}
+	GetOk(string) (interface{}, bool)
+	Id() string
 }
 
 func handleGetResourceError(
@@ -39,6 +30,16 @@ func handleGetResourceError(
 	return err
 }
 
+// getBlock returns the first element of a terraform list block whose MaxItems is 1.
+func getBlock(src interface{}) (map[string]interface{}, bool) {
+	a, ok := src.([]interface{})
+	if !ok || len(a) == 0 || a[0] == nil {
+		return nil, false
+	}
+	m, ok := a[0].(map[string]interface{})
+	return m, ok
+}
+
 func getStringArray(src []interface{}) []string {
 	dest := make([]string, len(src))
 	for i, p := range src {
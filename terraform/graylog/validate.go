@@ -0,0 +1,170 @@
+package graylog
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	playground "gopkg.in/go-playground/validator.v9"
+
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+// getValidator returns the validator for the planned change.
+// If the resource hasn't been created yet, CreateValidator is returned.
+func getValidator(d *schema.ResourceDiff) *playground.Validate {
+	if d.Id() == "" {
+		return validator.CreateValidator
+	}
+	return validator.UpdateValidator
+}
+
+// isKnown returns true if the values of all given attributes are known at the plan.
+// An attribute is unknown when it refers to an attribute of other resource which hasn't been created yet.
+func isKnown(d *schema.ResourceDiff, keys ...string) bool {
+	for _, key := range keys {
+		a := strings.Split(key, ".")
+		for i := range a {
+			if !d.NewValueKnown(strings.Join(a[:i+1], ".")) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validatePlan validates a model built from the planned state with a given validator
+// and returns an error which has field-level messages.
+// The errors about the attributes whose values are unknown at the plan are ignored,
+// because the values are decided at apply.
+// keys maps the top level JSON keys of the model to the terraform attribute names if they are different.
+// msgs are the messages of the resource specific validations, which are reported together.
+func validatePlan(
+	d *schema.ResourceDiff, v *playground.Validate, model interface{}, keys map[string]string, msgs ...string,
+) error {
+	a, err := getPlanErrorMessages(d, v, model, keys)
+	if err != nil {
+		return err
+	}
+	return newPlanError(append(msgs, a...))
+}
+
+// getPlanErrorMessages validates a model with a given validator and returns the field-level messages.
+// It is used to validate multiple models such as nested blocks and report the messages together.
+func getPlanErrorMessages(
+	d *schema.ResourceDiff, v *playground.Validate, model interface{}, keys map[string]string,
+) ([]string, error) {
+	err := v.Struct(model)
+	if err == nil {
+		return nil, nil
+	}
+	errs, ok := err.(playground.ValidationErrors)
+	if !ok {
+		return nil, err
+	}
+	msgs := []string{}
+	for _, e := range errs {
+		key := toAttrKey(toJSONPath(model, e.StructNamespace()), keys)
+		if !isKnown(d, key) {
+			continue
+		}
+		msg := fmt.Sprintf("%s: failed on the '%s' tag", key, e.Tag())
+		if p := e.Param(); p != "" {
+			msg += fmt.Sprintf(" (%s)", p)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// newPlanError returns an error which has all given messages.
+// If no message is given, nil is returned.
+func newPlanError(msgs []string) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New("invalid configuration:\n  " + strings.Join(msgs, "\n  "))
+}
+
+// toJSONPath converts the struct namespace of the validator's error to the JSON path.
+// ex. "Input.Attrs.BindAddress" -> ["attributes", "bind_address"]
+func toJSONPath(model interface{}, ns string) []string {
+	names := strings.Split(ns, ".")[1:]
+	path := make([]string, 0, len(names))
+	rv := indirectValue(reflect.ValueOf(model))
+	for _, name := range names {
+		idx := ""
+		if i := strings.Index(name, "["); i != -1 {
+			idx = strings.Trim(name[i:], "[]")
+			name = name[:i]
+		}
+		if !rv.IsValid() || rv.Kind() != reflect.Struct {
+			path = append(path, name)
+			rv = reflect.Value{}
+		} else {
+			key := name
+			if f, ok := rv.Type().FieldByName(name); ok {
+				if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag != "" {
+					key = tag
+				}
+			}
+			path = append(path, key)
+			rv = indirectValue(rv.FieldByName(name))
+		}
+		if idx == "" {
+			continue
+		}
+		path = append(path, idx)
+		if !rv.IsValid() || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+			rv = reflect.Value{}
+			continue
+		}
+		i, err := strconv.Atoi(idx)
+		if err != nil || i >= rv.Len() {
+			rv = reflect.Value{}
+			continue
+		}
+		rv = indirectValue(rv.Index(i))
+	}
+	return path
+}
+
+// toAttrKey converts the JSON path to the terraform attribute key.
+// Because nested objects are defined as lists whose MaxItems is 1 at terraform,
+// the index "0" is inserted between the object and the field.
+// ex. ["attributes", "bind_address"] -> "attributes.0.bind_address"
+func toAttrKey(path []string, keys map[string]string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	if k, ok := keys[path[0]]; ok && k != "" {
+		path[0] = k
+	}
+	a := make([]string, 0, len(path)*2)
+	for i, k := range path {
+		a = append(a, k)
+		if i == len(path)-1 || isIndex(k) || isIndex(path[i+1]) {
+			continue
+		}
+		a = append(a, "0")
+	}
+	return strings.Join(a, ".")
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func indirectValue(rv reflect.Value) reflect.Value {
+	for rv.IsValid() && (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
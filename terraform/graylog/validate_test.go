@@ -0,0 +1,141 @@
+package graylog
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccPlanValidation(t *testing.T) {
+	_, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	indexSetTf := `
+resource "graylog_index_set" "test" {
+  title = "terraform test index set"
+  index_prefix = "%s"
+  shards = 4
+  replicas = 0
+  rotation_strategy_class = "org.graylog2.indexer.rotation.strategies.SizeBasedRotationStrategy"
+  rotation_strategy {
+    type = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig"
+    max_docs_per_index = 20000000
+  }
+  retention_strategy_class = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
+  retention_strategy {
+    type = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
+  }
+  index_analyzer = "standard"
+  index_optimization_max_num_segments = 1
+}`
+
+	inputTf := `
+resource "graylog_input" "test" {
+  title = "terraform test input"
+  type = "org.graylog2.inputs.syslog.udp.SyslogUDPInput"
+  global = true
+  attributes {
+    bind_address = "0.0.0.0"
+    recv_buffer_size = 262144
+    tls_cert_file = "/etc/graylog/server.crt"
+  }
+}`
+
+	alertConditionTf := `
+resource "graylog_alert_condition" "test" {
+  type = "field_value"
+  stream_id = "5d84c1a92ab79c000d35d6ca"
+  title = "terraform test alert condition"
+  field_value_parameters {
+    field = "status"
+    type = "MEAN"
+    threshold_type = "MORE"
+    time = 5
+  }
+}`
+
+	// dependent attributes are unknown at the plan, so they aren't validated
+	unknownTf := `
+resource "graylog_index_set" "test" {
+  title = "terraform test index set"
+  index_prefix = "terraform-test-plan-validation"
+  shards = 4
+  replicas = 0
+  rotation_strategy_class = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy"
+  rotation_strategy {
+    type = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig"
+    max_docs_per_index = 20000000
+  }
+  retention_strategy_class = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
+  retention_strategy {
+    type = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
+    max_number_of_indices = 20
+  }
+  index_analyzer = "standard"
+  index_optimization_max_num_segments = 1
+}
+
+resource "graylog_stream" "test" {
+  title = "terraform test stream"
+  index_set_id = graylog_index_set.test.id
+  matching_type = "AND"
+}`
+
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(indexSetTf, "Invalid Prefix"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`index_prefix: failed on the 'indexprefixregexp' tag`),
+			},
+			{
+				Config:      fmt.Sprintf(indexSetTf, "terraform-test-plan-validation"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rotation_strategy.0.type: must be org.graylog2.indexer.rotation.strategies.SizeBasedRotationStrategyConfig`),
+			},
+			{
+				Config:      fmt.Sprintf(indexSetTf, "terraform-test-plan-validation"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`retention_strategy.0.max_number_of_indices: must be greater than 0`),
+			},
+			{
+				Config:      inputTf,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`attributes.0.port: failed on the 'required' tag`),
+			},
+			{
+				Config:      inputTf,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`attributes.0.tls_cert_file: isn't supported by the input type`),
+			},
+			{
+				Config:      alertConditionTf,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`field_value_parameters.0.threshold_type: failed on the 'oneof' tag \(HIGHER LOWER\)`),
+			},
+			{
+				Config:             unknownTf,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package testdata
+
+import (
+	"github.com/suzuki-shunsuke/go-ptr"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+var (
+	MessageCountDashboardWidget = &graylog.Widget{
+		Description:   "Message count",
+		CreatorUserID: "admin",
+		ID:            "2c5e3d4f-3f6d-4dab-9c7e-2f7a8b9c0d1e",
+		CacheTime:     ptr.PInt(10),
+		Config: &graylog.WidgetConfigSearchResultCount{
+			Timerange: &graylog.Timerange{
+				Type:  "relative",
+				Range: 300,
+			},
+			StreamID:      "5d84c1a92ab79c000d35d6ca",
+			Query:         "level:3",
+			LowerIsBetter: true,
+			Trend:         true,
+		},
+	}
+)
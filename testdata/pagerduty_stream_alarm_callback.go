@@ -0,0 +1,22 @@
+package testdata
+
+import (
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+var (
+	PagerDutyStreamAlarmCallback = graylog.AlarmCallback{
+		ID:            "5d84c1a92ab79c000d35d6d6",
+		StreamID:      "5d84c1a92ab79c000d35d6ca",
+		Title:         "pagerduty",
+		CreatorUserID: "admin",
+		CreatedAt:     "2019-09-20T12:10:17.801+0000",
+		Configuration: &graylog.PagerDutyAlarmCallbackConfiguration{
+			ServiceKey:     "e93facc04764012d7bfb002500d5d1a6",
+			ClientName:     "Graylog",
+			ClientURL:      "https://graylog.example.com",
+			KeyPrefix:      "Graylog",
+			CustomIncident: true,
+		},
+	}
+)
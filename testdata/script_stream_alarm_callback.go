@@ -0,0 +1,21 @@
+package testdata
+
+import (
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+var (
+	ScriptStreamAlarmCallback = graylog.AlarmCallback{
+		ID:            "5d84c1a92ab79c000d35d6d7",
+		StreamID:      "5d84c1a92ab79c000d35d6ca",
+		Title:         "script",
+		CreatorUserID: "admin",
+		CreatedAt:     "2019-09-20T12:10:17.812+0000",
+		Configuration: &graylog.ScriptAlarmCallbackConfiguration{
+			ScriptPath:      "/usr/share/graylog/scripts/notify.sh",
+			ScriptArgs:      "stream_id alert_description",
+			ScriptTimeout:   5000,
+			ScriptSendStdin: true,
+		},
+	}
+)
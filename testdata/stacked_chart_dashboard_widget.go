@@ -0,0 +1,38 @@
+package testdata
+
+import (
+	"github.com/suzuki-shunsuke/go-ptr"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+var (
+	StackedChartDashboardWidget = &graylog.Widget{
+		Description:   "Stacked chart",
+		CreatorUserID: "admin",
+		ID:            "1b4d2c3e-2e5c-4c9a-8b6d-1e6f7a8b9c0d",
+		CacheTime:     ptr.PInt(10),
+		Config: &graylog.WidgetConfigStackedChart{
+			Timerange: &graylog.Timerange{
+				Type:  "relative",
+				Range: 86400,
+			},
+			StreamID:      "5d84c1a92ab79c000d35d6ca",
+			Interval:      "hour",
+			Renderer:      "bar",
+			Interpolation: "linear",
+			Series: []graylog.WidgetConfigStackedChartSeries{
+				{
+					Query:               "status:500",
+					Field:               "took_ms",
+					StatisticalFunction: "mean",
+				},
+				{
+					Query:               "status:200",
+					Field:               "took_ms",
+					StatisticalFunction: "mean",
+				},
+			},
+		},
+	}
+)
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+var (
+	TeamsStreamAlarmCallback = graylog.AlarmCallback{
+		ID:            "5d84c1a92ab79c000d35d6d8",
+		StreamID:      "5d84c1a92ab79c000d35d6ca",
+		Title:         "teams",
+		CreatorUserID: "admin",
+		CreatedAt:     "2019-09-20T12:10:17.823+0000",
+		Configuration: &graylog.TeamsAlarmCallbackConfiguration{
+			WebhookURL:    "https://outlook.office.com/webhook/00000000-0000-0000-0000-000000000000@00000000-0000-0000-0000-000000000000/IncomingWebhook/00000000000000000000000000000000/00000000-0000-0000-0000-000000000000",
+			Color:         "#FF0000",
+			Graylog2URL:   "https://graylog.example.com",
+			IconURL:       "https://graylog.example.com/assets/favicon.png",
+			ProxyAddress:  "http://proxy.example.com:3128",
+			CustomMessage: "${alert_condition.title}\\n\\n${foreach backlog message}${message.message}\\n${end}",
+			BacklogItems:  5,
+		},
+	}
+)
@@ -0,0 +1,25 @@
+package testdata
+
+import (
+	"github.com/suzuki-shunsuke/go-ptr"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+var (
+	WorldMapDashboardWidget = &graylog.Widget{
+		Description:   "World map",
+		CreatorUserID: "admin",
+		ID:            "0a3c1b2d-1d4b-4b8f-9a5c-0d5e6f7a8b9c",
+		CacheTime:     ptr.PInt(10),
+		Config: &graylog.WidgetConfigWorldMap{
+			Timerange: &graylog.Timerange{
+				Type:  "relative",
+				Range: 300,
+			},
+			StreamID: "5d84c1a92ab79c000d35d6ca",
+			Query:    "",
+			Field:    "client_ip_geolocation",
+		},
+	}
+)
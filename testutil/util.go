@@ -9,7 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/suzuki-shunsuke/go-graylog"
 	"github.com/suzuki-shunsuke/go-graylog/client"
-	"github.com/suzuki-shunsuke/graylog-mock-server/mockserver"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
 )
 
 const (
@@ -140,6 +140,23 @@ func WaitAfterDeleteIndexSet(server *mockserver.Server) {
 	}
 }
 
+// Seed returns the initial data of the mock server for tests.
+// In addition to mockserver.DefaultSeed, the data has a paused stream which isn't the default stream.
+func Seed() *mockserver.Seed {
+	seed := mockserver.DefaultSeed()
+	seed.Streams = append(seed.Streams, graylog.Stream{
+		Title:        "test",
+		Description:  "test stream",
+		IndexSetID:   mockserver.DefaultIndexSetID,
+		MatchingType: "AND",
+		Disabled:     true,
+		Rules: []graylog.StreamRule{{
+			Type: 1, Field: "tag", Value: "test",
+		}},
+	})
+	return seed
+}
+
 // GetServerAndClient returns server and client.
 func GetServerAndClient() (*mockserver.Server, *client.Client, error) {
 	var (
@@ -156,7 +173,7 @@ func GetServerAndClient() (*mockserver.Server, *client.Client, error) {
 	}
 	endpoint := os.Getenv("GRAYLOG_WEB_ENDPOINT_URI")
 	if endpoint == "" {
-		server, err = mockserver.NewServer("", nil)
+		server, err = mockserver.NewServer("", Seed())
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "Failed to get Mock Server")
 		}
@@ -0,0 +1,217 @@
+package graylog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+type (
+	// NewAlarmCallbackConfiguration is the constructor of AlarmCallbackConfiguration.
+	NewAlarmCallbackConfiguration func() AlarmCallbackConfiguration
+
+	// NewAlertConditionParameters is the constructor of AlertConditionParameters.
+	NewAlertConditionParameters func() AlertConditionParameters
+
+	// NewWidgetConfig is the constructor of WidgetConfig.
+	NewWidgetConfig func() WidgetConfig
+
+	// typeRegistry maps a type name to the constructor of the typed struct.
+	typeRegistry struct {
+		mutex sync.RWMutex
+		data  map[string]func() interface{}
+	}
+)
+
+var (
+	alarmCallbackTypes  = &typeRegistry{data: map[string]func() interface{}{}}
+	alertConditionTypes = &typeRegistry{data: map[string]func() interface{}{}}
+	widgetTypes         = &typeRegistry{data: map[string]func() interface{}{}}
+
+	alarmCallbackConfigurationList = []NewAlarmCallbackConfiguration{
+		func() AlarmCallbackConfiguration { return &EmailAlarmCallbackConfiguration{} },
+		func() AlarmCallbackConfiguration { return &HTTPAlarmCallbackConfiguration{} },
+		func() AlarmCallbackConfiguration { return &SlackAlarmCallbackConfiguration{} },
+		func() AlarmCallbackConfiguration { return &PagerDutyAlarmCallbackConfiguration{} },
+		func() AlarmCallbackConfiguration { return &ScriptAlarmCallbackConfiguration{} },
+		func() AlarmCallbackConfiguration { return &TeamsAlarmCallbackConfiguration{} },
+	}
+	alertConditionParametersList = []NewAlertConditionParameters{
+		func() AlertConditionParameters { return FieldContentAlertConditionParameters{} },
+		func() AlertConditionParameters { return FieldAggregationAlertConditionParameters{} },
+		func() AlertConditionParameters { return MessageCountAlertConditionParameters{} },
+	}
+	widgetConfigList = []NewWidgetConfig{
+		func() WidgetConfig { return &WidgetConfigStatsCount{} },
+		func() WidgetConfig { return &WidgetConfigQuickValues{} },
+		func() WidgetConfig { return &WidgetConfigQuickValuesHistogram{} },
+		func() WidgetConfig { return &WidgetConfigFieldChart{} },
+		func() WidgetConfig { return &WidgetConfigStreamSearchResultCount{} },
+		func() WidgetConfig { return &WidgetConfigSearchResultChart{} },
+		func() WidgetConfig { return &WidgetConfigWorldMap{} },
+		func() WidgetConfig { return &WidgetConfigStackedChart{} },
+		func() WidgetConfig { return &WidgetConfigSearchResultCount{} },
+	}
+)
+
+func init() {
+	if err := RegisterAlarmCallbackConfigurations(alarmCallbackConfigurationList...); err != nil {
+		panic(err)
+	}
+	if err := RegisterAlertConditionParameters(alertConditionParametersList...); err != nil {
+		panic(err)
+	}
+	if err := RegisterWidgetConfigs(widgetConfigList...); err != nil {
+		panic(err)
+	}
+}
+
+func (r *typeRegistry) set(t string, f func() interface{}) error {
+	if t == "" {
+		return fmt.Errorf("type is empty")
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.data[t] = f
+	return nil
+}
+
+func (r *typeRegistry) get(t string) (interface{}, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	f, ok := r.data[t]
+	if !ok {
+		return nil, false
+	}
+	return f(), true
+}
+
+func (r *typeRegistry) types() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	ts := make([]string, 0, len(r.data))
+	for t := range r.data {
+		ts = append(ts, t)
+	}
+	sort.Strings(ts)
+	return ts
+}
+
+// unmarshalTyped unmarshals JSON into a value returned by a registered constructor.
+// If the value isn't a pointer, the unmarshaled value is returned as not a pointer.
+func unmarshalTyped(b []byte, v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if err := json.Unmarshal(b, v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	p := reflect.New(rv.Type())
+	p.Elem().Set(rv)
+	if err := json.Unmarshal(b, p.Interface()); err != nil {
+		return nil, err
+	}
+	return p.Elem().Interface(), nil
+}
+
+// RegisterAlarmCallbackConfigurations registers alarm callback types.
+// The configuration of a registered type is decoded into the typed struct instead of GeneralAlarmCallbackConfiguration.
+// You can add the custom types of third party plugins and override existing types.
+func RegisterAlarmCallbackConfigurations(args ...NewAlarmCallbackConfiguration) error {
+	for _, f := range args {
+		a := f()
+		if a == nil {
+			return fmt.Errorf("NewAlarmCallbackConfiguration must not return nil")
+		}
+		f := f
+		if err := alarmCallbackTypes.set(a.AlarmCallbackType(), func() interface{} { return f() }); err != nil {
+			return fmt.Errorf("failed to register an alarm callback type: %v", err)
+		}
+	}
+	return nil
+}
+
+// NewAlarmCallbackConfigurationByType returns a new configuration of a registered alarm callback type.
+// If the type isn't registered, the second returned value is false.
+func NewAlarmCallbackConfigurationByType(t string) (AlarmCallbackConfiguration, bool) {
+	a, ok := alarmCallbackTypes.get(t)
+	if !ok {
+		return nil, false
+	}
+	return a.(AlarmCallbackConfiguration), true
+}
+
+// AlarmCallbackTypes returns the sorted list of registered alarm callback types.
+func AlarmCallbackTypes() []string {
+	return alarmCallbackTypes.types()
+}
+
+// RegisterAlertConditionParameters registers alert condition types.
+// The parameters of a registered type is decoded into the typed struct instead of GeneralAlertConditionParameters.
+// You can add the custom types of third party plugins and override existing types.
+func RegisterAlertConditionParameters(args ...NewAlertConditionParameters) error {
+	for _, f := range args {
+		a := f()
+		if a == nil {
+			return fmt.Errorf("NewAlertConditionParameters must not return nil")
+		}
+		f := f
+		if err := alertConditionTypes.set(a.AlertConditionType(), func() interface{} { return f() }); err != nil {
+			return fmt.Errorf("failed to register an alert condition type: %v", err)
+		}
+	}
+	return nil
+}
+
+// NewAlertConditionParametersByType returns new parameters of a registered alert condition type.
+// If the type isn't registered, the second returned value is false.
+func NewAlertConditionParametersByType(t string) (AlertConditionParameters, bool) {
+	a, ok := alertConditionTypes.get(t)
+	if !ok {
+		return nil, false
+	}
+	return a.(AlertConditionParameters), true
+}
+
+// AlertConditionTypes returns the sorted list of registered alert condition types.
+func AlertConditionTypes() []string {
+	return alertConditionTypes.types()
+}
+
+// RegisterWidgetConfigs registers dashboard widget types.
+// The config of a registered type is decoded into the typed struct instead of WidgetConfigUnknownType.
+// You can add the custom types and override existing types.
+func RegisterWidgetConfigs(args ...NewWidgetConfig) error {
+	for _, f := range args {
+		a := f()
+		if a == nil {
+			return fmt.Errorf("NewWidgetConfig must not return nil")
+		}
+		if reflect.TypeOf(a).Kind() != reflect.Ptr {
+			return fmt.Errorf("NewWidgetConfig must return pointer")
+		}
+		f := f
+		if err := widgetTypes.set(a.Type(), func() interface{} { return f() }); err != nil {
+			return fmt.Errorf("failed to register a widget type: %v", err)
+		}
+	}
+	return nil
+}
+
+// NewWidgetConfigByType returns a new config of a registered widget type.
+// If the type isn't registered, the second returned value is false.
+func NewWidgetConfigByType(t string) (WidgetConfig, bool) {
+	a, ok := widgetTypes.get(t)
+	if !ok {
+		return nil, false
+	}
+	return a.(WidgetConfig), true
+}
+
+// WidgetTypes returns the sorted list of registered widget types.
+func WidgetTypes() []string {
+	return widgetTypes.types()
+}
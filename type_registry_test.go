@@ -0,0 +1,107 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+type customAlarmCallbackConfiguration struct {
+	ServiceKey string `json:"service_key" v-create:"required"`
+	Retries    int    `json:"retries"`
+}
+
+func (cfg *customAlarmCallbackConfiguration) AlarmCallbackType() string {
+	return "com.example.CustomAlarmCallback"
+}
+
+type customAlertConditionParameters struct {
+	Threshold int `json:"threshold"`
+}
+
+func (p customAlertConditionParameters) AlertConditionType() string {
+	return "custom_condition"
+}
+
+type customWidgetConfig struct {
+	StreamID string `json:"stream_id"`
+}
+
+func (cfg *customWidgetConfig) Type() string {
+	return "CUSTOM_WIDGET"
+}
+
+func TestRegisterAlarmCallbackConfigurations(t *testing.T) {
+	require.Nil(t, graylog.RegisterAlarmCallbackConfigurations(func() graylog.AlarmCallbackConfiguration {
+		return &customAlarmCallbackConfiguration{}
+	}))
+	require.Contains(t, graylog.AlarmCallbackTypes(), "com.example.CustomAlarmCallback")
+	require.Contains(t, graylog.AlarmCallbackTypes(), graylog.HTTPAlarmCallbackType)
+
+	ac := &graylog.AlarmCallback{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "type": "com.example.CustomAlarmCallback",
+  "title": "custom",
+  "configuration": {"service_key": "foo", "retries": 3}
+}`), ac))
+	require.Equal(t, &customAlarmCallbackConfiguration{ServiceKey: "foo", Retries: 3}, ac.Configuration)
+
+	b, err := json.Marshal(ac)
+	require.Nil(t, err)
+	ac2 := &graylog.AlarmCallback{}
+	require.Nil(t, json.Unmarshal(b, ac2))
+	require.Equal(t, ac, ac2)
+}
+
+func TestRegisterAlertConditionParameters(t *testing.T) {
+	require.Nil(t, graylog.RegisterAlertConditionParameters(func() graylog.AlertConditionParameters {
+		return customAlertConditionParameters{}
+	}))
+	require.Contains(t, graylog.AlertConditionTypes(), "custom_condition")
+
+	cond := &graylog.AlertCondition{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "type": "custom_condition",
+  "title": "custom",
+  "parameters": {"threshold": 10}
+}`), cond))
+	require.Equal(t, customAlertConditionParameters{Threshold: 10}, cond.Parameters)
+
+	// built-in types are registered as not pointer
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "type": "message_count",
+  "title": "count",
+  "parameters": {"threshold": 10, "time": 5}
+}`), cond))
+	require.Equal(t, graylog.MessageCountAlertConditionParameters{Threshold: 10, Time: 5}, cond.Parameters)
+}
+
+func TestRegisterWidgetConfigs(t *testing.T) {
+	require.NotNil(t, graylog.RegisterWidgetConfigs(func() graylog.WidgetConfig {
+		return nil
+	}))
+	require.Nil(t, graylog.RegisterWidgetConfigs(func() graylog.WidgetConfig {
+		return &customWidgetConfig{}
+	}))
+	require.Contains(t, graylog.WidgetTypes(), "CUSTOM_WIDGET")
+
+	widget := &graylog.Widget{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "type": "CUSTOM_WIDGET",
+  "description": "custom",
+  "config": {"stream_id": "foo"}
+}`), widget))
+	require.Equal(t, &customWidgetConfig{StreamID: "foo"}, widget.Config)
+
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "type": "UNREGISTERED_WIDGET",
+  "description": "unknown",
+  "config": {"stream_id": "foo"}
+}`), widget))
+	b, err := json.Marshal(widget.Config)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"stream_id": "foo"}`, string(b))
+}
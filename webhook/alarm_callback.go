@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+type (
+	// AlarmCallbackPayload represents the payload of Graylog's HTTP alarm callback (graylog.HTTPAlarmCallbackType).
+	AlarmCallbackPayload struct {
+		CheckResult CheckResult     `json:"check_result"`
+		Stream      *graylog.Stream `json:"stream"`
+	}
+
+	// CheckResult represents the result of the alert condition's check.
+	CheckResult struct {
+		ResultDescription  string                  `json:"result_description"`
+		TriggeredCondition *graylog.AlertCondition `json:"triggered_condition"`
+		// ex. "2019-09-20T12:20:09.287Z"
+		TriggeredAt      string    `json:"triggered_at"`
+		Triggered        bool      `json:"triggered"`
+		MatchingMessages []Message `json:"matching_messages"`
+	}
+
+	// Message represents a message which matches the alert condition.
+	Message struct {
+		ID        string   `json:"id"`
+		Index     string   `json:"index"`
+		Message   string   `json:"message"`
+		Source    string   `json:"source"`
+		Timestamp string   `json:"timestamp"`
+		StreamIDs []string `json:"stream_ids"`
+		// Fields is the message's fields except for the above fields.
+		Fields map[string]interface{} `json:"fields"`
+	}
+)
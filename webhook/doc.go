@@ -0,0 +1,26 @@
+/*
+Package webhook provides a http.Handler which receives Graylog's HTTP alarm callbacks
+and HTTP event notifications (Graylog 3.1+).
+
+The payload is decoded into the typed struct and passed to the callback.
+
+	h := &webhook.Handler{
+		Secret: os.Getenv("GRAYLOG_WEBHOOK_SECRET"),
+		OnAlarmCallback: func(ctx context.Context, p *webhook.AlarmCallbackPayload) error {
+			log.Println(p.Stream.Title, p.CheckResult.ResultDescription)
+			return nil
+		},
+		OnEventNotification: func(ctx context.Context, p *webhook.EventNotificationPayload) error {
+			log.Println(p.EventDefinitionTitle, p.Event.Message)
+			return nil
+		},
+	}
+	http.Handle("/graylog", h)
+
+Graylog's HTTP alarm callback and HTTP notification can only be configured with the URL,
+so the secret is passed by the query parameter "secret" such as "https://example.com/graylog?secret=xxx".
+The header "X-Graylog-Webhook-Secret" is also accepted.
+
+The sub package webhooktest provides utilities to test the handler without Graylog.
+*/
+package webhook
@@ -0,0 +1,39 @@
+package webhook
+
+type (
+	// EventNotificationPayload represents the payload of Graylog's HTTP event notification.
+	// Event notifications are available since Graylog 3.1.
+	EventNotificationPayload struct {
+		EventDefinitionID          string `json:"event_definition_id"`
+		EventDefinitionType        string `json:"event_definition_type"`
+		EventDefinitionTitle       string `json:"event_definition_title"`
+		EventDefinitionDescription string `json:"event_definition_description"`
+		JobDefinitionID            string `json:"job_definition_id"`
+		JobTriggerID               string `json:"job_trigger_id"`
+		Event                      Event  `json:"event"`
+		// Backlog is the messages which match the event definition.
+		Backlog []Message `json:"backlog"`
+	}
+
+	// Event represents an event of the event notification.
+	Event struct {
+		ID                  string `json:"id"`
+		EventDefinitionType string `json:"event_definition_type"`
+		EventDefinitionID   string `json:"event_definition_id"`
+		OriginContext       string `json:"origin_context"`
+		// ex. "2019-09-20T12:20:09.287Z"
+		Timestamp           string                 `json:"timestamp"`
+		TimestampProcessing string                 `json:"timestamp_processing"`
+		TimerangeStart      string                 `json:"timerange_start"`
+		TimerangeEnd        string                 `json:"timerange_end"`
+		Streams             []string               `json:"streams"`
+		SourceStreams       []string               `json:"source_streams"`
+		Message             string                 `json:"message"`
+		Source              string                 `json:"source"`
+		KeyTuple            []string               `json:"key_tuple"`
+		Key                 string                 `json:"key"`
+		Priority            int                    `json:"priority"`
+		Alert               bool                   `json:"alert"`
+		Fields              map[string]interface{} `json:"fields"`
+	}
+)
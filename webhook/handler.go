@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// SecretQueryParam is the name of the query parameter which has the shared secret.
+	SecretQueryParam = "secret"
+	// SecretHeader is the name of the request header which has the shared secret.
+	SecretHeader = "X-Graylog-Webhook-Secret"
+
+	// PayloadTypeAlarmCallback is the payload type of the HTTP alarm callback.
+	PayloadTypeAlarmCallback = "alarm_callback"
+	// PayloadTypeEventNotification is the payload type of the HTTP event notification.
+	PayloadTypeEventNotification = "event_notification"
+
+	// DefaultMaxBodySize is the default max size of the request body in bytes.
+	DefaultMaxBodySize int64 = 1 << 20
+)
+
+type (
+	// Handler is a http.Handler which receives Graylog's webhooks.
+	// The payload is dispatched to OnAlarmCallback or OnEventNotification.
+	// If the callback returns an error, the handler responds with the status 500.
+	// If the request body is larger than MaxBodySize, the handler responds with the status 413.
+	Handler struct {
+		// Secret is the shared secret. If Secret is empty, the secret isn't checked.
+		Secret              string
+		OnAlarmCallback     func(ctx context.Context, payload *AlarmCallbackPayload) error
+		OnEventNotification func(ctx context.Context, payload *EventNotificationPayload) error
+		// OnError is called when the request is rejected or the callback returns an error.
+		// It is useful for logging.
+		OnError func(req *http.Request, err error)
+		// MaxBodySize is the max size of the request body in bytes. The default is DefaultMaxBodySize.
+		MaxBodySize int64
+	}
+
+	// payloadKeys is used to detect the payload type.
+	payloadKeys struct {
+		CheckResult       json.RawMessage `json:"check_result"`
+		EventDefinitionID *string         `json:"event_definition_id"`
+	}
+)
+
+// PayloadType returns the type of the payload,
+// PayloadTypeAlarmCallback or PayloadTypeEventNotification.
+func PayloadType(b []byte) (string, error) {
+	keys := payloadKeys{}
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return "", fmt.Errorf("failed to parse the payload as JSON: %v", err)
+	}
+	if keys.EventDefinitionID != nil {
+		return PayloadTypeEventNotification, nil
+	}
+	if keys.CheckResult != nil {
+		return PayloadTypeAlarmCallback, nil
+	}
+	return "", fmt.Errorf("unknown payload type")
+}
+
+// DecodeAlarmCallback decodes the payload of the HTTP alarm callback.
+func DecodeAlarmCallback(b []byte) (*AlarmCallbackPayload, error) {
+	payload := &AlarmCallbackPayload{}
+	if err := json.Unmarshal(b, payload); err != nil {
+		return nil, fmt.Errorf("failed to decode the alarm callback payload: %v", err)
+	}
+	return payload, nil
+}
+
+// DecodeEventNotification decodes the payload of the HTTP event notification.
+func DecodeEventNotification(b []byte) (*EventNotificationPayload, error) {
+	payload := &EventNotificationPayload{}
+	if err := json.Unmarshal(b, payload); err != nil {
+		return nil, fmt.Errorf("failed to decode the event notification payload: %v", err)
+	}
+	return payload, nil
+}
+
+func (h *Handler) checkSecret(req *http.Request) bool {
+	if h.Secret == "" {
+		return true
+	}
+	s := req.URL.Query().Get(SecretQueryParam)
+	if s == "" {
+		s = req.Header.Get(SecretHeader)
+	}
+	return subtle.ConstantTimeCompare([]byte(s), []byte(h.Secret)) == 1
+}
+
+func (h *Handler) maxBodySize() int64 {
+	if h.MaxBodySize <= 0 {
+		return DefaultMaxBodySize
+	}
+	return h.MaxBodySize
+}
+
+func (h *Handler) error(w http.ResponseWriter, req *http.Request, code int, err error) {
+	if h.OnError != nil {
+		h.OnError(req, err)
+	}
+	http.Error(w, http.StatusText(code), code)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.error(w, req, http.StatusMethodNotAllowed, fmt.Errorf("method %s isn't allowed", req.Method))
+		return
+	}
+	if !h.checkSecret(req) {
+		h.error(w, req, http.StatusUnauthorized, fmt.Errorf("the secret is invalid"))
+		return
+	}
+	size := h.maxBodySize()
+	if req.ContentLength > size {
+		h.error(w, req, http.StatusRequestEntityTooLarge, fmt.Errorf("the request body is larger than %d bytes", size))
+		return
+	}
+	b, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, size))
+	if err != nil {
+		// MaxBytesReader returns an error after reading the limit
+		if int64(len(b)) == size {
+			h.error(w, req, http.StatusRequestEntityTooLarge, fmt.Errorf("the request body is larger than %d bytes", size))
+			return
+		}
+		h.error(w, req, http.StatusBadRequest, fmt.Errorf("failed to read the request body: %v", err))
+		return
+	}
+	t, err := PayloadType(b)
+	if err != nil {
+		h.error(w, req, http.StatusBadRequest, err)
+		return
+	}
+	ctx := req.Context()
+	var cbErr error
+	switch t {
+	case PayloadTypeAlarmCallback:
+		if h.OnAlarmCallback == nil {
+			h.error(w, req, http.StatusBadRequest, fmt.Errorf("alarm callback isn't supported"))
+			return
+		}
+		payload, err := DecodeAlarmCallback(b)
+		if err != nil {
+			h.error(w, req, http.StatusBadRequest, err)
+			return
+		}
+		cbErr = h.OnAlarmCallback(ctx, payload)
+	case PayloadTypeEventNotification:
+		if h.OnEventNotification == nil {
+			h.error(w, req, http.StatusBadRequest, fmt.Errorf("event notification isn't supported"))
+			return
+		}
+		payload, err := DecodeEventNotification(b)
+		if err != nil {
+			h.error(w, req, http.StatusBadRequest, err)
+			return
+		}
+		cbErr = h.OnEventNotification(ctx, payload)
+	}
+	if cbErr != nil {
+		h.error(w, req, http.StatusInternalServerError, cbErr)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
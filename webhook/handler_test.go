@@ -0,0 +1,124 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/webhook"
+	"github.com/suzuki-shunsuke/go-graylog/webhook/webhooktest"
+)
+
+func TestPayloadType(t *testing.T) {
+	typ, err := webhook.PayloadType([]byte(webhooktest.AlarmCallbackJSON))
+	require.Nil(t, err)
+	require.Equal(t, webhook.PayloadTypeAlarmCallback, typ)
+	typ, err = webhook.PayloadType([]byte(webhooktest.EventNotificationJSON))
+	require.Nil(t, err)
+	require.Equal(t, webhook.PayloadTypeEventNotification, typ)
+	_, err = webhook.PayloadType([]byte(`{}`))
+	require.NotNil(t, err)
+	_, err = webhook.PayloadType([]byte(`foo`))
+	require.NotNil(t, err)
+}
+
+func TestDecodeAlarmCallback(t *testing.T) {
+	p := webhooktest.AlarmCallbackPayload()
+	require.Equal(t, "5d84c1a92ab79c000d35d6ca", p.Stream.ID)
+	require.True(t, p.CheckResult.Triggered)
+	require.Equal(t, "too many messages", p.CheckResult.TriggeredCondition.Title)
+	require.Equal(t, graylog.MessageCountAlertConditionParameters{
+		Backlog: 1, Query: "*", ThresholdType: "MORE", Threshold: 1, Time: 1,
+	}, p.CheckResult.TriggeredCondition.Parameters)
+	require.Len(t, p.CheckResult.MatchingMessages, 1)
+	require.Equal(t, "hello", p.CheckResult.MatchingMessages[0].Message)
+	require.Equal(t, float64(6), p.CheckResult.MatchingMessages[0].Fields["level"])
+}
+
+func TestDecodeEventNotification(t *testing.T) {
+	p := webhooktest.EventNotificationPayload()
+	require.Equal(t, "too many errors", p.EventDefinitionTitle)
+	require.Equal(t, 2, p.Event.Priority)
+	require.True(t, p.Event.Alert)
+	require.Equal(t, []string{"5d84c1a92ab79c000d35d6ca"}, p.Event.SourceStreams)
+}
+
+func TestHandler(t *testing.T) {
+	rec := &webhooktest.Recorder{}
+	h := rec.Handler("password")
+
+	resp := webhooktest.Serve(h, "password", webhooktest.AlarmCallbackJSON)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Len(t, rec.AlarmCallbacks(), 1)
+	require.Equal(t, "test", rec.AlarmCallbacks()[0].Stream.Title)
+
+	req := webhooktest.NewRequest("", webhooktest.EventNotificationJSON)
+	req.Header.Set(webhook.SecretHeader, "password")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, rec.EventNotifications(), 1)
+
+	resp, err := webhooktest.ServePayload(h, "password", webhooktest.EventNotificationPayload())
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, webhooktest.EventNotificationPayload(), rec.EventNotifications()[1])
+
+	resp = webhooktest.Serve(h, "invalid", webhooktest.AlarmCallbackJSON)
+	require.Equal(t, http.StatusUnauthorized, resp.Code)
+	require.Len(t, rec.AlarmCallbacks(), 1)
+
+	resp = webhooktest.Serve(h, "password", `{}`)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?secret=password", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	rec.Err = errors.New("failed to notify")
+	resp = webhooktest.Serve(h, "password", webhooktest.AlarmCallbackJSON)
+	require.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
+func TestHandler_maxBodySize(t *testing.T) {
+	rec := &webhooktest.Recorder{}
+	h := rec.Handler("")
+	h.MaxBodySize = int64(len(webhooktest.AlarmCallbackJSON))
+
+	resp := webhooktest.Serve(h, "", webhooktest.AlarmCallbackJSON)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	resp = webhooktest.Serve(h, "", webhooktest.AlarmCallbackJSON+" ")
+	require.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+
+	// the body whose size is unknown
+	req := webhooktest.NewRequest("", webhooktest.AlarmCallbackJSON+" ")
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	require.Len(t, rec.AlarmCallbacks(), 1)
+}
+
+func TestHandler_unsupported(t *testing.T) {
+	var handlerErr error
+	h := &webhook.Handler{
+		OnAlarmCallback: func(ctx context.Context, p *webhook.AlarmCallbackPayload) error {
+			return nil
+		},
+		OnError: func(req *http.Request, err error) {
+			handlerErr = err
+		},
+	}
+	resp := webhooktest.Serve(h, "", webhooktest.AlarmCallbackJSON)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Nil(t, handlerErr)
+	resp = webhooktest.Serve(h, "", webhooktest.EventNotificationJSON)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	require.NotNil(t, handlerErr)
+}
@@ -0,0 +1,9 @@
+/*
+Package webhooktest provides utilities to test webhook.Handler without Graylog.
+
+	rec := &webhooktest.Recorder{}
+	h := rec.Handler("secret")
+	resp := webhooktest.Serve(h, "secret", webhooktest.AlarmCallbackJSON)
+	// resp.Code == 200, len(rec.AlarmCallbacks()) == 1
+*/
+package webhooktest
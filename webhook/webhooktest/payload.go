@@ -0,0 +1,114 @@
+package webhooktest
+
+import (
+	"github.com/suzuki-shunsuke/go-graylog/webhook"
+)
+
+const (
+	// AlarmCallbackJSON is an example payload of Graylog's HTTP alarm callback.
+	AlarmCallbackJSON = `{
+  "check_result": {
+    "result_description": "Stream had 2 messages in the last 1 minutes with trigger condition more than 1 messages. (Current grace time: 0 minutes)",
+    "triggered_condition": {
+      "id": "6d3aafd0-b277-4b55-bfd9-f4a000000000",
+      "type": "message_count",
+      "created_at": "2019-09-20T12:10:17.792Z",
+      "creator_user_id": "admin",
+      "grace": 0,
+      "parameters": {
+        "backlog": 1,
+        "repeat_notifications": false,
+        "query": "*",
+        "grace": 0,
+        "threshold_type": "MORE",
+        "threshold": 1,
+        "time": 1
+      },
+      "description": "time: 1, threshold_type: more, threshold: 1, grace: 0, repeat notifications: false",
+      "type_string": "message_count",
+      "backlog": 1,
+      "repeat_notifications": false,
+      "title": "too many messages"
+    },
+    "triggered_at": "2019-09-20T12:20:09.287Z",
+    "triggered": true,
+    "matching_messages": [
+      {
+        "index": "graylog_0",
+        "message": "hello",
+        "fields": {
+          "level": 6,
+          "gl2_remote_ip": "127.0.0.1"
+        },
+        "id": "b0dcfe20-db9b-11e9-a0b9-0242ac120004",
+        "stream_ids": ["5d84c1a92ab79c000d35d6ca"],
+        "source": "example.com",
+        "timestamp": "2019-09-20T12:20:05.424Z"
+      }
+    ]
+  },
+  "stream": {
+    "creator_user_id": "admin",
+    "outputs": [],
+    "matching_type": "AND",
+    "description": "test",
+    "created_at": "2019-09-20T12:09:45.392Z",
+    "disabled": false,
+    "rules": [],
+    "alert_conditions": [],
+    "title": "test",
+    "content_pack": null,
+    "remove_matches_from_default_stream": false,
+    "index_set_id": "5d84c1a92ab79c000d35d6c0",
+    "id": "5d84c1a92ab79c000d35d6ca"
+  }
+}`
+
+	// EventNotificationJSON is an example payload of Graylog's HTTP event notification.
+	EventNotificationJSON = `{
+  "event_definition_id": "5d84c5d92ab79c000d35e400",
+  "event_definition_type": "aggregation-v1",
+  "event_definition_title": "too many errors",
+  "event_definition_description": "",
+  "job_definition_id": "5d84c5d92ab79c000d35e402",
+  "job_trigger_id": "5d84c6052ab79c000d35e430",
+  "event": {
+    "id": "01DN8AQ4HQ3N0W4WD2Y3ZQAGPM",
+    "event_definition_type": "aggregation-v1",
+    "event_definition_id": "5d84c5d92ab79c000d35e400",
+    "origin_context": "urn:graylog:message:es:graylog_0:b0dcfe20-db9b-11e9-a0b9-0242ac120004",
+    "timestamp": "2019-09-20T12:20:05.424Z",
+    "timestamp_processing": "2019-09-20T12:20:10.231Z",
+    "timerange_start": null,
+    "timerange_end": null,
+    "streams": ["5d84c1a92ab79c000d35d6ca"],
+    "source_streams": ["5d84c1a92ab79c000d35d6ca"],
+    "message": "too many errors",
+    "source": "graylog",
+    "key_tuple": [],
+    "key": "",
+    "priority": 2,
+    "alert": true,
+    "fields": {}
+  },
+  "backlog": []
+}`
+)
+
+// AlarmCallbackPayload returns the decoded AlarmCallbackJSON.
+func AlarmCallbackPayload() *webhook.AlarmCallbackPayload {
+	p, err := webhook.DecodeAlarmCallback([]byte(AlarmCallbackJSON))
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// EventNotificationPayload returns the decoded EventNotificationJSON.
+func EventNotificationPayload() *webhook.EventNotificationPayload {
+	p, err := webhook.DecodeEventNotification([]byte(EventNotificationJSON))
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
@@ -0,0 +1,84 @@
+package webhooktest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/suzuki-shunsuke/go-graylog/webhook"
+)
+
+// Recorder records the payloads which a webhook.Handler receives.
+type Recorder struct {
+	// Err is returned by the callbacks. It is useful to test the error handling.
+	Err error
+
+	mutex              sync.Mutex
+	alarmCallbacks     []*webhook.AlarmCallbackPayload
+	eventNotifications []*webhook.EventNotificationPayload
+}
+
+// Handler returns a webhook.Handler which records the payloads.
+func (rec *Recorder) Handler(secret string) *webhook.Handler {
+	return &webhook.Handler{
+		Secret: secret,
+		OnAlarmCallback: func(ctx context.Context, p *webhook.AlarmCallbackPayload) error {
+			rec.mutex.Lock()
+			defer rec.mutex.Unlock()
+			rec.alarmCallbacks = append(rec.alarmCallbacks, p)
+			return rec.Err
+		},
+		OnEventNotification: func(ctx context.Context, p *webhook.EventNotificationPayload) error {
+			rec.mutex.Lock()
+			defer rec.mutex.Unlock()
+			rec.eventNotifications = append(rec.eventNotifications, p)
+			return rec.Err
+		},
+	}
+}
+
+// AlarmCallbacks returns the received alarm callback payloads.
+func (rec *Recorder) AlarmCallbacks() []*webhook.AlarmCallbackPayload {
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	return append([]*webhook.AlarmCallbackPayload{}, rec.alarmCallbacks...)
+}
+
+// EventNotifications returns the received event notification payloads.
+func (rec *Recorder) EventNotifications() []*webhook.EventNotificationPayload {
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	return append([]*webhook.EventNotificationPayload{}, rec.eventNotifications...)
+}
+
+// NewRequest returns a webhook request as Graylog sends.
+// If the secret isn't empty, it is set to the query parameter.
+func NewRequest(secret, body string) *http.Request {
+	target := "/"
+	if secret != "" {
+		target += "?" + url.Values{webhook.SecretQueryParam: []string{secret}}.Encode()
+	}
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// Serve sends a webhook request to the handler in process and returns the response.
+func Serve(h http.Handler, secret, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, NewRequest(secret, body))
+	return w
+}
+
+// ServePayload encodes the payload as JSON and sends it to the handler in process.
+func ServePayload(h http.Handler, secret string, payload interface{}) (*httptest.ResponseRecorder, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return Serve(h, secret, string(b)), nil
+}